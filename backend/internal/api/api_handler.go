@@ -3,14 +3,18 @@ package api
 import (
 	"net/http"
 
+	kh "github.com/durianpay/fullstack-boilerplate/internal/module/apikey/handler"
 	ah "github.com/durianpay/fullstack-boilerplate/internal/module/auth/handler"
 	ph "github.com/durianpay/fullstack-boilerplate/internal/module/payment/handler"
+	uh "github.com/durianpay/fullstack-boilerplate/internal/module/user/handler"
 	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
 )
 
 type APIHandler struct {
+	APIKey  *kh.APIKeyHandler
 	Auth    *ah.AuthHandler
 	Payment *ph.PaymentHandler
+	User    *uh.UserHandler
 }
 
 var _ openapigen.ServerInterface = (*APIHandler)(nil)
@@ -23,6 +27,178 @@ func (h *APIHandler) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.R
 	h.Auth.PostDashboardV1AuthRefresh(w, r)
 }
 
+func (h *APIHandler) PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthLogout(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthLoginMfa(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthLoginMfa(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthLoginMfaEnroll(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthLoginMfaEnroll(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthSsoAuthorize(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthSsoAuthorize(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthSsoCallback(w http.ResponseWriter, r *http.Request, params openapigen.PostDashboardV1AuthSsoCallbackParams) {
+	h.Auth.PostDashboardV1AuthSsoCallback(w, r, params)
+}
+
+func (h *APIHandler) PostDashboardV1AuthMfaEnroll(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthMfaEnroll(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthMfaActivate(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthMfaActivate(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthMfaDisable(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthMfaDisable(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthMfaRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthMfaRecoveryCodes(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthPassword(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthPassword(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthPasswordForgot(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthPasswordForgot(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthPasswordReset(w, r)
+}
+
+func (h *APIHandler) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+	h.Auth.GetWellKnownJwksJson(w, r)
+}
+
+func (h *APIHandler) GetDashboardV1AuthKeys(w http.ResponseWriter, r *http.Request) {
+	h.Auth.GetDashboardV1AuthKeys(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1AuthKeysRotate(w http.ResponseWriter, r *http.Request) {
+	h.Auth.PostDashboardV1AuthKeysRotate(w, r)
+}
+
+func (h *APIHandler) GetDashboardV1AuthSecurityEvents(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1AuthSecurityEventsParams) {
+	h.Auth.GetDashboardV1AuthSecurityEvents(w, r, params)
+}
+
+func (h *APIHandler) GetDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request) {
+	h.Auth.GetDashboardV1AuthSessions(w, r)
+}
+
+func (h *APIHandler) DeleteDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request) {
+	h.Auth.DeleteDashboardV1AuthSessions(w, r)
+}
+
+func (h *APIHandler) DeleteDashboardV1AuthSessionsSessionId(w http.ResponseWriter, r *http.Request, sessionId string) {
+	h.Auth.DeleteDashboardV1AuthSessionsSessionId(w, r, sessionId)
+}
+
+func (h *APIHandler) GetDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request) {
+	h.User.GetDashboardV1UsersProfile(w, r)
+}
+
+func (h *APIHandler) PatchDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request) {
+	h.User.PatchDashboardV1UsersProfile(w, r)
+}
+
+func (h *APIHandler) GetDashboardV1Users(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1UsersParams) {
+	h.User.GetDashboardV1Users(w, r, params)
+}
+
+func (h *APIHandler) PostDashboardV1Users(w http.ResponseWriter, r *http.Request) {
+	h.User.PostDashboardV1Users(w, r)
+}
+
+func (h *APIHandler) DeleteDashboardV1UsersId(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	h.User.DeleteDashboardV1UsersId(w, r, id)
+}
+
+func (h *APIHandler) PatchDashboardV1UsersIdRole(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	h.User.PatchDashboardV1UsersIdRole(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1UsersIdDeactivate(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	h.User.PostDashboardV1UsersIdDeactivate(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1UsersIdReactivate(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	h.User.PostDashboardV1UsersIdReactivate(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1UsersIdUnlock(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	h.User.PostDashboardV1UsersIdUnlock(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1UsersIdImpersonate(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	h.User.PostDashboardV1UsersIdImpersonate(w, r, id)
+}
+
+func (h *APIHandler) GetDashboardV1RolePolicies(w http.ResponseWriter, r *http.Request) {
+	h.User.GetDashboardV1RolePolicies(w, r)
+}
+
+func (h *APIHandler) PutDashboardV1RolePoliciesRole(w http.ResponseWriter, r *http.Request, role openapigen.Role) {
+	h.User.PutDashboardV1RolePoliciesRole(w, r, role)
+}
+
+func (h *APIHandler) GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+	h.APIKey.GetDashboardV1ApiKeys(w, r)
+}
+
+func (h *APIHandler) PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+	h.APIKey.PostDashboardV1ApiKeys(w, r)
+}
+
+func (h *APIHandler) DeleteDashboardV1ApiKeysId(w http.ResponseWriter, r *http.Request, id string) {
+	h.APIKey.DeleteDashboardV1ApiKeysId(w, r, id)
+}
+
 func (h *APIHandler) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1PaymentsParams) {
 	h.Payment.GetDashboardV1Payments(w, r, params)
 }
+
+func (h *APIHandler) GetDashboardV1PaymentsSummary(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1PaymentsSummaryParams) {
+	h.Payment.GetDashboardV1PaymentsSummary(w, r, params)
+}
+
+func (h *APIHandler) GetDashboardV1PaymentsId(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId) {
+	h.Payment.GetDashboardV1PaymentsId(w, r, id)
+}
+
+func (h *APIHandler) GetDashboardV1PaymentsIdEvents(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId) {
+	h.Payment.GetDashboardV1PaymentsIdEvents(w, r, id)
+}
+
+func (h *APIHandler) GetDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId) {
+	h.Payment.GetDashboardV1PaymentsIdRefunds(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId) {
+	h.Payment.PostDashboardV1PaymentsIdRefunds(w, r, id)
+}
+
+func (h *APIHandler) PostDashboardV1PaymentsIdRefundsRefundIdApprove(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId, refundId openapigen.RefundId) {
+	h.Payment.PostDashboardV1PaymentsIdRefundsRefundIdApprove(w, r, id, refundId)
+}
+
+func (h *APIHandler) PostDashboardV1PaymentsIdRefundsRefundIdReject(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId, refundId openapigen.RefundId) {
+	h.Payment.PostDashboardV1PaymentsIdRefundsRefundIdReject(w, r, id, refundId)
+}
+
+func (h *APIHandler) PostDashboardV1Payments(w http.ResponseWriter, r *http.Request, params openapigen.PostDashboardV1PaymentsParams) {
+	h.Payment.PostDashboardV1Payments(w, r, params)
+}
+
+func (h *APIHandler) PatchDashboardV1PaymentsIdStatus(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId, params openapigen.PatchDashboardV1PaymentsIdStatusParams) {
+	h.Payment.PatchDashboardV1PaymentsIdStatus(w, r, id, params)
+}
@@ -1,7 +1,10 @@
 package config
 
 import (
+	"log"
 	"os"
+	"strconv"
+	"time"
 
 	_ "github.com/mattn/go-sqlite3"
 )
@@ -9,9 +12,44 @@ import (
 var (
 	JwtSecret           = []byte(getEnv("JWT_SECRET", "dev-secret-replace-me"))
 	JwtExpired          = getEnv("JWT_EXPIRED", "24h")
+	JwtSigningAlg       = getEnv("JWT_SIGNING_ALG", "EdDSA")
+	JwtKeysDir          = getEnv("JWT_KEYS_DIR", "keys")
+	JwtAcceptHS256      = getEnvBool("JWT_ACCEPT_HS256", true)
+	ImpersonationTTL    = getEnvDuration("IMPERSONATION_TTL", 15*time.Minute)
 	HttpAddress         = getEnv("HTTP_ADDR", ":8080")
 	OpenapiYamlLocation = getEnv("OPENAPIYAML_LOCATION", "../openapi.yaml")
 	RedisAddr           = getEnv("REDIS_ADDR", "localhost:6379")
+	MailOutboxFile      = getEnv("MAIL_OUTBOX_FILE", "")
+	PasswordResetURL    = getEnv("PASSWORD_RESET_URL", "http://localhost:5173/reset-password")
+
+	// Login throttling, see usecase.LoginLockout
+	LoginBackoffAfter    = getEnvInt("LOGIN_BACKOFF_AFTER", 3)
+	LoginBackoffBase     = getEnvDuration("LOGIN_BACKOFF_BASE", time.Second)
+	LoginMaxAttempts     = getEnvInt("LOGIN_MAX_ATTEMPTS", 10)
+	LoginIPMaxAttempts   = getEnvInt("LOGIN_IP_MAX_ATTEMPTS", 50)
+	LoginLockoutDuration = getEnvDuration("LOGIN_LOCKOUT_DURATION", 15*time.Minute)
+
+	// Password hashing and policy, see the password service
+	PasswordHashAlg          = getEnv("PASSWORD_HASH_ALG", "bcrypt")
+	PasswordBcryptCost       = getEnvInt("PASSWORD_BCRYPT_COST", 12)
+	PasswordMinLength        = getEnvInt("PASSWORD_MIN_LENGTH", 8)
+	PasswordMinClasses       = getEnvInt("PASSWORD_MIN_CLASSES", 3)
+	PasswordBreachedListFile = getEnv("PASSWORD_BREACHED_LIST_FILE", "")
+
+	// OpenID Connect single sign-on, disabled while OIDC_ISSUER is empty
+	OIDCIssuer        = getEnv("OIDC_ISSUER", "")
+	OIDCClientID      = getEnv("OIDC_CLIENT_ID", "")
+	OIDCClientSecret  = getEnv("OIDC_CLIENT_SECRET", "")
+	OIDCRedirectURL   = getEnv("OIDC_REDIRECT_URL", "http://localhost:5173/auth/callback")
+	OIDCScopes        = getEnv("OIDC_SCOPES", "openid email profile")
+	OIDCGroupsClaim   = getEnv("OIDC_GROUPS_CLAIM", "groups")
+	OIDCRoleMapping   = getEnv("OIDC_ROLE_MAPPING", "")
+	OIDCDefaultRole   = getEnv("OIDC_DEFAULT_ROLE", "")
+	OIDCAutoProvision = getEnvBool("OIDC_AUTO_PROVISION", false)
+	OIDCMFAACRValues  = getEnv("OIDC_MFA_ACR_VALUES", "")
+
+	// Exchange rates, upserted from a CSV or JSON file on startup when set
+	FXRatesFile = getEnv("FX_RATES_FILE", "")
 )
 
 func getEnv(key, fallback string) string {
@@ -20,3 +58,39 @@ func getEnv(key, fallback string) string {
 	}
 	return fallback
 }
+
+func getEnvInt(key string, fallback int) int {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	n, err := strconv.Atoi(v)
+	if err != nil {
+		log.Fatalf("config: %s must be an integer: %v", key, err)
+	}
+	return n
+}
+
+func getEnvDuration(key string, fallback time.Duration) time.Duration {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	d, err := time.ParseDuration(v)
+	if err != nil {
+		log.Fatalf("config: %s must be a duration: %v", key, err)
+	}
+	return d
+}
+
+func getEnvBool(key string, fallback bool) bool {
+	v := os.Getenv(key)
+	if v == "" {
+		return fallback
+	}
+	b, err := strconv.ParseBool(v)
+	if err != nil {
+		log.Fatalf("config: %s must be a boolean: %v", key, err)
+	}
+	return b
+}
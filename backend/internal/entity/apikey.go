@@ -0,0 +1,41 @@
+package entity
+
+import "time"
+
+// Scopes an API key can be granted. Operations list the scopes they need in x-scopes.
+const (
+	ScopePaymentsRead  = "payments:read"
+	ScopePaymentsWrite = "payments:write"
+)
+
+// ValidAPIKeyScope reports whether scope is one that can be granted to an API key.
+func ValidAPIKeyScope(scope string) bool {
+	switch scope {
+	case ScopePaymentsRead, ScopePaymentsWrite:
+		return true
+	}
+	return false
+}
+
+// APIKey is a credential for machine clients. It acts as its owner, limited to its scopes.
+// Only a hash of the secret is stored; Prefix is the public part used to look the key up.
+type APIKey struct {
+	ID         string
+	Prefix     string
+	KeyHash    string `json:"-"`
+	Label      string
+	Owner      *User
+	Scopes     []string
+	CreatedAt  time.Time
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+}
+
+// NewAPIKey holds what a superuser chooses when creating a key.
+type NewAPIKey struct {
+	Label     string
+	OwnerID   string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
@@ -2,6 +2,7 @@ package entity
 
 import (
 	"fmt"
+	"time"
 )
 
 // Code type (string for readability)
@@ -12,7 +13,13 @@ const (
 	ErrorCodeInternal     Code = "internal_error"
 	ErrorCodeNotFound     Code = "not_found"
 	ErrorCodeUnauthorized Code = "unauthorized"
+	ErrorCodeForbidden    Code = "forbidden"
 	ErrorCodeBadRequest   Code = "bad_request"
+	ErrorCodeConflict     Code = "conflict"
+	ErrorCodeTooMany      Code = "too_many_requests"
+	// optimistic concurrency: the client's If-Match is stale, or missing
+	ErrorCodePreconditionFailed   Code = "precondition_failed"
+	ErrorCodePreconditionRequired Code = "precondition_required"
 )
 
 type AppError struct {
@@ -20,6 +27,8 @@ type AppError struct {
 	Message string `json:"message"`
 	Err     error  `json:"-"`
 	Details any    `json:"details,omitempty"`
+	// RetryAfter tells the client how long to back off (sent as Retry-After)
+	RetryAfter time.Duration `json:"-"`
 }
 
 func (e *AppError) Error() string {
@@ -35,7 +44,7 @@ func NewError(code Code, message string) *AppError {
 
 func WrapError(err error, code Code, message string) *AppError {
 	if app, ok := err.(*AppError); ok {
-		return &AppError{Code: app.Code, Message: app.Message, Err: app.Err, Details: app.Details}
+		return &AppError{Code: app.Code, Message: app.Message, Err: app.Err, Details: app.Details, RetryAfter: app.RetryAfter}
 	}
 	return &AppError{Code: code, Message: message, Err: err}
 }
@@ -43,5 +52,17 @@ func WrapError(err error, code Code, message string) *AppError {
 // Convenience constructors
 func ErrorNotFound(msg string) *AppError     { return NewError(ErrorCodeNotFound, msg) }
 func ErrorUnauthorized(msg string) *AppError { return NewError(ErrorCodeUnauthorized, msg) }
+func ErrorForbidden(msg string) *AppError    { return NewError(ErrorCodeForbidden, msg) }
 func ErrorInternal(msg string) *AppError     { return NewError(ErrorCodeInternal, msg) }
 func ErrorBadRequest(msg string) *AppError   { return NewError(ErrorCodeBadRequest, msg) }
+func ErrorConflict(msg string) *AppError     { return NewError(ErrorCodeConflict, msg) }
+func ErrorPreconditionFailed(msg string) *AppError {
+	return NewError(ErrorCodePreconditionFailed, msg)
+}
+func ErrorPreconditionRequired(msg string) *AppError {
+	return NewError(ErrorCodePreconditionRequired, msg)
+}
+
+func ErrorTooManyRequests(msg string, retryAfter time.Duration) *AppError {
+	return &AppError{Code: ErrorCodeTooMany, Message: msg, RetryAfter: retryAfter}
+}
@@ -0,0 +1,74 @@
+package entity
+
+import (
+	"math/big"
+	"strings"
+	"time"
+)
+
+// FXRate is the price of one unit of Base in Quote, in force from EffectiveFrom
+// until the next rate for the same pair takes over.
+type FXRate struct {
+	Base          string
+	Quote         string
+	Rate          string // decimal, e.g. "16250.50"
+	EffectiveFrom time.Time
+}
+
+// ParseRate parses a positive decimal exchange rate exactly.
+func ParseRate(s string) (*big.Rat, error) {
+	if !isDecimal(s) {
+		return nil, ErrorBadRequest("rate must be a decimal number")
+	}
+	rate, ok := new(big.Rat).SetString(s)
+	if !ok || rate.Sign() <= 0 {
+		return nil, ErrorBadRequest("rate must be greater than zero")
+	}
+	return rate, nil
+}
+
+// FormatRate formats a rate as a decimal, cut to 12 places when it does not end sooner.
+func FormatRate(rate *big.Rat) string {
+	s := rate.FloatString(12)
+	s = strings.TrimRight(s, "0")
+	return strings.TrimSuffix(s, ".")
+}
+
+// Convert converts m into currency at rate, the price of one unit of m's currency.
+// The result is rounded half away from zero to the minor unit of currency.
+func (m Money) Convert(currency string, rate *big.Rat) (Money, error) {
+	from, ok := CurrencyExponent(m.Currency)
+	if !ok {
+		return Money{}, ErrorBadRequest("unsupported currency " + m.Currency)
+	}
+	to, ok := CurrencyExponent(currency)
+	if !ok {
+		return Money{}, ErrorBadRequest("unsupported currency " + currency)
+	}
+
+	// minor units of m → major units → major units of currency → its minor units
+	v := new(big.Rat).SetInt64(m.Amount)
+	v.Mul(v, rate)
+	scale := new(big.Rat).SetInt(new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(abs(to-from))), nil))
+	if to > from {
+		v.Mul(v, scale)
+	} else {
+		v.Quo(v, scale)
+	}
+
+	q, r := new(big.Int).QuoRem(v.Num(), v.Denom(), new(big.Int))
+	if r.Sign() != 0 && new(big.Int).Mul(new(big.Int).Abs(r), big.NewInt(2)).Cmp(v.Denom()) >= 0 {
+		q.Add(q, big.NewInt(int64(v.Sign())))
+	}
+	if !q.IsInt64() {
+		return Money{}, ErrorBadRequest("amount is too large")
+	}
+	return Money{Amount: q.Int64(), Currency: currency}, nil
+}
+
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
@@ -0,0 +1,10 @@
+package entity
+
+import "time"
+
+// Impersonation is an access token a superuser was given to act as User.
+type Impersonation struct {
+	User        *User
+	AccessToken string
+	ExpiresAt   time.Time
+}
@@ -0,0 +1,28 @@
+package entity
+
+// LoginResult is the outcome of a login step. When a second factor is still
+// needed only MFAToken is set, and the client completes the login with it.
+type LoginResult struct {
+	User         *User
+	AccessToken  string
+	RefreshToken string
+
+	MFAToken string
+	// MFAEnrollmentRequired means the user's role mandates 2FA but the user
+	// has not set it up yet; they must enrol before the login can finish.
+	MFAEnrollmentRequired bool
+	// RecoveryCodes are returned once, when a login completed an enrolment.
+	RecoveryCodes []string
+}
+
+// MFAEnrollment is a TOTP secret waiting to be confirmed with a first code.
+type MFAEnrollment struct {
+	Secret string
+	URI    string
+}
+
+// RolePolicy holds the security settings a superuser can enforce for a role.
+type RolePolicy struct {
+	Role        string
+	MFARequired bool
+}
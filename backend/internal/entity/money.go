@@ -0,0 +1,153 @@
+package entity
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// DefaultCurrency is the currency of payments recorded before currencies were tracked.
+const DefaultCurrency = "IDR"
+
+// currencyExponents are the ISO 4217 minor unit digits of the supported currencies.
+var currencyExponents = map[string]int{
+	"AUD": 2,
+	"EUR": 2,
+	"GBP": 2,
+	"IDR": 2,
+	"JPY": 0,
+	"KRW": 0,
+	"MYR": 2,
+	"SGD": 2,
+	"USD": 2,
+}
+
+// CurrencyExponent returns the number of minor unit digits of an ISO 4217 currency code.
+func CurrencyExponent(currency string) (int, bool) {
+	exp, ok := currencyExponents[currency]
+	return exp, ok
+}
+
+// Currencies returns the supported currency codes, sorted.
+func Currencies() []string {
+	codes := make([]string, 0, len(currencyExponents))
+	for c := range currencyExponents {
+		codes = append(codes, c)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Money is an amount in the minor units of its currency (cents for USD), so
+// arithmetic and comparisons stay exact.
+type Money struct {
+	Amount   int64
+	Currency string
+}
+
+// ParseMoney parses a decimal amount such as "50000.00" in the given currency.
+func ParseMoney(amount, currency string) (Money, error) {
+	exp, ok := CurrencyExponent(currency)
+	if !ok {
+		return Money{}, ErrorBadRequest("unsupported currency " + strconv.Quote(currency))
+	}
+	minor, err := ParseMinorUnits(amount, exp)
+	if err != nil {
+		return Money{}, err
+	}
+	return Money{Amount: minor, Currency: currency}, nil
+}
+
+// ParseMinorUnits parses a non-negative decimal with at most exponent decimals
+// into an integer count of 10^-exponent units: "12.5" at exponent 2 is 1250.
+func ParseMinorUnits(s string, exponent int) (int64, error) {
+	if !isDecimal(s) {
+		return 0, ErrorBadRequest("amount must be a decimal number")
+	}
+	whole, frac, _ := strings.Cut(s, ".")
+	if len(frac) > exponent {
+		return 0, ErrorBadRequest("amount has more than " + strconv.Itoa(exponent) + " decimal places")
+	}
+
+	digits := strings.TrimLeft(whole+frac+strings.Repeat("0", exponent-len(frac)), "0")
+	if digits == "" {
+		return 0, nil
+	}
+	v, err := strconv.ParseInt(digits, 10, 64)
+	if err != nil {
+		return 0, ErrorBadRequest("amount is too large")
+	}
+	return v, nil
+}
+
+// isDecimal reports whether s is digits with an optional fraction, like "12" or "12.50".
+func isDecimal(s string) bool {
+	whole, frac, hasPoint := strings.Cut(s, ".")
+	return whole != "" && isDigits(whole) && isDigits(frac) && (!hasPoint || frac != "")
+}
+
+func isDigits(s string) bool {
+	for _, r := range s {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// String formats the amount as a decimal with the currency's number of decimals.
+func (m Money) String() string {
+	exp, ok := CurrencyExponent(m.Currency)
+	if !ok {
+		exp = 2 // the ISO 4217 default
+	}
+
+	abs, negative := strings.CutPrefix(strconv.FormatInt(m.Amount, 10), "-")
+	sign := ""
+	if negative {
+		sign = "-"
+	}
+	if exp == 0 {
+		return sign + abs
+	}
+	if len(abs) <= exp {
+		abs = strings.Repeat("0", exp-len(abs)+1) + abs
+	}
+	return sign + abs[:len(abs)-exp] + "." + abs[len(abs)-exp:]
+}
+
+// Add sums two amounts of the same currency.
+func (m Money) Add(o Money) (Money, error) {
+	if m.Currency != o.Currency {
+		return Money{}, ErrorBadRequest("cannot add " + o.Currency + " to " + m.Currency)
+	}
+	if (o.Amount > 0 && m.Amount > math.MaxInt64-o.Amount) || (o.Amount < 0 && m.Amount < math.MinInt64-o.Amount) {
+		return Money{}, ErrorBadRequest("amount is too large")
+	}
+	return Money{Amount: m.Amount + o.Amount, Currency: m.Currency}, nil
+}
+
+type moneyJSON struct {
+	Amount   string `json:"amount"`
+	Currency string `json:"currency"`
+}
+
+// MarshalJSON encodes the amount as a decimal string, as the API shows it.
+func (m Money) MarshalJSON() ([]byte, error) {
+	return json.Marshal(moneyJSON{Amount: m.String(), Currency: m.Currency})
+}
+
+func (m *Money) UnmarshalJSON(data []byte) error {
+	var v moneyJSON
+	if err := json.Unmarshal(data, &v); err != nil {
+		return err
+	}
+	parsed, err := ParseMoney(v.Amount, v.Currency)
+	if err != nil {
+		return err
+	}
+	*m = parsed
+	return nil
+}
@@ -0,0 +1,118 @@
+package entity
+
+import (
+	"testing"
+)
+
+func TestParseMoneyRoundTrip(t *testing.T) {
+	tests := []struct {
+		currency string
+		in       string
+		minor    int64
+		out      string
+	}{
+		{"JPY", "500", 500, "500"},
+		{"JPY", "0", 0, "0"},
+		{"IDR", "50000", 5000000, "50000.00"},
+		{"IDR", "50000.5", 5000050, "50000.50"},
+		{"IDR", "0.05", 5, "0.05"},
+		{"USD", "99.99", 9999, "99.99"},
+		{"USD", "0.01", 1, "0.01"},
+		{"USD", "007.10", 710, "7.10"},
+		{"USD", "92233720368547758.07", 9223372036854775807, "92233720368547758.07"},
+	}
+	for _, tt := range tests {
+		m, err := ParseMoney(tt.in, tt.currency)
+		if err != nil {
+			t.Errorf("ParseMoney(%q, %s): %v", tt.in, tt.currency, err)
+			continue
+		}
+		if m.Amount != tt.minor {
+			t.Errorf("ParseMoney(%q, %s) = %d minor units, want %d", tt.in, tt.currency, m.Amount, tt.minor)
+		}
+		if got := m.String(); got != tt.out {
+			t.Errorf("ParseMoney(%q, %s).String() = %q, want %q", tt.in, tt.currency, got, tt.out)
+		}
+		back, err := ParseMoney(m.String(), tt.currency)
+		if err != nil || back != m {
+			t.Errorf("ParseMoney(%q, %s) = %+v, %v; want %+v", m.String(), tt.currency, back, err, m)
+		}
+	}
+}
+
+func TestParseMoneyRejects(t *testing.T) {
+	tests := []struct {
+		currency string
+		in       string
+	}{
+		{"JPY", "1.5"},                  // JPY has no minor units
+		{"USD", "1.234"},                // more decimals than cents
+		{"USD", "92233720368547758.08"}, // overflows int64
+		{"USD", "-1"},
+		{"USD", "1."},
+		{"USD", ".5"},
+		{"USD", "1e3"},
+		{"USD", ""},
+		{"XXX", "1"},
+	}
+	for _, tt := range tests {
+		if m, err := ParseMoney(tt.in, tt.currency); err == nil {
+			t.Errorf("ParseMoney(%q, %s) = %+v, want an error", tt.in, tt.currency, m)
+		}
+	}
+}
+
+func TestMoneyStringNegative(t *testing.T) {
+	tests := []struct {
+		m    Money
+		want string
+	}{
+		{Money{-5, "USD"}, "-0.05"},
+		{Money{-12345, "IDR"}, "-123.45"},
+		{Money{-7, "JPY"}, "-7"},
+	}
+	for _, tt := range tests {
+		if got := tt.m.String(); got != tt.want {
+			t.Errorf("%+v.String() = %q, want %q", tt.m, got, tt.want)
+		}
+	}
+}
+
+func TestConvertRounding(t *testing.T) {
+	tests := []struct {
+		name string
+		from Money
+		to   string
+		rate string
+		want int64
+	}{
+		// same exponent: 0.01 USD at 16250.5 is 162.505 IDR
+		{"half rounds up", Money{1, "USD"}, "IDR", "16250.5", 16251},
+		{"half rounds away from zero", Money{-1, "USD"}, "IDR", "16250.5", -16251},
+		{"below half rounds down", Money{1, "USD"}, "IDR", "16250.49", 16250},
+		// to more decimals: 1 JPY at 0.005 is 0.5 cents
+		{"JPY to USD half", Money{1, "JPY"}, "USD", "0.005", 1},
+		{"JPY to USD below half", Money{1, "JPY"}, "USD", "0.00499", 0},
+		// to fewer decimals: 1.50 USD at 1 is 1.5 JPY, not rounded to even
+		{"USD to JPY half", Money{150, "USD"}, "JPY", "1", 2},
+		{"USD to JPY half, even", Money{250, "USD"}, "JPY", "1", 3},
+		{"USD to JPY half, negative", Money{-250, "USD"}, "JPY", "1", -3},
+		{"USD to JPY below half", Money{149, "USD"}, "JPY", "1", 1},
+		{"exact", Money{9999, "USD"}, "IDR", "16250", 162483750},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rate, err := ParseRate(tt.rate)
+			if err != nil {
+				t.Fatalf("ParseRate(%q): %v", tt.rate, err)
+			}
+			got, err := tt.from.Convert(tt.to, rate)
+			if err != nil {
+				t.Fatalf("Convert: %v", err)
+			}
+			if got.Amount != tt.want || got.Currency != tt.to {
+				t.Errorf("%+v.Convert(%s, %s) = %+v, want %d %s", tt.from, tt.to, tt.rate, got, tt.want, tt.to)
+			}
+		})
+	}
+}
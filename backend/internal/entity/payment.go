@@ -5,15 +5,137 @@ import "time"
 type PaymentStatus string
 
 const (
-	PaymentStatusCompleted   PaymentStatus = "completed"
-	PaymentStatusProcessing  PaymentStatus = "processing"
-	PaymentStatusFailed      PaymentStatus = "failed"
+	PaymentStatusCompleted  PaymentStatus = "completed"
+	PaymentStatusProcessing PaymentStatus = "processing"
+	PaymentStatusFailed     PaymentStatus = "failed"
 )
 
+// paymentTransitions lists the statuses a payment may move to from each status.
+// Completed and failed are final, so they have none.
+var paymentTransitions = map[PaymentStatus][]PaymentStatus{
+	PaymentStatusProcessing: {PaymentStatusCompleted, PaymentStatusFailed},
+	PaymentStatusCompleted:  {},
+	PaymentStatusFailed:     {},
+}
+
+// Valid reports whether s is a known payment status.
+func (s PaymentStatus) Valid() bool {
+	_, ok := paymentTransitions[s]
+	return ok
+}
+
+// Final reports whether a payment in status s can no longer change status.
+func (s PaymentStatus) Final() bool {
+	return s.Valid() && len(paymentTransitions[s]) == 0
+}
+
+// CanTransitionTo reports whether a payment may move from s to next.
+func (s PaymentStatus) CanTransitionTo(next PaymentStatus) bool {
+	for _, to := range paymentTransitions[s] {
+		if to == next {
+			return true
+		}
+	}
+	return false
+}
+
 type Payment struct {
 	ID        string        `json:"id"`
 	Merchant  string        `json:"merchant"`
 	Status    PaymentStatus `json:"status"`
-	Amount    string        `json:"amount"`
+	Amount    Money         `json:"amount"`
+	Reference string        `json:"reference"`
+	Notes     string        `json:"notes"`
 	CreatedAt time.Time     `json:"created_at"`
+	// Version goes up by one on every change, for optimistic concurrency.
+	Version int64 `json:"version"`
+	// Refunded is the sum of the approved refunds, in minor units of Amount.Currency.
+	Refunded int64 `json:"refunded"`
+	// Highlights maps each field that matched a search to its HTML with the matches marked.
+	Highlights map[string]string `json:"highlights,omitempty"`
+	// ReportAmount is Amount converted into the requested report currency at FXRate.
+	ReportAmount *Money `json:"report_amount,omitempty"`
+	FXRate       string `json:"fx_rate,omitempty"`
+}
+
+// RefundedAmount is the part of the payment given back by approved refunds.
+func (p *Payment) RefundedAmount() Money {
+	return Money{Amount: p.Refunded, Currency: p.Amount.Currency}
+}
+
+// NetAmount is what the payment is worth after its approved refunds.
+func (p *Payment) NetAmount() Money {
+	return Money{Amount: p.Amount.Amount - p.Refunded, Currency: p.Amount.Currency}
+}
+
+// PaymentPage is one page of a payment listing. NextCursor is empty on the last page.
+type PaymentPage struct {
+	Payments   []*Payment `json:"payments"`
+	NextCursor string     `json:"next_cursor,omitempty"`
+	HasMore    bool       `json:"has_more"`
+}
+
+// PaymentSummary counts the payments created From through To by status and adds
+// up their amounts per day, converted into Currency.
+type PaymentSummary struct {
+	Currency   string
+	From       string // YYYY-MM-DD in the time zone the summary was made for
+	To         string
+	Total      int
+	Completed  int
+	Processing int
+	Failed     int
+	// Days has an entry for every day with payments, oldest first.
+	Days []*PaymentDayTotals
+	// Unconverted adds up, per currency, the payments with no rate into Currency
+	// in force; they are counted but left out of Days.
+	Unconverted []*UnconvertedTotal
+}
+
+// UnconvertedTotal is the amount, in its own currency, of Count payments that could not be converted.
+type UnconvertedTotal struct {
+	Count  int
+	Amount Money
+}
+
+// PaymentDayTotals are the amounts of the payments created on Date, by status.
+type PaymentDayTotals struct {
+	Date       string // YYYY-MM-DD in the time zone the summary was made for
+	Total      Money
+	Completed  Money
+	Processing Money
+	Failed     Money
+}
+
+// NewPayment is a payment as a client submits it, before validation.
+type NewPayment struct {
+	Merchant  string
+	Amount    string
+	Currency  string
+	Reference string
+	Notes     string
+}
+
+// IdempotencyRecord ties a request made with an Idempotency-Key to the payment
+// it created, so that a retry gets that payment instead of repeating the work.
+// Keys belong to the user and to the credential (an API key or a session) that
+// sent them.
+type IdempotencyRecord struct {
+	UserID      string
+	Credential  string
+	Key         string
+	RequestHash string
+	PaymentID   string
+	CreatedAt   time.Time
+	ExpiresAt   time.Time
+}
+
+// PaymentStatusChange moves a payment from one status to another. It only
+// applies while the payment is still at Version.
+type PaymentStatusChange struct {
+	PaymentID string
+	From      PaymentStatus
+	To        PaymentStatus
+	Version   int64
+	Reason    string
 }
@@ -0,0 +1,39 @@
+package entity
+
+import "time"
+
+// Payment event types.
+const (
+	// PaymentEventCreated means the payment was recorded; NewValue is its status.
+	PaymentEventCreated = "created"
+	// PaymentEventStatusChanged means the payment moved from OldValue to NewValue.
+	PaymentEventStatusChanged = "status_changed"
+	// Refund events: OldValue and NewValue are the status of refund RefundID.
+	PaymentEventRefundRequested = "refund_requested"
+	PaymentEventRefundApproved  = "refund_approved"
+	PaymentEventRefundRejected  = "refund_rejected"
+)
+
+// Payment event sources: how the actor reached the API.
+const (
+	PaymentEventSourceDashboard     = "dashboard"
+	PaymentEventSourceAPIKey        = "api_key"
+	PaymentEventSourceImpersonation = "impersonation"
+)
+
+// PaymentEvent is one entry in a payment's history. ActorEmail is kept next to
+// ActorID so the entry still names the actor after the user is deleted; for
+// impersonated requests the actor is the superuser, not the impersonated user.
+type PaymentEvent struct {
+	ID         string
+	PaymentID  string
+	Type       string
+	ActorID    string
+	ActorEmail string
+	Source     string
+	OldValue   string
+	NewValue   string
+	Reason     string
+	RefundID   string
+	CreatedAt  time.Time
+}
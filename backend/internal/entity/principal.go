@@ -0,0 +1,22 @@
+package entity
+
+import "time"
+
+// Principal is the authenticated caller of a request, as established from its
+// access token or API key. For an API key the user fields describe the key's owner.
+type Principal struct {
+	UserID    string
+	Email     string
+	Role      string
+	SessionID string
+	TokenID   string
+	ExpiresAt time.Time
+
+	// Set only when the request authenticated with an API key
+	APIKeyID string
+	Scopes   []string
+
+	// Set only for impersonation tokens: the superuser acting as the user
+	ActorID    string
+	ActorEmail string
+}
@@ -0,0 +1,52 @@
+package entity
+
+import "time"
+
+type RefundStatus string
+
+const (
+	RefundStatusRequested RefundStatus = "requested"
+	RefundStatusApproved  RefundStatus = "approved"
+	RefundStatusRejected  RefundStatus = "rejected"
+)
+
+// refundTransitions lists the statuses a refund may move to from each status.
+// A refund is decided once: approved and rejected are final.
+var refundTransitions = map[RefundStatus][]RefundStatus{
+	RefundStatusRequested: {RefundStatusApproved, RefundStatusRejected},
+	RefundStatusApproved:  {},
+	RefundStatusRejected:  {},
+}
+
+// CanTransitionTo reports whether a refund may move from s to next.
+func (s RefundStatus) CanTransitionTo(next RefundStatus) bool {
+	for _, to := range refundTransitions[s] {
+		if to == next {
+			return true
+		}
+	}
+	return false
+}
+
+// Reserves reports whether a refund in status s counts against the amount that
+// is left to refund. Requested refunds do, so that pending requests can never
+// add up to more than the payment.
+func (s RefundStatus) Reserves() bool {
+	return s == RefundStatusRequested || s == RefundStatusApproved
+}
+
+// Refund gives back part or all of a completed payment, in its currency. It is
+// requested by one user and approved or rejected by another.
+type Refund struct {
+	ID               string
+	PaymentID        string
+	Amount           Money
+	Status           RefundStatus
+	Reason           string
+	RequestedBy      string
+	RequestedByEmail string
+	DecidedBy        string
+	DecidedByEmail   string
+	CreatedAt        time.Time
+	DecidedAt        *time.Time
+}
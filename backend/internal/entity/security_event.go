@@ -0,0 +1,40 @@
+package entity
+
+import "time"
+
+// Security event types.
+const (
+	// SecurityEventRefreshTokenReuse means a refresh token was presented after it
+	// had been rotated, so a copy of it exists somewhere. Its session was revoked.
+	SecurityEventRefreshTokenReuse = "refresh_token_reuse"
+	// SecurityEventImpersonationStarted means a superuser got a token acting as the user.
+	SecurityEventImpersonationStarted = "impersonation_started"
+	// SecurityEventImpersonatedRequest is one request made with an impersonation token.
+	SecurityEventImpersonatedRequest = "impersonated_request"
+)
+
+// SecurityEvent records something that may indicate an account compromise.
+// IP and UserAgent belong to the client that triggered it. ActorID is the
+// superuser behind impersonation events. The emails are those of the users
+// when the event was recorded, so events still name them after deletion.
+type SecurityEvent struct {
+	ID         string
+	Type       string
+	UserID     string
+	UserEmail  string
+	ActorID    string
+	ActorEmail string
+	SessionID  string
+	IP         string
+	UserAgent  string
+	Detail     string
+	CreatedAt  time.Time
+}
+
+// SecurityEventPage is one page of a security event listing.
+type SecurityEventPage struct {
+	Events   []*SecurityEvent
+	Page     int
+	PageSize int
+	Total    int
+}
@@ -0,0 +1,19 @@
+package entity
+
+import "time"
+
+// Session is a signed-in device. Each login opens one and each refresh keeps it alive.
+type Session struct {
+	ID         string    `json:"id"`
+	UserID     string    `json:"user_id"`
+	UserAgent  string    `json:"user_agent"`
+	IP         string    `json:"ip"`
+	CreatedAt  time.Time `json:"created_at"`
+	LastUsedAt time.Time `json:"last_used_at"`
+}
+
+// ClientInfo describes the client a session is opened from.
+type ClientInfo struct {
+	UserAgent string
+	IP        string
+}
@@ -0,0 +1,12 @@
+package entity
+
+import "time"
+
+// SigningKey describes a JWT signing key without its key material.
+// RetiredAt is zero for the key currently used to sign new tokens.
+type SigningKey struct {
+	ID        string
+	Alg       string
+	CreatedAt time.Time
+	RetiredAt time.Time
+}
@@ -0,0 +1,7 @@
+package entity
+
+// SSOAuthorization is where the browser goes to sign in with the identity provider.
+type SSOAuthorization struct {
+	URL   string
+	State string
+}
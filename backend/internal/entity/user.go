@@ -1,8 +1,45 @@
 package entity
 
+// Roles a dashboard user can hold.
+const (
+	RoleCS        = "cs"
+	RoleOperation = "operation"
+	RoleSuperuser = "superuser"
+)
+
+// ValidRole reports whether role is one of the known dashboard roles.
+func ValidRole(role string) bool {
+	switch role {
+	case RoleCS, RoleOperation, RoleSuperuser:
+		return true
+	}
+	return false
+}
+
 type User struct {
 	ID           string `json:"id"`
 	Email        string `json:"email"`
 	PasswordHash string `json:"-"`
 	Role         string `json:"role"`
+	DisplayName  string `json:"display_name"`
+	Timezone     string `json:"timezone"`
+	Locale       string `json:"locale"`
+	Active       bool   `json:"active"`
+	MFAEnabled   bool   `json:"mfa_enabled"`
+	TOTPSecret   string `json:"-"`
+}
+
+// ProfileUpdate holds the self-editable profile fields; nil fields are left unchanged.
+type ProfileUpdate struct {
+	DisplayName *string
+	Timezone    *string
+	Locale      *string
+}
+
+// UserPage is one page of a user listing.
+type UserPage struct {
+	Users    []*User
+	Page     int
+	PageSize int
+	Total    int
 }
@@ -0,0 +1,105 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/module/apikey/usecase"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/principal"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+)
+
+type APIKeyHandler struct {
+	apiKeyUC usecase.APIKeyUsecase
+}
+
+func NewAPIKeyHandler(apiKeyUC usecase.APIKeyUsecase) *APIKeyHandler {
+	return &APIKeyHandler{
+		apiKeyUC: apiKeyUC,
+	}
+}
+
+func toAPIKey(k *entity.APIKey) openapigen.ApiKey {
+	scopes := []openapigen.ApiKeyScope{}
+	for _, s := range k.Scopes {
+		scopes = append(scopes, openapigen.ApiKeyScope(s))
+	}
+	return openapigen.ApiKey{
+		Id:         &k.ID,
+		Prefix:     &k.Prefix,
+		Label:      &k.Label,
+		OwnerId:    &k.Owner.ID,
+		OwnerEmail: &k.Owner.Email,
+		Scopes:     &scopes,
+		CreatedAt:  &k.CreatedAt,
+		ExpiresAt:  k.ExpiresAt,
+		LastUsedAt: k.LastUsedAt,
+		RevokedAt:  k.RevokedAt,
+	}
+}
+
+func (h *APIKeyHandler) GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+	keys, err := h.apiKeyUC.ListAPIKeys()
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	keyList := []openapigen.ApiKey{}
+	for _, k := range keys {
+		keyList = append(keyList, toAPIKey(k))
+	}
+
+	response := openapigen.ApiKeyListResponse{
+		ApiKeys: &keyList,
+	}
+
+	transport.WriteJSON(w, http.StatusOK, response)
+}
+
+func (h *APIKeyHandler) PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.PostDashboardV1ApiKeysJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	input := entity.NewAPIKey{
+		Label:     req.Label,
+		ExpiresAt: req.ExpiresAt,
+	}
+	if req.OwnerId != nil {
+		input.OwnerID = *req.OwnerId
+	}
+	for _, s := range req.Scopes {
+		input.Scopes = append(input.Scopes, string(s))
+	}
+
+	key, secret, err := h.apiKeyUC.CreateAPIKey(caller, input)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	response := openapigen.ApiKeyCreatedResponse{
+		ApiKey: toAPIKey(key),
+		Secret: secret,
+	}
+
+	transport.WriteJSON(w, http.StatusCreated, response)
+}
+
+func (h *APIKeyHandler) DeleteDashboardV1ApiKeysId(w http.ResponseWriter, r *http.Request, id string) {
+	if err := h.apiKeyUC.RevokeAPIKey(id); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
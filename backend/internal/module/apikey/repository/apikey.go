@@ -0,0 +1,139 @@
+package repository
+
+import (
+	"database/sql"
+	"errors"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/mattn/go-sqlite3"
+)
+
+type APIKeyRepository interface {
+	CreateAPIKey(key *entity.APIKey) (*entity.APIKey, error)
+	ListAPIKeys() ([]*entity.APIKey, error)
+	GetAPIKeyByID(id string) (*entity.APIKey, error)
+	GetAPIKeyByPrefix(prefix string) (*entity.APIKey, error)
+	RevokeAPIKey(id string, at time.Time) error
+	TouchAPIKey(id string, at time.Time) error
+}
+
+type APIKey struct {
+	db *sql.DB
+}
+
+func NewAPIKeyRepo(db *sql.DB) *APIKey {
+	return &APIKey{db: db}
+}
+
+// Keys are always loaded with their owner, whose role and status decide what the key may do.
+const apiKeySelect = `SELECT k.id, k.prefix, k.key_hash, k.label, k.scopes, k.created_at,
+	  k.expires_at, k.last_used_at, k.revoked_at, u.id, u.email, u.role, u.active
+	FROM api_keys k JOIN users u ON u.id = k.owner_id`
+
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanAPIKey(row rowScanner) (*entity.APIKey, error) {
+	var (
+		k                            entity.APIKey
+		owner                        entity.User
+		scopes                       string
+		expiresAt, lastUsed, revoked sql.NullTime
+	)
+	err := row.Scan(&k.ID, &k.Prefix, &k.KeyHash, &k.Label, &scopes, &k.CreatedAt,
+		&expiresAt, &lastUsed, &revoked, &owner.ID, &owner.Email, &owner.Role, &owner.Active)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, entity.ErrorNotFound("api key not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	k.Owner = &owner
+	k.Scopes = strings.Fields(scopes)
+	k.ExpiresAt = nullTime(expiresAt)
+	k.LastUsedAt = nullTime(lastUsed)
+	k.RevokedAt = nullTime(revoked)
+	return &k, nil
+}
+
+func nullTime(t sql.NullTime) *time.Time {
+	if !t.Valid {
+		return nil
+	}
+	return &t.Time
+}
+
+// CreateAPIKey stores a new key; key.Owner only needs its ID set.
+func (r *APIKey) CreateAPIKey(key *entity.APIKey) (*entity.APIKey, error) {
+	res, err := r.db.Exec(
+		`INSERT INTO api_keys(prefix, key_hash, label, owner_id, scopes, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		key.Prefix, key.KeyHash, key.Label, key.Owner.ID, strings.Join(key.Scopes, " "), key.CreatedAt, key.ExpiresAt,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintForeignKey {
+			return nil, entity.ErrorNotFound("owner not found")
+		}
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return r.GetAPIKeyByID(strconv.FormatInt(id, 10))
+}
+
+// ListAPIKeys returns every key, newest first.
+func (r *APIKey) ListAPIKeys() ([]*entity.APIKey, error) {
+	rows, err := r.db.Query(apiKeySelect + ` ORDER BY k.id DESC`)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+
+	keys := []*entity.APIKey{}
+	for rows.Next() {
+		k, err := scanAPIKey(rows)
+		if err != nil {
+			return nil, err
+		}
+		keys = append(keys, k)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return keys, nil
+}
+
+func (r *APIKey) GetAPIKeyByID(id string) (*entity.APIKey, error) {
+	return scanAPIKey(r.db.QueryRow(apiKeySelect+` WHERE k.id = ?`, id))
+}
+
+func (r *APIKey) GetAPIKeyByPrefix(prefix string) (*entity.APIKey, error) {
+	return scanAPIKey(r.db.QueryRow(apiKeySelect+` WHERE k.prefix = ?`, prefix))
+}
+
+// RevokeAPIKey marks the key revoked; revoking twice keeps the first timestamp.
+func (r *APIKey) RevokeAPIKey(id string, at time.Time) error {
+	res, err := r.db.Exec(`UPDATE api_keys SET revoked_at = COALESCE(revoked_at, ?) WHERE id = ?`, at, id)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return entity.ErrorNotFound("api key not found")
+	}
+	return nil
+}
+
+func (r *APIKey) TouchAPIKey(id string, at time.Time) error {
+	if _, err := r.db.Exec(`UPDATE api_keys SET last_used_at = ? WHERE id = ?`, at, id); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/module/apikey/repository"
+	userRepository "github.com/durianpay/fullstack-boilerplate/internal/module/auth/repository"
+)
+
+const (
+	// keys look like dpk_<8 hex prefix>_<secret>
+	keyPrefix       = "dpk_"
+	prefixHexLength = 8
+	maxLabelLength  = 100
+	// last_used_at is only written when it is older than this, to spare a write per request
+	touchInterval = time.Minute
+)
+
+type APIKeyUsecase interface {
+	CreateAPIKey(caller *entity.Principal, input entity.NewAPIKey) (key *entity.APIKey, secret string, err error)
+	ListAPIKeys() ([]*entity.APIKey, error)
+	RevokeAPIKey(id string) error
+	AuthenticateAPIKey(ctx context.Context, secret string) (*entity.Principal, error)
+}
+
+type APIKey struct {
+	repo  repository.APIKeyRepository
+	users userRepository.UserRepository
+}
+
+func NewAPIKeyUsecase(repo repository.APIKeyRepository, users userRepository.UserRepository) *APIKey {
+	return &APIKey{repo: repo, users: users}
+}
+
+func hashKey(secret string) string {
+	sum := sha256.Sum256([]byte(secret))
+	return hex.EncodeToString(sum[:])
+}
+
+// CreateAPIKey issues a key owned by input.OwnerID, or by the caller when it is empty.
+// The returned secret is the only time the full key is available.
+func (u *APIKey) CreateAPIKey(caller *entity.Principal, input entity.NewAPIKey) (*entity.APIKey, string, error) {
+	label := strings.TrimSpace(input.Label)
+	if label == "" || len([]rune(label)) > maxLabelLength {
+		return nil, "", entity.ErrorBadRequest("label must be 1 to 100 characters")
+	}
+	if len(input.Scopes) == 0 {
+		return nil, "", entity.ErrorBadRequest("at least one scope is required")
+	}
+	scopes := []string{}
+	for _, s := range input.Scopes {
+		if !entity.ValidAPIKeyScope(s) {
+			return nil, "", entity.ErrorBadRequest("unknown scope: " + s)
+		}
+		if !slices.Contains(scopes, s) {
+			scopes = append(scopes, s)
+		}
+	}
+	now := time.Now().UTC()
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(now) {
+		return nil, "", entity.ErrorBadRequest("expires_at must be in the future")
+	}
+
+	ownerID := input.OwnerID
+	if ownerID == "" {
+		ownerID = caller.UserID
+	}
+	owner, err := u.users.GetUserByID(ownerID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !owner.Active {
+		return nil, "", entity.ErrorBadRequest("owner account is deactivated")
+	}
+
+	prefixBytes := make([]byte, prefixHexLength/2)
+	secretBytes := make([]byte, 32)
+	if _, err := rand.Read(prefixBytes); err != nil {
+		return nil, "", entity.ErrorInternal("failed to generate api key")
+	}
+	if _, err := rand.Read(secretBytes); err != nil {
+		return nil, "", entity.ErrorInternal("failed to generate api key")
+	}
+	prefix := keyPrefix + hex.EncodeToString(prefixBytes)
+	secret := prefix + "_" + base64.RawURLEncoding.EncodeToString(secretBytes)
+
+	key, err := u.repo.CreateAPIKey(&entity.APIKey{
+		Prefix:    prefix,
+		KeyHash:   hashKey(secret),
+		Label:     label,
+		Owner:     owner,
+		Scopes:    scopes,
+		CreatedAt: now,
+		ExpiresAt: input.ExpiresAt,
+	})
+	if err != nil {
+		return nil, "", err
+	}
+	return key, secret, nil
+}
+
+func (u *APIKey) ListAPIKeys() ([]*entity.APIKey, error) {
+	return u.repo.ListAPIKeys()
+}
+
+func (u *APIKey) RevokeAPIKey(id string) error {
+	return u.repo.RevokeAPIKey(id, time.Now().UTC())
+}
+
+// AuthenticateAPIKey resolves an X-API-Key header to a principal acting as the key's owner.
+func (u *APIKey) AuthenticateAPIKey(_ context.Context, secret string) (*entity.Principal, error) {
+	if !strings.HasPrefix(secret, keyPrefix) || len(secret) < len(keyPrefix)+prefixHexLength+2 {
+		return nil, entity.ErrorUnauthorized("invalid api key")
+	}
+	prefix := secret[:len(keyPrefix)+prefixHexLength]
+
+	key, err := u.repo.GetAPIKeyByPrefix(prefix)
+	if appErr, ok := err.(*entity.AppError); ok && appErr.Code == entity.ErrorCodeNotFound {
+		return nil, entity.ErrorUnauthorized("invalid api key")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(hashKey(secret)), []byte(key.KeyHash)) != 1 {
+		return nil, entity.ErrorUnauthorized("invalid api key")
+	}
+
+	now := time.Now().UTC()
+	switch {
+	case key.RevokedAt != nil:
+		return nil, entity.ErrorUnauthorized("api key has been revoked")
+	case key.ExpiresAt != nil && !key.ExpiresAt.After(now):
+		return nil, entity.ErrorUnauthorized("api key has expired")
+	case !key.Owner.Active:
+		return nil, entity.ErrorUnauthorized("api key owner is deactivated")
+	}
+
+	if key.LastUsedAt == nil || now.Sub(*key.LastUsedAt) >= touchInterval {
+		if err := u.repo.TouchAPIKey(key.ID, now); err != nil {
+			log.Printf("apikey: failed to record use of key %s: %v", key.ID, err)
+		}
+	}
+
+	return &entity.Principal{
+		UserID:   key.Owner.ID,
+		Email:    key.Owner.Email,
+		Role:     key.Owner.Role,
+		APIKeyID: key.ID,
+		Scopes:   key.Scopes,
+	}, nil
+}
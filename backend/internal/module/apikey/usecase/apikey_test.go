@@ -0,0 +1,165 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/module/apikey/repository"
+	userRepository "github.com/durianpay/fullstack-boilerplate/internal/module/auth/repository"
+	"github.com/durianpay/fullstack-boilerplate/internal/seeder"
+	passwordsvc "github.com/durianpay/fullstack-boilerplate/internal/service/password"
+	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type testAPIKey struct {
+	*APIKey
+	db     *sql.DB
+	caller *entity.Principal
+}
+
+// newTestAPIKey returns an APIKey on a freshly seeded database, called by the seeded superuser.
+func newTestAPIKey(t *testing.T) *testAPIKey {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db")+"?_foreign_keys=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	passwords, err := passwordsvc.NewHasher(passwordsvc.AlgBcrypt, bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := seeder.Seed(db, passwords); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	users := userRepository.NewUserRepo(db)
+	superuser, err := users.GetUserByEmail("superuser@test.com")
+	if err != nil {
+		t.Fatal(err)
+	}
+	caller := &entity.Principal{UserID: superuser.ID, Email: superuser.Email, Role: superuser.Role}
+	return &testAPIKey{APIKey: NewAPIKeyUsecase(repository.NewAPIKeyRepo(db), users), db: db, caller: caller}
+}
+
+func (u *testAPIKey) create(t *testing.T, input entity.NewAPIKey) (*entity.APIKey, string) {
+	t.Helper()
+	key, secret, err := u.CreateAPIKey(u.caller, input)
+	if err != nil {
+		t.Fatalf("CreateAPIKey: %v", err)
+	}
+	return key, secret
+}
+
+// wantCode fails unless err is an AppError with the given code.
+func wantCode(t *testing.T, err error, code entity.Code) {
+	t.Helper()
+	appErr, ok := err.(*entity.AppError)
+	if !ok || appErr.Code != code {
+		t.Fatalf("err = %v, want %s", err, code)
+	}
+}
+
+func TestCreateAPIKey(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+	tests := []struct {
+		name  string
+		input entity.NewAPIKey
+		want  entity.Code // empty when the key is created
+	}{
+		{"valid", entity.NewAPIKey{Label: "ci", Scopes: []string{entity.ScopePaymentsRead}}, ""},
+		{"no label", entity.NewAPIKey{Label: "  ", Scopes: []string{entity.ScopePaymentsRead}}, entity.ErrorCodeBadRequest},
+		{"long label", entity.NewAPIKey{Label: strings.Repeat("x", maxLabelLength+1), Scopes: []string{entity.ScopePaymentsRead}}, entity.ErrorCodeBadRequest},
+		{"no scopes", entity.NewAPIKey{Label: "ci"}, entity.ErrorCodeBadRequest},
+		{"unknown scope", entity.NewAPIKey{Label: "ci", Scopes: []string{"users:write"}}, entity.ErrorCodeBadRequest},
+		{"expired", entity.NewAPIKey{Label: "ci", Scopes: []string{entity.ScopePaymentsRead}, ExpiresAt: &past}, entity.ErrorCodeBadRequest},
+		{"unknown owner", entity.NewAPIKey{Label: "ci", Scopes: []string{entity.ScopePaymentsRead}, OwnerID: "999"}, entity.ErrorCodeNotFound},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestAPIKey(t)
+			_, _, err := u.CreateAPIKey(u.caller, tt.input)
+			if tt.want != "" {
+				wantCode(t, err, tt.want)
+			} else if err != nil {
+				t.Fatalf("CreateAPIKey: %v", err)
+			}
+		})
+	}
+}
+
+func TestAPIKeysAreStoredHashed(t *testing.T) {
+	u := newTestAPIKey(t)
+	key, secret := u.create(t, entity.NewAPIKey{Label: "ci", Scopes: []string{entity.ScopePaymentsRead, entity.ScopePaymentsRead}})
+
+	if !strings.HasPrefix(secret, key.Prefix+"_") {
+		t.Errorf("secret %q does not start with the prefix %q", secret, key.Prefix)
+	}
+	var stored string
+	if err := u.db.QueryRow("SELECT key_hash FROM api_keys WHERE id = ?", key.ID).Scan(&stored); err != nil {
+		t.Fatal(err)
+	}
+	if stored != hashKey(secret) || strings.Contains(stored, secret[len(key.Prefix)+1:]) {
+		t.Errorf("stored %q, want the SHA-256 of the secret", stored)
+	}
+	if len(key.Scopes) != 1 {
+		t.Errorf("scopes = %v, want duplicates dropped", key.Scopes)
+	}
+}
+
+func TestAuthenticateAPIKey(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret func(secret string) string
+		setup  func(t *testing.T, u *testAPIKey, key *entity.APIKey)
+		ok     bool
+	}{
+		{"valid", func(s string) string { return s }, nil, true},
+		{"wrong secret with a known prefix", func(s string) string { return s[:len(s)-1] + "x" }, nil, false},
+		{"unknown prefix", func(s string) string { return keyPrefix + "00000000_" + s[len(keyPrefix)+prefixHexLength+1:] }, nil, false},
+		{"not an api key", func(string) string { return "Bearer abc" }, nil, false},
+		{"revoked", func(s string) string { return s }, func(t *testing.T, u *testAPIKey, key *entity.APIKey) {
+			if err := u.RevokeAPIKey(key.ID); err != nil {
+				t.Fatal(err)
+			}
+		}, false},
+		{"expired", func(s string) string { return s }, func(t *testing.T, u *testAPIKey, key *entity.APIKey) {
+			if _, err := u.db.Exec("UPDATE api_keys SET expires_at = ? WHERE id = ?", time.Now().UTC().Add(-time.Second), key.ID); err != nil {
+				t.Fatal(err)
+			}
+		}, false},
+		{"owner deactivated", func(s string) string { return s }, func(t *testing.T, u *testAPIKey, key *entity.APIKey) {
+			if _, err := u.db.Exec("UPDATE users SET active = 0 WHERE id = ?", key.Owner.ID); err != nil {
+				t.Fatal(err)
+			}
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u := newTestAPIKey(t)
+			future := time.Now().Add(time.Hour)
+			key, secret := u.create(t, entity.NewAPIKey{Label: "ci", Scopes: []string{entity.ScopePaymentsRead}, ExpiresAt: &future})
+			if tt.setup != nil {
+				tt.setup(t, u, key)
+			}
+
+			p, err := u.AuthenticateAPIKey(context.Background(), tt.secret(secret))
+			if !tt.ok {
+				wantCode(t, err, entity.ErrorCodeUnauthorized)
+				return
+			}
+			if err != nil {
+				t.Fatalf("AuthenticateAPIKey: %v", err)
+			}
+			if p.APIKeyID != key.ID || p.UserID != u.caller.UserID || len(p.Scopes) != 1 || p.Scopes[0] != entity.ScopePaymentsRead {
+				t.Errorf("principal = %+v, want the key's owner and scopes", p)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/durianpay/fullstack-boilerplate/internal/entity"
 	authUsecase "github.com/durianpay/fullstack-boilerplate/internal/module/auth/usecase"
 	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/principal"
 	"github.com/durianpay/fullstack-boilerplate/internal/transport"
 )
 
@@ -24,20 +25,80 @@ func (a *AuthHandler) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Re
 	if !transport.DecodeJSONBody(w, r, &req) {
 		return
 	}
-	accessToken, refreshToken, user, err := a.authUC.Login(req.Email, req.Password)
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+	result, err := a.authUC.Login(req.Email, req.Password, client)
 	if err != nil {
 		transport.WriteError(w, err)
 		return
 	}
 
+	transport.WriteJSON(w, http.StatusOK, toLoginResponse(result))
+}
+
+// toLoginResponse carries either the token pair or, when a second factor is
+// still needed, the MFA token.
+func toLoginResponse(result *entity.LoginResult) openapigen.LoginResponse {
 	response := openapigen.LoginResponse{
-		Email:        &user.Email,
-		Role:         &user.Role,
-		Token:        &accessToken,
-		RefreshToken: &refreshToken,
+		Email: &result.User.Email,
+		Role:  &result.User.Role,
+	}
+	if result.MFAToken != "" {
+		mfaRequired := true
+		response.MfaRequired = &mfaRequired
+		response.MfaToken = &result.MFAToken
+		response.MfaEnrollmentRequired = &result.MFAEnrollmentRequired
+		return response
 	}
 
-	transport.WriteJSON(w, http.StatusOK, response)
+	response.Token = &result.AccessToken
+	response.RefreshToken = &result.RefreshToken
+	if result.RecoveryCodes != nil {
+		response.RecoveryCodes = &result.RecoveryCodes
+	}
+	return response
+}
+
+func toMFAEnrollment(e *entity.MFAEnrollment) openapigen.MFAEnrollment {
+	return openapigen.MFAEnrollment{
+		Secret:     &e.Secret,
+		OtpauthUri: &e.URI,
+	}
+}
+
+func (a *AuthHandler) PostDashboardV1AuthLoginMfa(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthLoginMfaJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+	result, err := a.authUC.CompleteMFALogin(req.MfaToken, req.Code, client)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toLoginResponse(result))
+}
+
+func (a *AuthHandler) PostDashboardV1AuthLoginMfaEnroll(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthLoginMfaEnrollJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	enrollment, err := a.authUC.BeginMFALoginEnrollment(req.MfaToken)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toMFAEnrollment(enrollment))
 }
 
 func (a *AuthHandler) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
@@ -53,7 +114,11 @@ func (a *AuthHandler) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.
 		return
 	}
 
-	accessToken, refreshToken, err := a.authUC.RefreshAccessToken(req.RefreshToken)
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+	accessToken, refreshToken, err := a.authUC.RefreshAccessToken(req.RefreshToken, client)
 	if err != nil {
 		transport.WriteError(w, err)
 		return
@@ -66,3 +131,231 @@ func (a *AuthHandler) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.
 
 	transport.WriteJSON(w, http.StatusOK, response)
 }
+
+func (a *AuthHandler) PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	if err := a.authUC.Logout(caller); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AuthHandler) GetDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	sessions, err := a.authUC.ListSessions(caller)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	sessionList := []openapigen.Session{}
+	for _, s := range sessions {
+		current := s.ID == caller.SessionID
+		sessionList = append(sessionList, openapigen.Session{
+			Id:         &s.ID,
+			UserAgent:  &s.UserAgent,
+			Ip:         &s.IP,
+			CreatedAt:  &s.CreatedAt,
+			LastUsedAt: &s.LastUsedAt,
+			Current:    &current,
+		})
+	}
+
+	response := openapigen.SessionListResponse{
+		Sessions: &sessionList,
+	}
+
+	transport.WriteJSON(w, http.StatusOK, response)
+}
+
+func (a *AuthHandler) DeleteDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	if err := a.authUC.RevokeOtherSessions(caller); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AuthHandler) DeleteDashboardV1AuthSessionsSessionId(w http.ResponseWriter, r *http.Request, sessionId string) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	if err := a.authUC.RevokeSession(caller, sessionId); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthPassword(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.PostDashboardV1AuthPasswordJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+
+	if err := a.authUC.ChangePassword(caller, req.CurrentPassword, req.NewPassword, client); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthPasswordForgot(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthPasswordForgotJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+
+	if err := a.authUC.RequestPasswordReset(req.Email, client); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1AuthPasswordResetJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	if err := a.authUC.ResetPassword(req.Token, req.NewPassword); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthMfaEnroll(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	enrollment, err := a.authUC.BeginMFAEnrollment(caller)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toMFAEnrollment(enrollment))
+}
+
+func (a *AuthHandler) PostDashboardV1AuthMfaActivate(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.PostDashboardV1AuthMfaActivateJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+
+	codes, err := a.authUC.ActivateMFA(caller, req.Code, client)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, openapigen.RecoveryCodesResponse{RecoveryCodes: &codes})
+}
+
+func (a *AuthHandler) PostDashboardV1AuthMfaDisable(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.PostDashboardV1AuthMfaDisableJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+
+	var password string
+	if req.Password != nil {
+		password = *req.Password
+	}
+	if err := a.authUC.DisableMFA(caller, password, req.Code, client); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthMfaRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.PostDashboardV1AuthMfaRecoveryCodesJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+
+	codes, err := a.authUC.RegenerateRecoveryCodes(caller, req.Code, client)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, openapigen.RecoveryCodesResponse{RecoveryCodes: &codes})
+}
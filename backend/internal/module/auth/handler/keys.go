@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+)
+
+// jwksMaxAge lets verifiers cache the key set briefly; new keys are picked up
+// by refetching when a token carries an unknown kid.
+const jwksMaxAge = "public, max-age=300"
+
+func toSigningKey(k *entity.SigningKey) openapigen.SigningKey {
+	alg := openapigen.SigningKeyAlg(k.Alg)
+	active := k.RetiredAt.IsZero()
+	key := openapigen.SigningKey{
+		Kid:       &k.ID,
+		Alg:       &alg,
+		CreatedAt: &k.CreatedAt,
+		Active:    &active,
+	}
+	if !active {
+		key.RetiredAt = &k.RetiredAt
+	}
+	return key
+}
+
+// optional maps empty strings to absent JSON fields.
+func optional(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func (a *AuthHandler) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+	keys := []openapigen.JWK{}
+	for _, k := range a.authUC.JWKS() {
+		keys = append(keys, openapigen.JWK{
+			Kty: openapigen.JWKKty(k.Kty),
+			Kid: k.Kid,
+			Use: k.Use,
+			Alg: k.Alg,
+			N:   optional(k.N),
+			E:   optional(k.E),
+			Crv: optional(k.Crv),
+			X:   optional(k.X),
+		})
+	}
+
+	w.Header().Set("Cache-Control", jwksMaxAge)
+	transport.WriteJSON(w, http.StatusOK, openapigen.JWKSetResponse{Keys: keys})
+}
+
+func (a *AuthHandler) GetDashboardV1AuthKeys(w http.ResponseWriter, r *http.Request) {
+	keyList := []openapigen.SigningKey{}
+	for _, k := range a.authUC.ListSigningKeys() {
+		keyList = append(keyList, toSigningKey(k))
+	}
+
+	response := openapigen.SigningKeyListResponse{
+		Keys: &keyList,
+	}
+
+	transport.WriteJSON(w, http.StatusOK, response)
+}
+
+func (a *AuthHandler) PostDashboardV1AuthKeysRotate(w http.ResponseWriter, r *http.Request) {
+	key, err := a.authUC.RotateSigningKey()
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toSigningKey(key))
+}
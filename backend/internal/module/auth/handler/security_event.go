@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+)
+
+func toSecurityEvent(e *entity.SecurityEvent) openapigen.SecurityEvent {
+	eventType := openapigen.SecurityEventType(e.Type)
+	return openapigen.SecurityEvent{
+		Id:         &e.ID,
+		Type:       &eventType,
+		UserId:     &e.UserID,
+		UserEmail:  optional(e.UserEmail),
+		ActorId:    optional(e.ActorID),
+		ActorEmail: optional(e.ActorEmail),
+		SessionId:  optional(e.SessionID),
+		Ip:         optional(e.IP),
+		UserAgent:  optional(e.UserAgent),
+		Detail:     optional(e.Detail),
+		CreatedAt:  &e.CreatedAt,
+	}
+}
+
+// GetDashboardV1AuthSecurityEvents lists security events page by page
+func (a *AuthHandler) GetDashboardV1AuthSecurityEvents(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1AuthSecurityEventsParams) {
+	page, pageSize := 0, 0
+	if params.Page != nil {
+		page = *params.Page
+	}
+	if params.PageSize != nil {
+		pageSize = *params.PageSize
+	}
+
+	result, err := a.authUC.ListSecurityEvents(page, pageSize)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	eventList := []openapigen.SecurityEvent{}
+	for _, e := range result.Events {
+		eventList = append(eventList, toSecurityEvent(e))
+	}
+
+	response := openapigen.SecurityEventListResponse{
+		Events:   &eventList,
+		Page:     &result.Page,
+		PageSize: &result.PageSize,
+		Total:    &result.Total,
+	}
+
+	transport.WriteJSON(w, http.StatusOK, response)
+}
@@ -0,0 +1,68 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	authUsecase "github.com/durianpay/fullstack-boilerplate/internal/module/auth/usecase"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+)
+
+const (
+	ssoStateCookie = "sso_state"
+	ssoCookiePath  = "/dashboard/v1/auth/sso"
+)
+
+// setSSOStateCookie binds the state to the browser that started the login.
+// An empty state with maxAge -1 deletes the cookie.
+func setSSOStateCookie(w http.ResponseWriter, r *http.Request, state string, maxAge int) {
+	http.SetCookie(w, &http.Cookie{
+		Name:     ssoStateCookie,
+		Value:    state,
+		Path:     ssoCookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   r.TLS != nil || r.Header.Get("X-Forwarded-Proto") == "https",
+		// Lax is enough: the callback is a POST from the frontend, not a cross-site navigation
+		SameSite: http.SameSiteLaxMode,
+	})
+}
+
+func (a *AuthHandler) PostDashboardV1AuthSsoAuthorize(w http.ResponseWriter, r *http.Request) {
+	authorization, err := a.authUC.BeginSSOLogin()
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	setSSOStateCookie(w, r, authorization.State, int(authUsecase.SSOStateTTL.Seconds()))
+	transport.WriteJSON(w, http.StatusOK, openapigen.SSOAuthorization{
+		AuthorizationUrl: authorization.URL,
+		State:            authorization.State,
+	})
+}
+
+func (a *AuthHandler) PostDashboardV1AuthSsoCallback(w http.ResponseWriter, r *http.Request, params openapigen.PostDashboardV1AuthSsoCallbackParams) {
+	var req openapigen.PostDashboardV1AuthSsoCallbackJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	var browserState string
+	if params.SsoState != nil {
+		browserState = *params.SsoState
+	}
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+	result, err := a.authUC.CompleteSSOLogin(req.Code, req.State, browserState, client)
+	// the state is single use either way
+	setSSOStateCookie(w, r, "", -1)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toLoginResponse(result))
+}
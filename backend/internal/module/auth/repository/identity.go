@@ -0,0 +1,32 @@
+package repository
+
+import (
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// GetUserByIdentity finds the user linked to an account at an external identity provider.
+func (r *User) GetUserByIdentity(issuer string, subject string) (*entity.User, error) {
+	return scanUser(r.db.QueryRow(
+		`SELECT `+userColumns+` FROM users WHERE id = (SELECT user_id FROM user_identities WHERE issuer = ? AND subject = ?)`,
+		issuer, subject,
+	))
+}
+
+// LinkIdentity records that the IdP account issuer/subject belongs to the user.
+// Linking an account that is already linked to the same user does nothing.
+func (r *User) LinkIdentity(userID string, issuer string, subject string) error {
+	res, err := r.db.Exec(
+		`INSERT INTO user_identities(user_id, issuer, subject, created_at) VALUES (?, ?, ?, ?)
+		ON CONFLICT (issuer, subject) DO UPDATE SET user_id = user_id WHERE user_id = excluded.user_id`,
+		userID, issuer, subject, time.Now().UTC(),
+	)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return entity.ErrorConflict("this identity is linked to another user")
+	}
+	return nil
+}
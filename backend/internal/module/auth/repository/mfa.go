@@ -0,0 +1,115 @@
+package repository
+
+import (
+	"database/sql"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// EnableMFA stores the confirmed TOTP secret and a fresh set of recovery codes.
+func (r *User) EnableMFA(id string, secret string, recoveryCodeHashes []string) error {
+	return r.inTx(func(tx *sql.Tx) error {
+		res, err := tx.Exec(`UPDATE users SET totp_secret = ?, mfa_enabled = 1 WHERE id = ?`, secret, id)
+		if err != nil {
+			return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		if n, _ := res.RowsAffected(); n == 0 {
+			return entity.ErrorNotFound("user not found")
+		}
+		return replaceRecoveryCodes(tx, id, recoveryCodeHashes)
+	})
+}
+
+// DisableMFA removes the TOTP secret and every recovery code of the user.
+func (r *User) DisableMFA(id string) error {
+	return r.inTx(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(`UPDATE users SET totp_secret = '', mfa_enabled = 0 WHERE id = ?`, id); err != nil {
+			return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		return replaceRecoveryCodes(tx, id, nil)
+	})
+}
+
+func (r *User) ReplaceRecoveryCodes(id string, recoveryCodeHashes []string) error {
+	return r.inTx(func(tx *sql.Tx) error {
+		return replaceRecoveryCodes(tx, id, recoveryCodeHashes)
+	})
+}
+
+// UseRecoveryCode marks an unused recovery code as used and reports whether one matched.
+func (r *User) UseRecoveryCode(id string, codeHash string) (bool, error) {
+	res, err := r.db.Exec(
+		`UPDATE recovery_codes SET used_at = ? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL`,
+		time.Now().UTC(), id, codeHash,
+	)
+	if err != nil {
+		return false, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	n, _ := res.RowsAffected()
+	return n > 0, nil
+}
+
+// GetRolePolicy returns the policy of a role; roles without a row use the defaults.
+func (r *User) GetRolePolicy(role string) (*entity.RolePolicy, error) {
+	p := entity.RolePolicy{Role: role}
+	err := r.db.QueryRow(`SELECT mfa_required FROM role_policies WHERE role = ?`, role).Scan(&p.MFARequired)
+	if err != nil && err != sql.ErrNoRows {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return &p, nil
+}
+
+// ListRolePolicies returns the policy of every known role.
+func (r *User) ListRolePolicies() ([]*entity.RolePolicy, error) {
+	roles := []string{entity.RoleCS, entity.RoleOperation, entity.RoleSuperuser}
+	policies := make([]*entity.RolePolicy, 0, len(roles))
+	for _, role := range roles {
+		p, err := r.GetRolePolicy(role)
+		if err != nil {
+			return nil, err
+		}
+		policies = append(policies, p)
+	}
+	return policies, nil
+}
+
+func (r *User) SetRolePolicy(policy *entity.RolePolicy) error {
+	_, err := r.db.Exec(
+		`INSERT INTO role_policies(role, mfa_required) VALUES (?, ?)
+		ON CONFLICT(role) DO UPDATE SET mfa_required = excluded.mfa_required`,
+		policy.Role, policy.MFARequired,
+	)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+func replaceRecoveryCodes(tx *sql.Tx, id string, hashes []string) error {
+	if _, err := tx.Exec(`DELETE FROM recovery_codes WHERE user_id = ?`, id); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	for _, h := range hashes {
+		if _, err := tx.Exec(`INSERT INTO recovery_codes(user_id, code_hash) VALUES (?, ?)`, id, h); err != nil {
+			return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+	}
+	return nil
+}
+
+// inTx runs fn in a transaction, committing only if it succeeds.
+func (r *User) inTx(fn func(tx *sql.Tx) error) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if err := fn(tx); err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
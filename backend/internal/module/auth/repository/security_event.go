@@ -0,0 +1,58 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// RecordSecurityEvent stores an event along with the current emails of its user
+// and actor.
+func (r *User) RecordSecurityEvent(event *entity.SecurityEvent) error {
+	_, err := r.db.Exec(
+		`INSERT INTO security_events(type, user_id, user_email, actor_id, actor_email, session_id, ip, user_agent, detail, created_at)
+		 VALUES (?, ?, COALESCE((SELECT email FROM users WHERE id = ?), ''), ?, COALESCE((SELECT email FROM users WHERE id = ?), ''), ?, ?, ?, ?, ?)`,
+		event.Type, event.UserID, event.UserID,
+		sql.NullString{String: event.ActorID, Valid: event.ActorID != ""}, event.ActorID,
+		event.SessionID, event.IP, event.UserAgent, event.Detail, event.CreatedAt,
+	)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// ListSecurityEvents returns a page of events, newest first, plus the total number of events.
+func (r *User) ListSecurityEvents(offset int, limit int) ([]*entity.SecurityEvent, int, error) {
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(1) FROM security_events`).Scan(&total); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	rows, err := r.db.Query(
+		`SELECT id, type, user_id, user_email, actor_id, actor_email, session_id, ip, user_agent, detail, created_at
+		FROM security_events ORDER BY id DESC LIMIT ? OFFSET ?`, limit, offset,
+	)
+	if err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+
+	events := []*entity.SecurityEvent{}
+	for rows.Next() {
+		var (
+			e       entity.SecurityEvent
+			actorID sql.NullString
+		)
+		if err := rows.Scan(&e.ID, &e.Type, &e.UserID, &e.UserEmail, &actorID, &e.ActorEmail, &e.SessionID, &e.IP, &e.UserAgent, &e.Detail, &e.CreatedAt); err != nil {
+			return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		e.ActorID = actorID.String
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	return events, total, nil
+}
@@ -2,13 +2,35 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
+	"strconv"
 
 	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/mattn/go-sqlite3"
 )
 
 type UserRepository interface {
 	GetUserByEmail(email string) (*entity.User, error)
 	GetUserByID(id string) (*entity.User, error)
+	UpdateProfile(id string, update entity.ProfileUpdate) (*entity.User, error)
+	ListUsers(offset int, limit int) (users []*entity.User, total int, err error)
+	CreateUser(user *entity.User) (*entity.User, error)
+	UpdateRole(id string, role string) (*entity.User, error)
+	SetActive(id string, active bool) (*entity.User, error)
+	DeleteUser(id string) error
+	UpdatePassword(id string, passwordHash string) error
+	UpgradePasswordHash(id string, oldHash string, newHash string) error
+	EnableMFA(id string, secret string, recoveryCodeHashes []string) error
+	DisableMFA(id string) error
+	ReplaceRecoveryCodes(id string, recoveryCodeHashes []string) error
+	UseRecoveryCode(id string, codeHash string) (bool, error)
+	GetRolePolicy(role string) (*entity.RolePolicy, error)
+	ListRolePolicies() ([]*entity.RolePolicy, error)
+	SetRolePolicy(policy *entity.RolePolicy) error
+	GetUserByIdentity(issuer string, subject string) (*entity.User, error)
+	LinkIdentity(userID string, issuer string, subject string) error
+	RecordSecurityEvent(event *entity.SecurityEvent) error
+	ListSecurityEvents(offset int, limit int) (events []*entity.SecurityEvent, total int, err error)
 }
 
 type User struct {
@@ -19,10 +41,16 @@ func NewUserRepo(db *sql.DB) *User {
 	return &User{db: db}
 }
 
-func (r *User) GetUserByEmail(email string) (*entity.User, error) {
-	row := r.db.QueryRow(`SELECT id, email, password_hash, role FROM users WHERE email = ?`, email)
+const userColumns = "id, email, password_hash, role, display_name, timezone, locale, active, mfa_enabled, totp_secret"
+
+// rowScanner is satisfied by both *sql.Row and *sql.Rows.
+type rowScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUser(row rowScanner) (*entity.User, error) {
 	var u entity.User
-	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role); err != nil {
+	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role, &u.DisplayName, &u.Timezone, &u.Locale, &u.Active, &u.MFAEnabled, &u.TOTPSecret); err != nil {
 		if err == sql.ErrNoRows {
 			return nil, entity.ErrorNotFound("user not found")
 		}
@@ -31,14 +59,122 @@ func (r *User) GetUserByEmail(email string) (*entity.User, error) {
 	return &u, nil
 }
 
+func (r *User) GetUserByEmail(email string) (*entity.User, error) {
+	return scanUser(r.db.QueryRow(`SELECT `+userColumns+` FROM users WHERE email = ?`, email))
+}
+
 func (r *User) GetUserByID(id string) (*entity.User, error) {
-	row := r.db.QueryRow(`SELECT id, email, password_hash, role FROM users WHERE id = ?`, id)
-	var u entity.User
-	if err := row.Scan(&u.ID, &u.Email, &u.PasswordHash, &u.Role); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, entity.ErrorNotFound("user not found")
+	return scanUser(r.db.QueryRow(`SELECT `+userColumns+` FROM users WHERE id = ?`, id))
+}
+
+// UpdateProfile applies the non-nil fields of update and returns the updated user.
+func (r *User) UpdateProfile(id string, update entity.ProfileUpdate) (*entity.User, error) {
+	return r.updateAndGet(id,
+		`UPDATE users SET
+		  display_name = COALESCE(?, display_name),
+		  timezone = COALESCE(?, timezone),
+		  locale = COALESCE(?, locale)
+		WHERE id = ?`,
+		update.DisplayName, update.Timezone, update.Locale, id,
+	)
+}
+
+// ListUsers returns a page of users ordered by id, plus the total number of users.
+func (r *User) ListUsers(offset int, limit int) ([]*entity.User, int, error) {
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(1) FROM users`).Scan(&total); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	rows, err := r.db.Query(`SELECT `+userColumns+` FROM users ORDER BY id LIMIT ? OFFSET ?`, limit, offset)
+	if err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+
+	users := []*entity.User{}
+	for rows.Next() {
+		u, err := scanUser(rows)
+		if err != nil {
+			return nil, 0, err
+		}
+		users = append(users, u)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+
+	return users, total, nil
+}
+
+// CreateUser inserts a new user and returns it as stored.
+func (r *User) CreateUser(user *entity.User) (*entity.User, error) {
+	res, err := r.db.Exec(
+		`INSERT INTO users(email, password_hash, role, display_name, active) VALUES (?, ?, ?, ?, ?)`,
+		user.Email, user.PasswordHash, user.Role, user.DisplayName, user.Active,
+	)
+	if err != nil {
+		var sqliteErr sqlite3.Error
+		if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintUnique {
+			return nil, entity.ErrorConflict("a user with this email already exists")
 		}
 		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
 	}
-	return &u, nil
+
+	id, err := res.LastInsertId()
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return r.GetUserByID(strconv.FormatInt(id, 10))
+}
+
+func (r *User) UpdateRole(id string, role string) (*entity.User, error) {
+	return r.updateAndGet(id, `UPDATE users SET role = ? WHERE id = ?`, role, id)
+}
+
+func (r *User) SetActive(id string, active bool) (*entity.User, error) {
+	return r.updateAndGet(id, `UPDATE users SET active = ? WHERE id = ?`, active, id)
+}
+
+func (r *User) DeleteUser(id string) error {
+	res, err := r.db.Exec(`DELETE FROM users WHERE id = ?`, id)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return entity.ErrorNotFound("user not found")
+	}
+	return nil
+}
+
+func (r *User) UpdatePassword(id string, passwordHash string) error {
+	res, err := r.db.Exec(`UPDATE users SET password_hash = ? WHERE id = ?`, passwordHash, id)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return entity.ErrorNotFound("user not found")
+	}
+	return nil
+}
+
+// UpgradePasswordHash swaps in a stronger hash of the same password. It does
+// nothing when the password was changed since oldHash was read.
+func (r *User) UpgradePasswordHash(id string, oldHash string, newHash string) error {
+	if _, err := r.db.Exec(`UPDATE users SET password_hash = ? WHERE id = ? AND password_hash = ?`, newHash, id, oldHash); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// updateAndGet runs a single-row update and returns the updated user.
+func (r *User) updateAndGet(id string, query string, args ...any) (*entity.User, error) {
+	res, err := r.db.Exec(query, args...)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	if n, _ := res.RowsAffected(); n == 0 {
+		return nil, entity.ErrorNotFound("user not found")
+	}
+	return r.GetUserByID(id)
 }
@@ -6,72 +6,146 @@ import (
 
 	"github.com/durianpay/fullstack-boilerplate/internal/entity"
 	"github.com/durianpay/fullstack-boilerplate/internal/module/auth/repository"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/jwtkeys"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/mailer"
+	passwordsvc "github.com/durianpay/fullstack-boilerplate/internal/service/password"
 	redissvc "github.com/durianpay/fullstack-boilerplate/internal/service/redis"
 	"github.com/golang-jwt/jwt/v5"
-	goredis "github.com/redis/go-redis/v9"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/google/uuid"
 )
 
-const refreshTTL = 7 * 24 * time.Hour
+const RefreshTokenTTL = 7 * 24 * time.Hour
 
 type AuthUsecase interface {
-	Login(email string, password string) (accessToken string, refreshToken string, user *entity.User, err error)
-	RefreshAccessToken(refreshToken string) (accessToken string, newRefreshToken string, err error)
+	Login(email string, password string, client entity.ClientInfo) (*entity.LoginResult, error)
+	CompleteMFALogin(mfaToken string, code string, client entity.ClientInfo) (*entity.LoginResult, error)
+	BeginMFALoginEnrollment(mfaToken string) (*entity.MFAEnrollment, error)
+	RefreshAccessToken(refreshToken string, client entity.ClientInfo) (accessToken string, newRefreshToken string, err error)
+	Logout(caller *entity.Principal) error
+	ListSessions(caller *entity.Principal) ([]*entity.Session, error)
+	RevokeSession(caller *entity.Principal, sessionID string) error
+	RevokeOtherSessions(caller *entity.Principal) error
+	RevokeAllSessions(userID string) error
+	UnlockLogin(email string) error
+	ChangePassword(caller *entity.Principal, currentPassword string, newPassword string, client entity.ClientInfo) error
+	RequestPasswordReset(email string, client entity.ClientInfo) error
+	ResetPassword(token string, newPassword string) error
+	BeginMFAEnrollment(caller *entity.Principal) (*entity.MFAEnrollment, error)
+	ActivateMFA(caller *entity.Principal, code string, client entity.ClientInfo) ([]string, error)
+	DisableMFA(caller *entity.Principal, password string, code string, client entity.ClientInfo) error
+	RegenerateRecoveryCodes(caller *entity.Principal, code string, client entity.ClientInfo) ([]string, error)
+	IsAccessTokenRevoked(ctx context.Context, jti string, sessionID string) (bool, error)
+	ListSigningKeys() []*entity.SigningKey
+	RotateSigningKey() (*entity.SigningKey, error)
+	JWKS() []jwtkeys.JWK
+	ListSecurityEvents(page int, pageSize int) (*entity.SecurityEventPage, error)
+	BeginSSOLogin() (*entity.SSOAuthorization, error)
+	CompleteSSOLogin(code string, state string, browserState string, client entity.ClientInfo) (*entity.LoginResult, error)
+	Impersonate(actor *entity.Principal, target *entity.User, reason string, client entity.ClientInfo) (*entity.Impersonation, error)
+	RecordImpersonatedRequest(ctx context.Context, p *entity.Principal, client entity.ClientInfo, request string) error
 }
 
 type Auth struct {
-	repo      repository.UserRepository
-	redis     *redissvc.Client
-	jwtSecret []byte
-	ttl       time.Duration
+	repo             repository.UserRepository
+	redis            *redissvc.Client
+	mailer           mailer.Mailer
+	keys             *jwtkeys.KeyRing
+	passwords        passwordsvc.Hasher
+	policy           *passwordsvc.Policy
+	ttl              time.Duration
+	impersonationTTL time.Duration
+	passwordResetURL string
+	lockout          LoginLockout
+	sso              SSO
 }
 
-func NewAuthUsecase(repo repository.UserRepository, redis *redissvc.Client, jwtSecret []byte, ttl time.Duration) *Auth {
-	return &Auth{repo: repo, redis: redis, jwtSecret: jwtSecret, ttl: ttl}
+func NewAuthUsecase(repo repository.UserRepository, redis *redissvc.Client, mailer mailer.Mailer, keys *jwtkeys.KeyRing, passwords passwordsvc.Hasher, policy *passwordsvc.Policy, ttl time.Duration, impersonationTTL time.Duration, passwordResetURL string, lockout LoginLockout, sso SSO) *Auth {
+	return &Auth{repo: repo, redis: redis, mailer: mailer, keys: keys, passwords: passwords, policy: policy, ttl: ttl, impersonationTTL: impersonationTTL, passwordResetURL: passwordResetURL, lockout: lockout, sso: sso}
 }
 
-func refreshKey(userID string) string {
-	return "refresh:" + userID
+func denylistKey(jti string) string {
+	return "denylist:" + jti
 }
 
 // Verify email + password and returns access and refresh tokens.
-// The refresh token is persisted in Redis so only the latest token is valid.
-func (a *Auth) Login(email string, password string) (string, string, *entity.User, error) {
+// Every login opens a new session, so several devices can stay signed in at once.
+// Failed attempts are throttled per email and client IP, see LoginLockout.
+// When the user has 2FA enabled, or their role requires it, only an MFA token is
+// returned and the login is finished by CompleteMFALogin.
+func (a *Auth) Login(email string, password string, client entity.ClientInfo) (*entity.LoginResult, error) {
+	key := normalizeEmail(email)
+	if err := a.checkLoginAllowed(key, client.IP); err != nil {
+		return nil, err
+	}
+
 	user, err := a.repo.GetUserByEmail(email)
+	if appErr, ok := err.(*entity.AppError); ok && appErr.Code == entity.ErrorCodeNotFound {
+		if err := a.recordLoginFailure(key, client.IP); err != nil {
+			return nil, err
+		}
+		return nil, err
+	}
 	if err != nil {
-		return "", "", nil, err
+		return nil, err
 	}
 	if user.ID == "" {
-		return "", "", nil, entity.ErrorNotFound("user not found")
+		return nil, entity.ErrorNotFound("user not found")
+	}
+	ok, err := a.passwords.Verify(user.PasswordHash, password)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "failed to verify password")
+	}
+	if !ok {
+		if err := a.recordLoginFailure(key, client.IP); err != nil {
+			return nil, err
+		}
+		return nil, entity.ErrorUnauthorized("Invalid credentials")
 	}
-	if err := bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)); err != nil {
-		return "", "", nil, entity.WrapError(err, entity.ErrorCodeUnauthorized, "Invalid credentials")
+	if !user.Active {
+		return nil, entity.ErrorForbidden("account is deactivated")
 	}
+	a.upgradePasswordHash(user, password)
 
-	accessToken, err := a.generateAccessToken(user)
-	if err != nil {
-		return "", "", nil, err
+	// the failure counter is only cleared once the second factor passes too
+	if challenge, err := a.secondFactorChallenge(user); challenge != nil || err != nil {
+		return challenge, err
 	}
 
-	refreshToken, err := a.generateRefreshToken(user)
-	if err != nil {
-		return "", "", nil, err
+	if err := a.UnlockLogin(key); err != nil {
+		return nil, err
 	}
 
-	// Store refresh token in Redis (single-token-per-user rotation)
-	if err := a.redis.Set(context.Background(), refreshKey(user.ID), refreshToken, refreshTTL); err != nil {
-		return "", "", nil, entity.ErrorInternal("failed to persist refresh token")
+	accessToken, refreshToken, err := a.openSession(user, client)
+	if err != nil {
+		return nil, err
 	}
 
-	return accessToken, refreshToken, user, nil
+	return &entity.LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// secondFactorChallenge starts an MFA challenge when the user has 2FA enabled or
+// their role requires it, and returns nil when the login may go ahead.
+func (a *Auth) secondFactorChallenge(user *entity.User) (*entity.LoginResult, error) {
+	if user.MFAEnabled {
+		return a.startMFAChallenge(user, false)
+	}
+	policy, err := a.repo.GetRolePolicy(user.Role)
+	if err != nil {
+		return nil, err
+	}
+	if policy.MFARequired {
+		return a.startMFAChallenge(user, true)
+	}
+	return nil, nil
 }
 
 // Generate a new access+refresh token pair from a valid refresh token.
-func (a *Auth) RefreshAccessToken(refreshToken string) (string, string, error) {
+// The refresh token is rotated within its session; other sessions are untouched.
+// A session is a token family: every refresh token it issues carries the next
+// generation, and only the latest one is accepted.
+func (a *Auth) RefreshAccessToken(refreshToken string, client entity.ClientInfo) (string, string, error) {
 	// Parse and validate the refresh token
-	token, err := jwt.ParseWithClaims(refreshToken, &jwt.MapClaims{}, func(token *jwt.Token) (interface{}, error) {
-		return a.jwtSecret, nil
-	})
+	token, err := jwt.ParseWithClaims(refreshToken, &jwt.MapClaims{}, a.keys.Keyfunc, jwt.WithValidMethods(a.keys.ValidMethods()))
 
 	if err != nil || !token.Valid {
 		return "", "", entity.ErrorUnauthorized("invalid refresh token")
@@ -87,20 +161,36 @@ func (a *Auth) RefreshAccessToken(refreshToken string) (string, string, error) {
 		return "", "", entity.ErrorUnauthorized("invalid token type")
 	}
 
-	// Get user ID from token
-	userID, ok := (*claims)["sub"].(string)
-	if !ok {
+	// Get user, session and token IDs from token; tokens issued before
+	// generations were tracked have none and count as generation 0
+	userID, _ := (*claims)["sub"].(string)
+	sessionID, _ := (*claims)["sid"].(string)
+	jti, _ := (*claims)["jti"].(string)
+	gen, _ := (*claims)["gen"].(float64)
+	exp, _ := claims.GetExpirationTime()
+	if userID == "" || sessionID == "" || jti == "" || exp == nil {
 		return "", "", entity.ErrorUnauthorized("invalid token claims")
 	}
 
-	// Verify the token matches what's stored in Redis
-	stored, err := a.redis.Get(context.Background(), refreshKey(userID))
-	if err == goredis.Nil || stored != refreshToken {
-		_ = a.redis.Del(context.Background(), refreshKey(userID)) // cleanup existing token if any
+	// Verify the token is the latest one of its session
+	sess, err := a.getSession(sessionID)
+	if err != nil {
+		return "", "", err
+	}
+	if sess == nil || sess.UserID != userID {
 		return "", "", entity.ErrorUnauthorized("refresh token has been revoked")
 	}
+	if sess.RefreshTokenID != jti || sess.Generation != int(gen) {
+		return "", "", a.revokeReusedFamily(sess, int(gen), client)
+	}
+
+	// Claim the token so two concurrent refreshes with it cannot both succeed
+	claimed, err := a.redis.SetNX(context.Background(), refreshClaimKey(jti), "1", time.Until(exp.Time))
 	if err != nil {
-		return "", "", entity.ErrorInternal("failed to validate refresh token")
+		return "", "", entity.ErrorInternal("failed to rotate refresh token")
+	}
+	if !claimed {
+		return "", "", a.revokeReusedFamily(sess, int(gen), client)
 	}
 
 	// Fetch user from db
@@ -112,52 +202,110 @@ func (a *Auth) RefreshAccessToken(refreshToken string) (string, string, error) {
 	if user.ID == "" {
 		return "", "", entity.ErrorNotFound("user not found")
 	}
+	if !user.Active {
+		_ = a.deleteSession(user.ID, sess.ID)
+		return "", "", entity.ErrorUnauthorized("account is deactivated")
+	}
 
 	// Generate new tokens
-	accessToken, err := a.generateAccessToken(user)
+	accessToken, err := a.generateAccessToken(user, sess.ID)
 	if err != nil {
 		return "", "", err
 	}
 
-	newRefreshToken, err := a.generateRefreshToken(user)
+	sess.Generation++
+	newRefreshToken, newJTI, err := a.generateRefreshToken(user, sess.ID, sess.Generation)
 	if err != nil {
 		return "", "", err
 	}
 
-	// store the new refresh token, invalidating the old one
-	if err := a.redis.Set(context.Background(), refreshKey(user.ID), newRefreshToken, refreshTTL); err != nil {
-		return "", "", entity.ErrorInternal("failed to persist refresh token")
+	// store the new refresh token id, invalidating the old one
+	sess.RefreshTokenID = newJTI
+	sess.LastUsedAt = time.Now().UTC()
+	if err := a.saveSession(sess); err != nil {
+		return "", "", err
+	}
+	revoked, err := a.redis.Exists(context.Background(), revokedFamilyKey(sess.ID))
+	if err != nil {
+		return "", "", entity.ErrorInternal("failed to rotate refresh token")
+	}
+	if revoked {
+		_ = a.deleteSession(sess.UserID, sess.ID)
+		return "", "", entity.ErrorUnauthorized("refresh token has been revoked")
 	}
 
 	return accessToken, newRefreshToken, nil
 }
 
-func (a *Auth) generateAccessToken(user *entity.User) (string, error) {
-	claims := jwt.MapClaims{
-		"sub":  user.ID,
-		"exp":  time.Now().Add(a.ttl).Unix(),
-		"iat":  time.Now().Unix(),
-		"type": "access",
+// Revoke the caller's session and denylist its access token until it expires.
+// An impersonation token shares the superuser's session, so only the token is denylisted.
+func (a *Auth) Logout(caller *entity.Principal) error {
+	if caller.ActorID == "" {
+		if err := a.deleteSession(caller.UserID, caller.SessionID); err != nil {
+			return err
+		}
+	}
+
+	// Keep the denylist entry only as long as the token would have been valid
+	if ttl := time.Until(caller.ExpiresAt); ttl > 0 {
+		if err := a.redis.Set(context.Background(), denylistKey(caller.TokenID), "1", ttl); err != nil {
+			return entity.ErrorInternal("failed to revoke access token")
+		}
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(a.jwtSecret)
+
+	return nil
+}
+
+// IsAccessTokenRevoked reports whether the access token was logged out or its session revoked.
+func (a *Auth) IsAccessTokenRevoked(ctx context.Context, jti string, sessionID string) (bool, error) {
+	denied, err := a.redis.Exists(ctx, denylistKey(jti))
+	if err != nil || denied {
+		return denied, err
+	}
+	alive, err := a.redis.Exists(ctx, sessionKey(sessionID))
+	if err != nil {
+		return false, err
+	}
+	return !alive, nil
+}
+
+func (a *Auth) generateAccessToken(user *entity.User, sessionID string) (string, error) {
+	signed, err := a.keys.Sign(accessTokenClaims(user, sessionID, time.Now().Add(a.ttl)))
 	if err != nil {
 		return "", entity.WrapError(err, entity.ErrorCodeUnauthorized, "failed to sign token")
 	}
 	return signed, nil
 }
 
-func (a *Auth) generateRefreshToken(user *entity.User) (string, error) {
+func accessTokenClaims(user *entity.User, sessionID string, expiresAt time.Time) jwt.MapClaims {
+	return jwt.MapClaims{
+		"sub":   user.ID,
+		"sid":   sessionID,
+		"email": user.Email,
+		"role":  user.Role,
+		"exp":   expiresAt.Unix(),
+		"iat":   time.Now().Unix(),
+		"jti":   uuid.NewString(),
+		"type":  "access",
+	}
+}
+
+// generateRefreshToken returns the signed token together with its jti,
+// which the session keeps to recognise the latest token.
+func (a *Auth) generateRefreshToken(user *entity.User, sessionID string, generation int) (string, string, error) {
+	jti := uuid.NewString()
 	claims := jwt.MapClaims{
 		"sub":  user.ID,
-		"exp":  time.Now().Add(refreshTTL).Unix(),
+		"sid":  sessionID,
+		"gen":  generation,
+		"exp":  time.Now().Add(RefreshTokenTTL).Unix(),
 		"iat":  time.Now().Unix(),
+		"jti":  jti,
 		"type": "refresh",
 	}
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	signed, err := token.SignedString(a.jwtSecret)
+	signed, err := a.keys.Sign(claims)
 	if err != nil {
-		return "", entity.WrapError(err, entity.ErrorCodeUnauthorized, "failed to sign token")
+		return "", "", entity.WrapError(err, entity.ErrorCodeUnauthorized, "failed to sign token")
 	}
-	return signed, nil
+	return signed, jti, nil
 }
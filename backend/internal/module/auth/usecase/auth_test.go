@@ -0,0 +1,207 @@
+package usecase
+
+import (
+	"context"
+	"database/sql"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/module/auth/repository"
+	"github.com/durianpay/fullstack-boilerplate/internal/seeder"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/jwtkeys"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/mailer"
+	passwordsvc "github.com/durianpay/fullstack-boilerplate/internal/service/password"
+	redissvc "github.com/durianpay/fullstack-boilerplate/internal/service/redis"
+	"github.com/golang-jwt/jwt/v5"
+	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testLockout is small enough for the tests to reach every threshold.
+var testLockout = LoginLockout{
+	BackoffAfter:    3,
+	BackoffBase:     time.Second,
+	MaxAttempts:     5,
+	IPMaxAttempts:   8,
+	LockoutDuration: 15 * time.Minute,
+}
+
+var testClient = entity.ClientInfo{UserAgent: "test", IP: "203.0.113.7"}
+
+// outbox keeps the mail an Auth sends.
+type outbox struct {
+	mu   sync.Mutex
+	sent []mailer.Message
+}
+
+func (o *outbox) Send(_ context.Context, msg mailer.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	o.sent = append(o.sent, msg)
+	return nil
+}
+
+type testAuth struct {
+	*Auth
+	db    *sql.DB
+	redis *miniredis.Miniredis
+	mail  *outbox
+}
+
+// newTestAuth returns an Auth on a freshly seeded database and an in-memory Redis.
+func newTestAuth(t *testing.T) *testAuth {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db")+"?_foreign_keys=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	passwords, err := passwordsvc.NewHasher(passwordsvc.AlgBcrypt, bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := seeder.Seed(db, passwords); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	keys, err := jwtkeys.NewKeyRing(t.TempDir(), "EdDSA", time.Hour, nil, false, nil)
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	mr := miniredis.RunT(t)
+	mail := &outbox{}
+	policy := &passwordsvc.Policy{MinLength: 8, MinClasses: 3}
+
+	a := NewAuthUsecase(repository.NewUserRepo(db), redissvc.NewClient(mr.Addr()), mail, keys, passwords, policy,
+		time.Hour, 10*time.Minute, "http://localhost/reset-password", testLockout, SSO{})
+	return &testAuth{Auth: a, db: db, redis: mr, mail: mail}
+}
+
+// principal signs email in and returns the caller a handler would see.
+func (a *testAuth) principal(t *testing.T, email string) *entity.Principal {
+	t.Helper()
+	user, err := a.repo.GetUserByEmail(email)
+	if err != nil {
+		t.Fatalf("GetUserByEmail(%s): %v", email, err)
+	}
+	return &entity.Principal{UserID: user.ID, Email: user.Email, Role: user.Role}
+}
+
+// wantCode fails unless err is an AppError with the given code.
+func wantCode(t *testing.T, err error, code entity.Code) {
+	t.Helper()
+	appErr, ok := err.(*entity.AppError)
+	if !ok || appErr.Code != code {
+		t.Fatalf("err = %v, want %s", err, code)
+	}
+}
+
+// tokenClaims reads the claims of a token this Auth signed.
+func tokenClaims(t *testing.T, token string) jwt.MapClaims {
+	t.Helper()
+	claims := jwt.MapClaims{}
+	if _, _, err := jwt.NewParser().ParseUnverified(token, claims); err != nil {
+		t.Fatal(err)
+	}
+	return claims
+}
+
+// sessionRevoked reports whether the session of an access token is gone.
+func (a *testAuth) sessionRevoked(t *testing.T, accessToken string) bool {
+	t.Helper()
+	claims := tokenClaims(t, accessToken)
+	jti, _ := claims["jti"].(string)
+	sid, _ := claims["sid"].(string)
+	revoked, err := a.IsAccessTokenRevoked(context.Background(), jti, sid)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return revoked
+}
+
+func TestRefreshRotatesTheToken(t *testing.T) {
+	a := newTestAuth(t)
+	login, err := a.Login("cs@test.com", "password", testClient)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	refresh := login.RefreshToken
+	for i := 1; i <= 3; i++ {
+		access, next, err := a.RefreshAccessToken(refresh, testClient)
+		if err != nil {
+			t.Fatalf("refresh %d: %v", i, err)
+		}
+		if next == refresh || tokenClaims(t, next)["gen"] != float64(i) {
+			t.Fatalf("refresh %d: got generation %v, want %d", i, tokenClaims(t, next)["gen"], i)
+		}
+		if a.sessionRevoked(t, access) {
+			t.Fatalf("refresh %d: session revoked", i)
+		}
+		refresh = next
+	}
+}
+
+func TestRefreshTokenReuseRevokesTheFamily(t *testing.T) {
+	tests := []struct {
+		name  string
+		reuse func(t *testing.T, a *testAuth, first, second string) error
+	}{
+		{"token already rotated", func(t *testing.T, a *testAuth, first, _ string) error {
+			_, _, err := a.RefreshAccessToken(first, testClient)
+			return err
+		}},
+		{"token claimed by a concurrent refresh", func(t *testing.T, a *testAuth, _, second string) error {
+			// another refresh with the latest token got there first
+			jti, _ := tokenClaims(t, second)["jti"].(string)
+			a.redis.Set(refreshClaimKey(jti), "1")
+			_, _, err := a.RefreshAccessToken(second, testClient)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			login, err := a.Login("cs@test.com", "password", testClient)
+			if err != nil {
+				t.Fatal(err)
+			}
+			other, err := a.Login("cs@test.com", "password", testClient)
+			if err != nil {
+				t.Fatal(err)
+			}
+			access, second, err := a.RefreshAccessToken(login.RefreshToken, testClient)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			wantCode(t, tt.reuse(t, a, login.RefreshToken, second), entity.ErrorCodeUnauthorized)
+
+			// neither holder of the family can go on
+			_, _, err = a.RefreshAccessToken(second, testClient)
+			wantCode(t, err, entity.ErrorCodeUnauthorized)
+			if !a.sessionRevoked(t, access) {
+				t.Error("access token of the reused family still works")
+			}
+			// the user's other sessions are not touched
+			if a.sessionRevoked(t, other.AccessToken) {
+				t.Error("another session was revoked")
+			}
+			if _, _, err := a.RefreshAccessToken(other.RefreshToken, testClient); err != nil {
+				t.Errorf("refresh of another session: %v", err)
+			}
+
+			events, _, err := a.repo.ListSecurityEvents(0, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(events) != 1 || events[0].Type != entity.SecurityEventRefreshTokenReuse {
+				t.Errorf("security events = %+v, want one refresh_token_reuse", events)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+package usecase
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// Impersonate issues an access token for target whose act claim (RFC 8693)
+// names the superuser. The token carries the superuser's session ID, so it stops
+// working when that session ends, and it comes without a refresh token.
+// The caller is expected to have checked that target may be impersonated.
+func (a *Auth) Impersonate(actor *entity.Principal, target *entity.User, reason string, client entity.ClientInfo) (*entity.Impersonation, error) {
+	if actor.ActorID != "" {
+		return nil, entity.ErrorForbidden("an impersonation token cannot start another impersonation")
+	}
+
+	expiresAt := time.Now().Add(a.impersonationTTL)
+	claims := accessTokenClaims(target, actor.SessionID, expiresAt)
+	claims["act"] = map[string]any{"sub": actor.UserID, "email": actor.Email}
+
+	// without the audit record there is no token
+	err := a.repo.RecordSecurityEvent(&entity.SecurityEvent{
+		Type:      entity.SecurityEventImpersonationStarted,
+		UserID:    target.ID,
+		ActorID:   actor.UserID,
+		SessionID: actor.SessionID,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		Detail:    reason,
+		CreatedAt: time.Now().UTC(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	log.Printf("auth: user %s started impersonating user %s: %s", actor.UserID, target.ID, reason)
+
+	signed, err := a.keys.Sign(claims)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "failed to sign token")
+	}
+	return &entity.Impersonation{User: target, AccessToken: signed, ExpiresAt: expiresAt}, nil
+}
+
+// RecordImpersonatedRequest attributes one request made with an impersonation
+// token to the superuser behind it.
+func (a *Auth) RecordImpersonatedRequest(_ context.Context, p *entity.Principal, client entity.ClientInfo, request string) error {
+	return a.repo.RecordSecurityEvent(&entity.SecurityEvent{
+		Type:      entity.SecurityEventImpersonatedRequest,
+		UserID:    p.UserID,
+		ActorID:   p.ActorID,
+		SessionID: p.SessionID,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		Detail:    request,
+		CreatedAt: time.Now().UTC(),
+	})
+}
@@ -0,0 +1,40 @@
+package usecase
+
+import (
+	"errors"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/jwtkeys"
+)
+
+func toSigningKey(k *jwtkeys.Key) *entity.SigningKey {
+	return &entity.SigningKey{ID: k.ID, Alg: k.Alg, CreatedAt: k.CreatedAt, RetiredAt: k.RetiredAt}
+}
+
+// ListSigningKeys returns the active signing key first, then the retired keys still accepted.
+func (a *Auth) ListSigningKeys() []*entity.SigningKey {
+	keys := a.keys.Keys()
+	out := make([]*entity.SigningKey, 0, len(keys))
+	for _, k := range keys {
+		out = append(out, toSigningKey(k))
+	}
+	return out
+}
+
+// RotateSigningKey switches new tokens to a fresh key. Tokens signed with the
+// previous key stay valid until they expire.
+func (a *Auth) RotateSigningKey() (*entity.SigningKey, error) {
+	key, err := a.keys.Rotate()
+	if errors.Is(err, jwtkeys.ErrRotationUnsupported) {
+		return nil, entity.ErrorConflict("key rotation requires JWT_SIGNING_ALG RS256 or EdDSA")
+	}
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "failed to rotate signing key")
+	}
+	return toSigningKey(key), nil
+}
+
+// JWKS returns the public keys that verify our tokens.
+func (a *Auth) JWKS() []jwtkeys.JWK {
+	return a.keys.JWKS()
+}
@@ -0,0 +1,149 @@
+package usecase
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// LoginLockout configures how failed logins are throttled. Failures are
+// counted per email and per client IP and forgotten LockoutDuration after the
+// last one.
+type LoginLockout struct {
+	// BackoffAfter is the number of failures for an email before every further
+	// attempt is delayed, starting at BackoffBase and doubling each time.
+	BackoffAfter int
+	BackoffBase  time.Duration
+	// MaxAttempts failures for an email lock the account for LockoutDuration.
+	MaxAttempts int
+	// IPMaxAttempts failures from one client IP lock that IP for LockoutDuration.
+	IPMaxAttempts   int
+	LockoutDuration time.Duration
+}
+
+func loginFailuresKey(scope string, id string) string {
+	return "loginfail:" + scope + ":" + id
+}
+
+func loginLockKey(scope string, id string) string {
+	return "loginlock:" + scope + ":" + id
+}
+
+func normalizeEmail(email string) string {
+	return strings.ToLower(strings.TrimSpace(email))
+}
+
+// checkLoginAllowed fails with too_many_requests while the email or the client IP is locked.
+func (a *Auth) checkLoginAllowed(email string, ip string) error {
+	return a.checkNotLocked("too many failed login attempts, try again later",
+		loginLockKey("email", email), loginLockKey("ip", ip))
+}
+
+// checkNotLocked fails with too_many_requests and message while any of the lock keys is set.
+func (a *Auth) checkNotLocked(message string, locks ...string) error {
+	ctx := context.Background()
+	var wait time.Duration
+	for _, key := range locks {
+		ttl, err := a.redis.PTTL(ctx, key)
+		if err != nil {
+			return entity.ErrorInternal("failed to check login attempts")
+		}
+		if ttl > wait {
+			wait = ttl
+		}
+	}
+	if wait > 0 {
+		return entity.ErrorTooManyRequests(message, wait)
+	}
+	return nil
+}
+
+// recordLoginFailure counts a failed attempt and locks the email or IP when a threshold is crossed.
+func (a *Auth) recordLoginFailure(email string, ip string) error {
+	ctx := context.Background()
+	p := a.lockout
+
+	failures, err := a.countFailure(ctx, "email", email)
+	if err != nil {
+		return err
+	}
+	var lock time.Duration
+	switch {
+	case p.MaxAttempts > 0 && failures >= int64(p.MaxAttempts):
+		lock = p.LockoutDuration
+	case p.BackoffAfter > 0 && failures >= int64(p.BackoffAfter):
+		lock = p.BackoffBase << (failures - int64(p.BackoffAfter))
+		if lock <= 0 || lock > p.LockoutDuration {
+			lock = p.LockoutDuration
+		}
+	}
+	if lock > 0 {
+		if err := a.redis.Set(ctx, loginLockKey("email", email), "1", lock); err != nil {
+			return entity.ErrorInternal("failed to record login attempt")
+		}
+	}
+
+	return a.recordAttempt("ip", ip, p.IPMaxAttempts)
+}
+
+// recordAttempt counts an attempt for id in scope and locks it for the lockout
+// duration once max attempts were made. An empty id or a max of 0 is not limited.
+func (a *Auth) recordAttempt(scope string, id string, max int) error {
+	if id == "" || max <= 0 {
+		return nil
+	}
+	ctx := context.Background()
+	attempts, err := a.countFailure(ctx, scope, id)
+	if err != nil {
+		return err
+	}
+	if attempts >= int64(max) {
+		if err := a.redis.Set(ctx, loginLockKey(scope, id), "1", a.lockout.LockoutDuration); err != nil {
+			return entity.ErrorInternal("failed to record login attempt")
+		}
+	}
+	return nil
+}
+
+// verifyThrottled runs a password or code check for a signed-in user under the
+// login limits: it is refused while the user's email or the client IP is locked,
+// and a failed check counts as a failed login.
+func (a *Auth) verifyThrottled(email string, ip string, verify func() (bool, error)) (bool, error) {
+	email = normalizeEmail(email)
+	if err := a.checkLoginAllowed(email, ip); err != nil {
+		return false, err
+	}
+	ok, err := verify()
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		if err := a.recordLoginFailure(email, ip); err != nil {
+			return false, err
+		}
+	}
+	return ok, nil
+}
+
+func (a *Auth) countFailure(ctx context.Context, scope string, id string) (int64, error) {
+	key := loginFailuresKey(scope, id)
+	n, err := a.redis.Incr(ctx, key)
+	if err != nil {
+		return 0, entity.ErrorInternal("failed to record login attempt")
+	}
+	if err := a.redis.Expire(ctx, key, a.lockout.LockoutDuration); err != nil {
+		return 0, entity.ErrorInternal("failed to record login attempt")
+	}
+	return n, nil
+}
+
+// UnlockLogin clears the failed attempts and any lockout recorded for an email.
+func (a *Auth) UnlockLogin(email string) error {
+	email = normalizeEmail(email)
+	if err := a.redis.Del(context.Background(), loginFailuresKey("email", email), loginLockKey("email", email)); err != nil {
+		return entity.ErrorInternal("failed to unlock login")
+	}
+	return nil
+}
@@ -0,0 +1,144 @@
+package usecase
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// failLogins makes n logins with a wrong password, waiting out every backoff
+// in between, so that each of them counts.
+func failLogins(t *testing.T, a *testAuth, email string, ip string, n int) {
+	t.Helper()
+	client := entity.ClientInfo{UserAgent: "test", IP: ip}
+	for i := 0; i < n; i++ {
+		_, err := a.Login(email, "wrong", client)
+		if appErr, ok := err.(*entity.AppError); ok && appErr.Code == entity.ErrorCodeTooMany {
+			a.redis.FastForward(appErr.RetryAfter)
+			_, err = a.Login(email, "wrong", client)
+		}
+		if appErr, ok := err.(*entity.AppError); !ok || (appErr.Code != entity.ErrorCodeUnauthorized && appErr.Code != entity.ErrorCodeNotFound) {
+			t.Fatalf("failed login %d: err = %v", i+1, err)
+		}
+	}
+}
+
+func TestLoginBackoffAndLockout(t *testing.T) {
+	tests := []struct {
+		failures int
+		wait     time.Duration // 0 when the next attempt is allowed
+	}{
+		{testLockout.BackoffAfter - 1, 0},
+		{testLockout.BackoffAfter, testLockout.BackoffBase},
+		{testLockout.BackoffAfter + 1, 2 * testLockout.BackoffBase},
+		{testLockout.MaxAttempts, testLockout.LockoutDuration},
+	}
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("%d failures", tt.failures), func(t *testing.T) {
+			a := newTestAuth(t)
+			failLogins(t, a, "cs@test.com", testClient.IP, tt.failures)
+
+			_, err := a.Login("cs@test.com", "password", testClient)
+			if tt.wait == 0 {
+				if err != nil {
+					t.Fatalf("Login: %v", err)
+				}
+				return
+			}
+			wantCode(t, err, entity.ErrorCodeTooMany)
+			if got := err.(*entity.AppError).RetryAfter; got != tt.wait {
+				t.Errorf("RetryAfter = %v, want %v", got, tt.wait)
+			}
+			// the lock ends on its own
+			a.redis.FastForward(tt.wait)
+			if _, err := a.Login("cs@test.com", "password", testClient); err != nil {
+				t.Errorf("Login after the wait: %v", err)
+			}
+		})
+	}
+}
+
+func TestLoginLockoutIsPerEmailAndIP(t *testing.T) {
+	tests := []struct {
+		name   string
+		email  string
+		ip     string
+		locked bool
+	}{
+		{"locked email from another ip", "cs@test.com", "198.51.100.1", true},
+		{"another email from the same ip", "operation@test.com", testClient.IP, false},
+		// emails are compared as the user types them in
+		{"locked email in another case", " CS@test.com", "198.51.100.1", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			failLogins(t, a, "cs@test.com", testClient.IP, testLockout.MaxAttempts)
+
+			_, err := a.Login(tt.email, "password", entity.ClientInfo{UserAgent: "test", IP: tt.ip})
+			if tt.locked {
+				wantCode(t, err, entity.ErrorCodeTooMany)
+			} else if err != nil {
+				t.Fatalf("Login: %v", err)
+			}
+		})
+	}
+}
+
+func TestIPLockout(t *testing.T) {
+	a := newTestAuth(t)
+	// unknown emails count against the ip too
+	for i := 0; i < testLockout.IPMaxAttempts; i++ {
+		failLogins(t, a, fmt.Sprintf("nobody%d@test.com", i), testClient.IP, 1)
+	}
+
+	_, err := a.Login("cs@test.com", "password", testClient)
+	wantCode(t, err, entity.ErrorCodeTooMany)
+	if _, err := a.Login("cs@test.com", "password", entity.ClientInfo{UserAgent: "test", IP: "198.51.100.1"}); err != nil {
+		t.Fatalf("Login from another ip: %v", err)
+	}
+}
+
+func TestLoginFailuresAreCleared(t *testing.T) {
+	tests := []struct {
+		name  string
+		clear func(t *testing.T, a *testAuth)
+	}{
+		{"by a successful login", func(t *testing.T, a *testAuth) {
+			if _, err := a.Login("cs@test.com", "password", testClient); err != nil {
+				t.Fatalf("Login: %v", err)
+			}
+		}},
+		{"by an unlock", func(t *testing.T, a *testAuth) {
+			if err := a.UnlockLogin("cs@test.com"); err != nil {
+				t.Fatalf("UnlockLogin: %v", err)
+			}
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			failLogins(t, a, "cs@test.com", testClient.IP, testLockout.BackoffAfter-1)
+			tt.clear(t, a)
+
+			// without the clear, these would reach the backoff
+			failLogins(t, a, "cs@test.com", testClient.IP, testLockout.BackoffAfter-1)
+			if _, err := a.Login("cs@test.com", "password", testClient); err != nil {
+				t.Fatalf("Login: %v", err)
+			}
+		})
+	}
+}
+
+func TestUnlockLiftsALockout(t *testing.T) {
+	a := newTestAuth(t)
+	failLogins(t, a, "cs@test.com", testClient.IP, testLockout.MaxAttempts)
+	if err := a.UnlockLogin(" CS@test.com"); err != nil {
+		t.Fatalf("UnlockLogin: %v", err)
+	}
+	if _, err := a.Login("cs@test.com", "password", testClient); err != nil {
+		t.Fatalf("Login after unlock: %v", err)
+	}
+}
@@ -0,0 +1,376 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/totp"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	mfaChallengeTTL   = 5 * time.Minute
+	mfaEnrollmentTTL  = 10 * time.Minute
+	recoveryCodeCount = 10
+	totpIssuer        = "Durianpay Dashboard"
+)
+
+var recoveryCodeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// mfaChallenge is what an MFA token stands for between the two login steps.
+type mfaChallenge struct {
+	UserID string `json:"user_id"`
+	Enroll bool   `json:"enroll"`
+}
+
+func mfaChallengeKey(token string) string {
+	return "mfachallenge:" + hashToken(token)
+}
+
+func mfaEnrollmentKey(userID string) string {
+	return "mfaenroll:" + userID
+}
+
+func totpLastStepKey(userID string) string {
+	return "totplast:" + userID
+}
+
+// startMFAChallenge issues the token for the second login step instead of a session.
+// With enroll set the user has to set up 2FA first because their role requires it.
+func (a *Auth) startMFAChallenge(user *entity.User, enroll bool) (*entity.LoginResult, error) {
+	token, err := randomToken()
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to generate mfa token")
+	}
+	data, err := json.Marshal(mfaChallenge{UserID: user.ID, Enroll: enroll})
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to encode mfa challenge")
+	}
+	if err := a.redis.Set(context.Background(), mfaChallengeKey(token), string(data), mfaChallengeTTL); err != nil {
+		return nil, entity.ErrorInternal("failed to persist mfa challenge")
+	}
+	return &entity.LoginResult{User: user, MFAToken: token, MFAEnrollmentRequired: enroll}, nil
+}
+
+// loadMFAChallenge returns the challenge and its user, failing when the token is unknown or expired.
+func (a *Auth) loadMFAChallenge(mfaToken string) (*mfaChallenge, *entity.User, error) {
+	raw, err := a.redis.Get(context.Background(), mfaChallengeKey(mfaToken))
+	if err == goredis.Nil {
+		return nil, nil, entity.ErrorUnauthorized("mfa token is invalid or has expired")
+	}
+	if err != nil {
+		return nil, nil, entity.ErrorInternal("failed to load mfa challenge")
+	}
+	return a.decodeMFAChallenge(raw)
+}
+
+// takeMFAChallenge is loadMFAChallenge that also consumes the challenge, so two
+// concurrent requests with the same token cannot both complete it. The stored
+// challenge and its remaining lifetime are returned for restoreMFAChallenge.
+func (a *Auth) takeMFAChallenge(mfaToken string) (ch *mfaChallenge, user *entity.User, raw string, ttl time.Duration, err error) {
+	ctx := context.Background()
+	if ttl, err = a.redis.PTTL(ctx, mfaChallengeKey(mfaToken)); err != nil {
+		return nil, nil, "", 0, entity.ErrorInternal("failed to load mfa challenge")
+	}
+	raw, err = a.redis.GetDel(ctx, mfaChallengeKey(mfaToken))
+	if err == goredis.Nil {
+		return nil, nil, "", 0, entity.ErrorUnauthorized("mfa token is invalid or has expired")
+	}
+	if err != nil {
+		return nil, nil, "", 0, entity.ErrorInternal("failed to load mfa challenge")
+	}
+	ch, user, err = a.decodeMFAChallenge(raw)
+	return ch, user, raw, ttl, err
+}
+
+// restoreMFAChallenge puts a taken challenge back for another attempt, for the
+// rest of its lifetime.
+func (a *Auth) restoreMFAChallenge(mfaToken string, raw string, ttl time.Duration) error {
+	if ttl <= 0 {
+		return nil
+	}
+	if _, err := a.redis.SetNX(context.Background(), mfaChallengeKey(mfaToken), raw, ttl); err != nil {
+		return entity.ErrorInternal("failed to persist mfa challenge")
+	}
+	return nil
+}
+
+func (a *Auth) decodeMFAChallenge(raw string) (*mfaChallenge, *entity.User, error) {
+	var ch mfaChallenge
+	if err := json.Unmarshal([]byte(raw), &ch); err != nil {
+		return nil, nil, entity.ErrorInternal("failed to decode mfa challenge")
+	}
+
+	user, err := a.repo.GetUserByID(ch.UserID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if !user.Active {
+		return nil, nil, entity.ErrorForbidden("account is deactivated")
+	}
+	return &ch, user, nil
+}
+
+// CompleteMFALogin finishes a login with a TOTP or recovery code. For a challenge
+// that requires enrolment the code confirms the new secret, and the recovery codes
+// are returned with the tokens.
+func (a *Auth) CompleteMFALogin(mfaToken string, code string, client entity.ClientInfo) (*entity.LoginResult, error) {
+	ch, user, raw, ttl, err := a.takeMFAChallenge(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	key := normalizeEmail(user.Email)
+	if err := a.checkLoginAllowed(key, client.IP); err != nil {
+		if restoreErr := a.restoreMFAChallenge(mfaToken, raw, ttl); restoreErr != nil {
+			return nil, restoreErr
+		}
+		return nil, err
+	}
+
+	var (
+		ok            bool
+		recoveryCodes []string
+	)
+	if ch.Enroll {
+		recoveryCodes, ok, err = a.activateMFA(user, code)
+	} else {
+		ok, err = a.verifySecondFactor(user, code)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		if err := a.recordLoginFailure(key, client.IP); err != nil {
+			return nil, err
+		}
+		// a wrong code may be retried with the same token, subject to the login backoff
+		if err := a.restoreMFAChallenge(mfaToken, raw, ttl); err != nil {
+			return nil, err
+		}
+		return nil, entity.ErrorUnauthorized("invalid verification code")
+	}
+
+	if err := a.UnlockLogin(key); err != nil {
+		return nil, err
+	}
+
+	accessToken, refreshToken, err := a.openSession(user, client)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken, RecoveryCodes: recoveryCodes}, nil
+}
+
+// BeginMFALoginEnrollment hands out a TOTP secret during a login that requires enrolment.
+func (a *Auth) BeginMFALoginEnrollment(mfaToken string) (*entity.MFAEnrollment, error) {
+	ch, user, err := a.loadMFAChallenge(mfaToken)
+	if err != nil {
+		return nil, err
+	}
+	if !ch.Enroll {
+		return nil, entity.ErrorConflict("two-factor authentication is already enabled")
+	}
+	return a.beginEnrollment(user)
+}
+
+// BeginMFAEnrollment starts an optional 2FA setup for a signed-in user.
+// 2FA stays off until ActivateMFA confirms a code from the authenticator app.
+func (a *Auth) BeginMFAEnrollment(caller *entity.Principal) (*entity.MFAEnrollment, error) {
+	user, err := a.repo.GetUserByID(caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, entity.ErrorConflict("two-factor authentication is already enabled")
+	}
+	return a.beginEnrollment(user)
+}
+
+// ActivateMFA confirms the pending secret with a code and returns the recovery codes.
+// Wrong codes count as failed logins.
+func (a *Auth) ActivateMFA(caller *entity.Principal, code string, client entity.ClientInfo) ([]string, error) {
+	user, err := a.repo.GetUserByID(caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if user.MFAEnabled {
+		return nil, entity.ErrorConflict("two-factor authentication is already enabled")
+	}
+
+	var codes []string
+	ok, err := a.verifyThrottled(user.Email, client.IP, func() (ok bool, err error) {
+		codes, ok, err = a.activateMFA(user, code)
+		return ok, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, entity.ErrorBadRequest("invalid verification code")
+	}
+	return codes, nil
+}
+
+// DisableMFA turns 2FA off, unless the caller's role requires it. It needs a
+// current TOTP or recovery code and, when the account has one, the password, so a
+// stolen session alone cannot remove the second factor. Wrong ones count as failed logins.
+func (a *Auth) DisableMFA(caller *entity.Principal, password string, code string, client entity.ClientInfo) error {
+	user, err := a.repo.GetUserByID(caller.UserID)
+	if err != nil {
+		return err
+	}
+	if !user.MFAEnabled {
+		return nil
+	}
+
+	policy, err := a.repo.GetRolePolicy(user.Role)
+	if err != nil {
+		return err
+	}
+	if policy.MFARequired {
+		return entity.ErrorForbidden("two-factor authentication is required for your role")
+	}
+
+	ok, err := a.verifyThrottled(user.Email, client.IP, func() (bool, error) {
+		// accounts provisioned through SSO have no password to check
+		if user.PasswordHash != "" {
+			ok, err := a.passwords.Verify(user.PasswordHash, password)
+			if err != nil {
+				return false, entity.WrapError(err, entity.ErrorCodeInternal, "failed to verify password")
+			}
+			if !ok {
+				return false, nil
+			}
+		}
+		return a.verifySecondFactor(user, code)
+	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return entity.ErrorBadRequest("password or verification code is incorrect")
+	}
+	return a.repo.DisableMFA(user.ID)
+}
+
+// RegenerateRecoveryCodes replaces all recovery codes; it needs a current TOTP
+// code, and wrong codes count as failed logins.
+func (a *Auth) RegenerateRecoveryCodes(caller *entity.Principal, code string, client entity.ClientInfo) ([]string, error) {
+	user, err := a.repo.GetUserByID(caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+	if !user.MFAEnabled {
+		return nil, entity.ErrorBadRequest("two-factor authentication is not enabled")
+	}
+
+	ok, err := a.verifyThrottled(user.Email, client.IP, func() (bool, error) {
+		return a.verifyTOTP(user.ID, user.TOTPSecret, code)
+	})
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, entity.ErrorBadRequest("invalid verification code")
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, err
+	}
+	if err := a.repo.ReplaceRecoveryCodes(user.ID, hashes); err != nil {
+		return nil, err
+	}
+	return codes, nil
+}
+
+// beginEnrollment keeps a fresh secret in Redis until the user confirms it.
+func (a *Auth) beginEnrollment(user *entity.User) (*entity.MFAEnrollment, error) {
+	secret, err := totp.GenerateSecret()
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to generate totp secret")
+	}
+	if err := a.redis.Set(context.Background(), mfaEnrollmentKey(user.ID), secret, mfaEnrollmentTTL); err != nil {
+		return nil, entity.ErrorInternal("failed to persist totp secret")
+	}
+	return &entity.MFAEnrollment{Secret: secret, URI: totp.URI(totpIssuer, user.Email, secret)}, nil
+}
+
+// activateMFA checks code against the pending secret and, if it matches, enables 2FA.
+func (a *Auth) activateMFA(user *entity.User, code string) ([]string, bool, error) {
+	ctx := context.Background()
+	secret, err := a.redis.Get(ctx, mfaEnrollmentKey(user.ID))
+	if err == goredis.Nil {
+		return nil, false, entity.ErrorBadRequest("no two-factor enrolment in progress, start again")
+	}
+	if err != nil {
+		return nil, false, entity.ErrorInternal("failed to load totp secret")
+	}
+
+	ok, err := a.verifyTOTP(user.ID, secret, code)
+	if err != nil || !ok {
+		return nil, false, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, false, err
+	}
+	if err := a.repo.EnableMFA(user.ID, secret, hashes); err != nil {
+		return nil, false, err
+	}
+	_ = a.redis.Del(ctx, mfaEnrollmentKey(user.ID))
+	return codes, true, nil
+}
+
+// verifySecondFactor accepts either a TOTP code or an unused recovery code.
+func (a *Auth) verifySecondFactor(user *entity.User, code string) (bool, error) {
+	code = strings.TrimSpace(code)
+	if len(code) == totp.Digits {
+		return a.verifyTOTP(user.ID, user.TOTPSecret, code)
+	}
+	return a.repo.UseRecoveryCode(user.ID, hashToken(normalizeRecoveryCode(code)))
+}
+
+// verifyTOTP validates a code and refuses to accept a time step that was already used.
+func (a *Auth) verifyTOTP(userID string, secret string, code string) (bool, error) {
+	step, ok := totp.Validate(secret, strings.TrimSpace(code), time.Now())
+	if !ok {
+		return false, nil
+	}
+
+	// Claim the step atomically, so two concurrent requests with the same code
+	// cannot both pass; the key only needs to outlive the window in which the
+	// step would still validate
+	window := time.Duration(2*totp.Skew+1) * totp.Period
+	claimed, err := a.redis.SetIfGreater(context.Background(), totpLastStepKey(userID), step, window)
+	if err != nil {
+		return false, entity.ErrorInternal("failed to verify code")
+	}
+	return claimed, nil
+}
+
+// generateRecoveryCodes returns codes formatted for the user and the hashes to store.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := make([]string, 0, recoveryCodeCount)
+	hashes := make([]string, 0, recoveryCodeCount)
+	for i := 0; i < recoveryCodeCount; i++ {
+		buf := make([]byte, 5)
+		if _, err := rand.Read(buf); err != nil {
+			return nil, nil, entity.ErrorInternal("failed to generate recovery codes")
+		}
+		raw := strings.ToLower(recoveryCodeEncoding.EncodeToString(buf)) // 8 characters
+		codes = append(codes, raw[:4]+"-"+raw[4:])
+		hashes = append(hashes, hashToken(raw))
+	}
+	return codes, hashes, nil
+}
+
+func normalizeRecoveryCode(code string) string {
+	return strings.ToLower(strings.NewReplacer("-", "", " ", "").Replace(code))
+}
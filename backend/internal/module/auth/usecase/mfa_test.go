@@ -0,0 +1,190 @@
+package usecase
+
+import (
+	"testing"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/totp"
+)
+
+// totpCode returns the code for the step offset steps away from now.
+func totpCode(t *testing.T, secret string, offset int) string {
+	t.Helper()
+	code, err := totp.Code(secret, totp.Counter(time.Now())+uint64(offset))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return code
+}
+
+// enableMFA turns 2FA on for the caller with the code of the current step, and
+// returns the secret and the recovery codes.
+func enableMFA(t *testing.T, a *testAuth, caller *entity.Principal) (string, []string) {
+	t.Helper()
+	enrollment, err := a.BeginMFAEnrollment(caller)
+	if err != nil {
+		t.Fatalf("BeginMFAEnrollment: %v", err)
+	}
+	codes, err := a.ActivateMFA(caller, totpCode(t, enrollment.Secret, 0), testClient)
+	if err != nil {
+		t.Fatalf("ActivateMFA: %v", err)
+	}
+	return enrollment.Secret, codes
+}
+
+func TestTOTPStepsAreSingleUse(t *testing.T) {
+	a := newTestAuth(t)
+	caller := a.principal(t, "cs@test.com")
+	// activation claims the current step
+	secret, _ := enableMFA(t, a, caller)
+
+	tests := []struct {
+		name   string
+		offset int
+		ok     bool
+	}{
+		{"replay of the step used to activate", 0, false},
+		{"next step", 1, true},
+		{"replay of the next step", 1, false},
+		{"earlier step inside the skew window", -1, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := a.verifyTOTP(caller.UserID, secret, totpCode(t, secret, tt.offset))
+			if err != nil {
+				t.Fatalf("verifyTOTP: %v", err)
+			}
+			if ok != tt.ok {
+				t.Errorf("verifyTOTP ok = %v, want %v", ok, tt.ok)
+			}
+		})
+	}
+}
+
+func TestRecoveryCodesAreSingleUse(t *testing.T) {
+	a := newTestAuth(t)
+	caller := a.principal(t, "cs@test.com")
+	_, codes := enableMFA(t, a, caller)
+	if len(codes) != recoveryCodeCount {
+		t.Fatalf("got %d recovery codes, want %d", len(codes), recoveryCodeCount)
+	}
+
+	login := func(code string) error {
+		t.Helper()
+		result, err := a.Login("cs@test.com", "password", testClient)
+		if err != nil {
+			t.Fatalf("Login: %v", err)
+		}
+		if result.MFAToken == "" {
+			t.Fatal("Login did not ask for a second factor")
+		}
+		_, err = a.CompleteMFALogin(result.MFAToken, code, testClient)
+		return err
+	}
+
+	if err := login(codes[0]); err != nil {
+		t.Fatalf("first use of a recovery code: %v", err)
+	}
+	wantCode(t, login(codes[0]), entity.ErrorCodeUnauthorized)
+	// codes are accepted in any case and without the dash
+	if err := login("  " + normalizeRecoveryCode(codes[1]) + " "); err != nil {
+		t.Fatalf("recovery code without the dash: %v", err)
+	}
+}
+
+func TestDisableMFA(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		code     func(secret string, recovery []string) string
+		want     entity.Code // empty when 2FA should be turned off
+	}{
+		{"no code", "password", func(string, []string) string { return "" }, entity.ErrorCodeBadRequest},
+		{"wrong code", "password", func(string, []string) string { return "000000" }, entity.ErrorCodeBadRequest},
+		{"wrong password", "wrong", func(_ string, r []string) string { return r[0] }, entity.ErrorCodeBadRequest},
+		{"password and recovery code", "password", func(_ string, r []string) string { return r[0] }, ""},
+		{"password and totp code", "password", func(s string, _ []string) string { return totpCode(t, s, 1) }, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			caller := a.principal(t, "cs@test.com")
+			secret, recovery := enableMFA(t, a, caller)
+
+			err := a.DisableMFA(caller, tt.password, tt.code(secret, recovery), testClient)
+			if tt.want != "" {
+				wantCode(t, err, tt.want)
+			} else if err != nil {
+				t.Fatalf("DisableMFA: %v", err)
+			}
+
+			user, err := a.repo.GetUserByID(caller.UserID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if user.MFAEnabled != (tt.want != "") {
+				t.Errorf("MFAEnabled = %v after DisableMFA", user.MFAEnabled)
+			}
+		})
+	}
+}
+
+func TestDisableMFARequiredByRole(t *testing.T) {
+	a := newTestAuth(t)
+	caller := a.principal(t, "cs@test.com")
+	_, recovery := enableMFA(t, a, caller)
+	if err := a.repo.SetRolePolicy(&entity.RolePolicy{Role: "cs", MFARequired: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	wantCode(t, a.DisableMFA(caller, "password", recovery[0], testClient), entity.ErrorCodeForbidden)
+}
+
+func TestWrongMFACodesAreThrottled(t *testing.T) {
+	tests := []struct {
+		name  string
+		check func(a *testAuth, caller *entity.Principal) error
+	}{
+		{"disable", func(a *testAuth, caller *entity.Principal) error {
+			return a.DisableMFA(caller, "password", "000000", testClient)
+		}},
+		{"disable with a wrong password", func(a *testAuth, caller *entity.Principal) error {
+			return a.DisableMFA(caller, "wrong", "000000", testClient)
+		}},
+		{"regenerate recovery codes", func(a *testAuth, caller *entity.Principal) error {
+			_, err := a.RegenerateRecoveryCodes(caller, "000000", testClient)
+			return err
+		}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			caller := a.principal(t, "cs@test.com")
+			enableMFA(t, a, caller)
+
+			for i := 0; i < testLockout.BackoffAfter; i++ {
+				wantCode(t, tt.check(a, caller), entity.ErrorCodeBadRequest)
+			}
+			wantCode(t, tt.check(a, caller), entity.ErrorCodeTooMany)
+			// the failures count against logins too
+			_, err := a.Login("cs@test.com", "password", testClient)
+			wantCode(t, err, entity.ErrorCodeTooMany)
+		})
+	}
+}
+
+func TestWrongActivationCodesAreThrottled(t *testing.T) {
+	a := newTestAuth(t)
+	caller := a.principal(t, "cs@test.com")
+	if _, err := a.BeginMFAEnrollment(caller); err != nil {
+		t.Fatal(err)
+	}
+
+	for i := 0; i < testLockout.BackoffAfter; i++ {
+		_, err := a.ActivateMFA(caller, "000000", testClient)
+		wantCode(t, err, entity.ErrorCodeBadRequest)
+	}
+	_, err := a.ActivateMFA(caller, "000000", testClient)
+	wantCode(t, err, entity.ErrorCodeTooMany)
+}
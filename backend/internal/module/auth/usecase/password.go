@@ -0,0 +1,174 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"net/url"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/mailer"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	passwordResetTTL = 30 * time.Minute
+	// passwordResetsPerEmail reset emails can be asked for one address within the
+	// lockout duration; per client IP the login IP limit applies.
+	passwordResetsPerEmail = 3
+)
+
+// Only a hash of the reset token is kept, so a Redis dump cannot be used to reset passwords.
+func passwordResetKey(token string) string {
+	return "pwreset:" + hashToken(token)
+}
+
+// randomToken returns 256 random bits, URL-safe encoded.
+func randomToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// hashToken is used to store high-entropy secrets without keeping them in the clear.
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// ChangePassword replaces the caller's password after verifying the current one;
+// wrong ones count as failed logins. Every session of the user, including the
+// current one, is revoked.
+func (a *Auth) ChangePassword(caller *entity.Principal, currentPassword string, newPassword string, client entity.ClientInfo) error {
+	user, err := a.repo.GetUserByID(caller.UserID)
+	if err != nil {
+		return err
+	}
+	ok, err := a.verifyThrottled(user.Email, client.IP, func() (bool, error) {
+		ok, err := a.passwords.Verify(user.PasswordHash, currentPassword)
+		if err != nil {
+			return false, entity.WrapError(err, entity.ErrorCodeInternal, "failed to verify password")
+		}
+		return ok, nil
+	})
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return entity.ErrorBadRequest("current password is incorrect")
+	}
+
+	return a.setPassword(user.ID, newPassword)
+}
+
+// RequestPasswordReset emails a single-use reset link to the user.
+// Unknown or deactivated emails, and accounts that only sign in through SSO, are
+// silently ignored so the endpoint cannot be used to probe accounts. Requests are
+// limited per email and per client IP whether or not the account exists, with
+// counters of their own so they cannot lock anyone's login.
+func (a *Auth) RequestPasswordReset(email string, client entity.ClientInfo) error {
+	email = normalizeEmail(email)
+	if err := a.checkNotLocked("too many password reset requests, try again later",
+		loginLockKey("reset-email", email), loginLockKey("reset-ip", client.IP)); err != nil {
+		return err
+	}
+	if err := a.recordAttempt("reset-email", email, passwordResetsPerEmail); err != nil {
+		return err
+	}
+	if err := a.recordAttempt("reset-ip", client.IP, a.lockout.IPMaxAttempts); err != nil {
+		return err
+	}
+
+	user, err := a.repo.GetUserByEmail(email)
+	if err != nil {
+		if appErr, ok := err.(*entity.AppError); ok && appErr.Code == entity.ErrorCodeNotFound {
+			return nil
+		}
+		return err
+	}
+	if !user.Active || user.PasswordHash == "" {
+		return nil
+	}
+
+	token, err := randomToken()
+	if err != nil {
+		return entity.ErrorInternal("failed to generate reset token")
+	}
+
+	ctx := context.Background()
+	if err := a.redis.Set(ctx, passwordResetKey(token), user.ID, passwordResetTTL); err != nil {
+		return entity.ErrorInternal("failed to persist reset token")
+	}
+
+	link := a.passwordResetURL + "?token=" + url.QueryEscape(token)
+	msg := mailer.Message{
+		To:      user.Email,
+		Subject: "Reset your dashboard password",
+		Body: fmt.Sprintf("Use the link below to choose a new password. It expires in %d minutes and can be used once.\n\n%s\n\nIf you did not ask for this, you can ignore this email.",
+			int(passwordResetTTL.Minutes()), link),
+	}
+	if err := a.mailer.Send(ctx, msg); err != nil {
+		log.Printf("auth: failed to send password reset email to user %s: %v", user.ID, err)
+		return entity.ErrorInternal("failed to send reset email")
+	}
+	return nil
+}
+
+// ResetPassword consumes a reset token and sets the new password.
+// The policy is checked first so a rejected password does not use up the token.
+func (a *Auth) ResetPassword(token string, newPassword string) error {
+	if err := a.policy.Check(newPassword); err != nil {
+		return entity.ErrorBadRequest(err.Error())
+	}
+
+	userID, err := a.redis.GetDel(context.Background(), passwordResetKey(token))
+	if err == goredis.Nil {
+		return entity.ErrorBadRequest("reset token is invalid or has expired")
+	}
+	if err != nil {
+		return entity.ErrorInternal("failed to validate reset token")
+	}
+
+	return a.setPassword(userID, newPassword)
+}
+
+// setPassword checks the password policy, stores the new password hash and
+// signs the user out everywhere.
+func (a *Auth) setPassword(userID string, newPassword string) error {
+	if err := a.policy.Check(newPassword); err != nil {
+		return entity.ErrorBadRequest(err.Error())
+	}
+	hash, err := a.passwords.Hash(newPassword)
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "failed to hash password")
+	}
+	if err := a.repo.UpdatePassword(userID, hash); err != nil {
+		return err
+	}
+	return a.RevokeAllSessions(userID)
+}
+
+// upgradePasswordHash rehashes a just-verified password when its stored hash uses
+// an older algorithm or a lower cost than configured. The login goes ahead even
+// if the upgrade fails; it is retried on the next one.
+func (a *Auth) upgradePasswordHash(user *entity.User, password string) {
+	if !a.passwords.NeedsRehash(user.PasswordHash) {
+		return
+	}
+	hash, err := a.passwords.Hash(password)
+	if err == nil {
+		err = a.repo.UpgradePasswordHash(user.ID, user.PasswordHash, hash)
+	}
+	if err != nil {
+		log.Printf("auth: failed to upgrade password hash of user %s: %v", user.ID, err)
+		return
+	}
+	user.PasswordHash = hash
+}
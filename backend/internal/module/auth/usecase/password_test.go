@@ -0,0 +1,179 @@
+package usecase
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+	"testing"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	passwordsvc "github.com/durianpay/fullstack-boilerplate/internal/service/password"
+	"golang.org/x/crypto/bcrypt"
+)
+
+var resetLink = regexp.MustCompile(`https?://\S+`)
+
+// resetToken requests a password reset for email and returns the token mailed for it.
+func resetToken(t *testing.T, a *testAuth, email string) string {
+	t.Helper()
+	if err := a.RequestPasswordReset(email, testClient); err != nil {
+		t.Fatalf("RequestPasswordReset: %v", err)
+	}
+	if len(a.mail.sent) == 0 {
+		t.Fatal("no reset email was sent")
+	}
+	link, err := url.Parse(resetLink.FindString(a.mail.sent[len(a.mail.sent)-1].Body))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return link.Query().Get("token")
+}
+
+func TestLoginUpgradesPasswordHash(t *testing.T) {
+	tests := []struct {
+		name     string
+		hasher   passwordsvc.Hasher
+		password string
+		upgraded bool
+	}{
+		{"bcrypt to argon2id", &passwordsvc.Argon2id{Params: passwordsvc.Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1}}, "password", true},
+		{"bcrypt to a higher cost", &passwordsvc.Bcrypt{Cost: bcrypt.MinCost + 1}, "password", true},
+		{"hash already current", &passwordsvc.Bcrypt{Cost: bcrypt.MinCost}, "password", false},
+		{"wrong password", &passwordsvc.Argon2id{Params: passwordsvc.DefaultArgon2Params}, "wrong", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			a.passwords = tt.hasher
+			before, err := a.repo.GetUserByEmail("cs@test.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			_, _ = a.Login("cs@test.com", tt.password, testClient)
+
+			after, err := a.repo.GetUserByEmail("cs@test.com")
+			if err != nil {
+				t.Fatal(err)
+			}
+			if upgraded := after.PasswordHash != before.PasswordHash; upgraded != tt.upgraded {
+				t.Fatalf("hash upgraded = %v, want %v", upgraded, tt.upgraded)
+			}
+			if tt.upgraded {
+				if tt.hasher.NeedsRehash(after.PasswordHash) {
+					t.Errorf("stored hash %q still needs a rehash", after.PasswordHash)
+				}
+				if _, err := a.Login("cs@test.com", "password", testClient); err != nil {
+					t.Errorf("Login with the upgraded hash: %v", err)
+				}
+			}
+		})
+	}
+}
+
+func TestNewPasswordsFollowThePolicy(t *testing.T) {
+	set := map[string]func(t *testing.T, a *testAuth, password string) error{
+		"change": func(t *testing.T, a *testAuth, password string) error {
+			return a.ChangePassword(a.principal(t, "cs@test.com"), "password", password, testClient)
+		},
+		"reset": func(t *testing.T, a *testAuth, password string) error {
+			return a.ResetPassword(resetToken(t, a, "cs@test.com"), password)
+		},
+	}
+	tests := []struct {
+		password string
+		ok       bool
+	}{
+		{"N3w-password", true},
+		{"Sh0rt-", false},
+		{"newpassword1", false},
+		{strings.Repeat("Aa1-", 19), false},
+	}
+	for how, setPassword := range set {
+		for _, tt := range tests {
+			t.Run(how+" to "+tt.password, func(t *testing.T) {
+				a := newTestAuth(t)
+				err := setPassword(t, a, tt.password)
+				if !tt.ok {
+					wantCode(t, err, entity.ErrorCodeBadRequest)
+					return
+				}
+				if err != nil {
+					t.Fatalf("set password: %v", err)
+				}
+				if _, err := a.Login("cs@test.com", tt.password, testClient); err != nil {
+					t.Errorf("Login with the new password: %v", err)
+				}
+			})
+		}
+	}
+}
+
+func TestRejectedPasswordKeepsTheResetToken(t *testing.T) {
+	a := newTestAuth(t)
+	token := resetToken(t, a, "cs@test.com")
+
+	wantCode(t, a.ResetPassword(token, "weak"), entity.ErrorCodeBadRequest)
+	if err := a.ResetPassword(token, "N3w-password"); err != nil {
+		t.Fatalf("ResetPassword after a rejected password: %v", err)
+	}
+	// the token is single-use
+	wantCode(t, a.ResetPassword(token, "An0ther-password"), entity.ErrorCodeBadRequest)
+}
+
+func TestWrongCurrentPasswordsAreThrottled(t *testing.T) {
+	a := newTestAuth(t)
+	caller := a.principal(t, "cs@test.com")
+
+	for i := 0; i < testLockout.BackoffAfter; i++ {
+		wantCode(t, a.ChangePassword(caller, "wrong", "N3w-password", testClient), entity.ErrorCodeBadRequest)
+	}
+	// the right password is refused too while the email is locked
+	wantCode(t, a.ChangePassword(caller, "password", "N3w-password", testClient), entity.ErrorCodeTooMany)
+	_, err := a.Login("cs@test.com", "password", testClient)
+	wantCode(t, err, entity.ErrorCodeTooMany)
+}
+
+func TestPasswordResetRequestsAreLimited(t *testing.T) {
+	tests := []struct {
+		name   string
+		emails []string
+		ips    []string
+		limit  int
+	}{
+		{"per email", []string{"cs@test.com"}, []string{"203.0.113.1", "203.0.113.2"}, passwordResetsPerEmail},
+		{"per email, whatever its case", []string{"cs@test.com", " CS@test.com"}, []string{"203.0.113.1"}, passwordResetsPerEmail},
+		{"per unknown email", []string{"nobody@test.com"}, []string{"203.0.113.1"}, passwordResetsPerEmail},
+		{"per ip", []string{"a@test.com", "b@test.com", "c@test.com"}, []string{"203.0.113.1"}, testLockout.IPMaxAttempts},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			a := newTestAuth(t)
+			request := func(i int) error {
+				client := entity.ClientInfo{UserAgent: "test", IP: tt.ips[i%len(tt.ips)]}
+				return a.RequestPasswordReset(tt.emails[i%len(tt.emails)], client)
+			}
+			for i := 0; i < tt.limit; i++ {
+				if err := request(i); err != nil {
+					t.Fatalf("request %d: %v", i+1, err)
+				}
+			}
+			wantCode(t, request(tt.limit), entity.ErrorCodeTooMany)
+		})
+	}
+}
+
+func TestPasswordResetLimitDoesNotLockLogin(t *testing.T) {
+	a := newTestAuth(t)
+	for i := 0; i < passwordResetsPerEmail; i++ {
+		if err := a.RequestPasswordReset("cs@test.com", testClient); err != nil {
+			t.Fatalf("RequestPasswordReset: %v", err)
+		}
+	}
+	if got := len(a.mail.sent); got != passwordResetsPerEmail {
+		t.Errorf("sent %d reset emails, want %d", got, passwordResetsPerEmail)
+	}
+	if _, err := a.Login("cs@test.com", "password", testClient); err != nil {
+		t.Fatalf("Login after reset requests: %v", err)
+	}
+}
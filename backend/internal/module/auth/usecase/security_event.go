@@ -0,0 +1,27 @@
+package usecase
+
+import "github.com/durianpay/fullstack-boilerplate/internal/entity"
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// ListSecurityEvents returns one page of security events, newest first; page is 1-based.
+func (a *Auth) ListSecurityEvents(page int, pageSize int) (*entity.SecurityEventPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	events, total, err := a.repo.ListSecurityEvents((page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.SecurityEventPage{Events: events, Page: page, PageSize: pageSize, Total: total}, nil
+}
@@ -0,0 +1,230 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/google/uuid"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+// sessionRecord is what gets persisted in Redis for each session.
+// RefreshTokenID is the jti of the only refresh token currently valid for it,
+// and Generation counts how many times that token has been rotated.
+type sessionRecord struct {
+	entity.Session
+	RefreshTokenID string `json:"refresh_token_id"`
+	Generation     int    `json:"generation"`
+}
+
+func sessionKey(sessionID string) string {
+	return "session:" + sessionID
+}
+
+func userSessionsKey(userID string) string {
+	return "sessions:" + userID
+}
+
+// revokedFamilyKey remembers sessions revoked for token reuse, so that a refresh
+// racing the revocation cannot write the session back.
+func revokedFamilyKey(sessionID string) string {
+	return "revokedfamily:" + sessionID
+}
+
+// refreshClaimKey marks a refresh token as spent once a refresh has started with it.
+func refreshClaimKey(jti string) string {
+	return "refreshused:" + jti
+}
+
+// openSession creates a session for the user and issues its first token pair.
+func (a *Auth) openSession(user *entity.User, client entity.ClientInfo) (string, string, error) {
+	now := time.Now().UTC()
+	sess := &sessionRecord{
+		Session: entity.Session{
+			ID:         uuid.NewString(),
+			UserID:     user.ID,
+			UserAgent:  client.UserAgent,
+			IP:         client.IP,
+			CreatedAt:  now,
+			LastUsedAt: now,
+		},
+	}
+
+	accessToken, err := a.generateAccessToken(user, sess.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	refreshToken, jti, err := a.generateRefreshToken(user, sess.ID, sess.Generation)
+	if err != nil {
+		return "", "", err
+	}
+
+	sess.RefreshTokenID = jti
+	if err := a.saveSession(sess); err != nil {
+		return "", "", err
+	}
+
+	return accessToken, refreshToken, nil
+}
+
+// getSession loads a session, returning nil when it does not exist or has expired.
+func (a *Auth) getSession(sessionID string) (*sessionRecord, error) {
+	raw, err := a.redis.Get(context.Background(), sessionKey(sessionID))
+	if err == goredis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to load session")
+	}
+
+	var sess sessionRecord
+	if err := json.Unmarshal([]byte(raw), &sess); err != nil {
+		return nil, entity.ErrorInternal("failed to decode session")
+	}
+	return &sess, nil
+}
+
+// saveSession persists the session and indexes it under its user.
+// Both keys live as long as the refresh token they back.
+func (a *Auth) saveSession(sess *sessionRecord) error {
+	ctx := context.Background()
+	data, err := json.Marshal(sess)
+	if err != nil {
+		return entity.ErrorInternal("failed to encode session")
+	}
+	if err := a.redis.Set(ctx, sessionKey(sess.ID), string(data), RefreshTokenTTL); err != nil {
+		return entity.ErrorInternal("failed to persist session")
+	}
+	if err := a.redis.SAdd(ctx, userSessionsKey(sess.UserID), sess.ID); err != nil {
+		return entity.ErrorInternal("failed to persist session")
+	}
+	if err := a.redis.Expire(ctx, userSessionsKey(sess.UserID), RefreshTokenTTL); err != nil {
+		return entity.ErrorInternal("failed to persist session")
+	}
+	return nil
+}
+
+func (a *Auth) deleteSession(userID string, sessionID string) error {
+	ctx := context.Background()
+	if err := a.redis.Del(ctx, sessionKey(sessionID)); err != nil {
+		return entity.ErrorInternal("failed to revoke session")
+	}
+	if err := a.redis.SRem(ctx, userSessionsKey(userID), sessionID); err != nil {
+		return entity.ErrorInternal("failed to revoke session")
+	}
+	return nil
+}
+
+// revokeReusedFamily handles a refresh token that was already rotated. Either
+// an attacker or the real user holds a copy, and there is no telling which, so
+// the whole session is revoked and both have to log in again.
+func (a *Auth) revokeReusedFamily(sess *sessionRecord, gen int, client entity.ClientInfo) error {
+	if err := a.redis.Set(context.Background(), revokedFamilyKey(sess.ID), "1", RefreshTokenTTL); err != nil {
+		return entity.ErrorInternal("failed to revoke session")
+	}
+	if err := a.deleteSession(sess.UserID, sess.ID); err != nil {
+		return err
+	}
+
+	event := &entity.SecurityEvent{
+		Type:      entity.SecurityEventRefreshTokenReuse,
+		UserID:    sess.UserID,
+		SessionID: sess.ID,
+		IP:        client.IP,
+		UserAgent: client.UserAgent,
+		Detail:    fmt.Sprintf("refresh token of generation %d presented, session was at generation %d", gen, sess.Generation),
+		CreatedAt: time.Now().UTC(),
+	}
+	if err := a.repo.RecordSecurityEvent(event); err != nil {
+		log.Printf("auth: failed to record security event for user %s: %v", sess.UserID, err)
+	}
+	log.Printf("auth: refresh token reuse detected for user %s, session %s revoked", sess.UserID, sess.ID)
+
+	return entity.ErrorUnauthorized("refresh token was already used; the session has been revoked")
+}
+
+// userSessions returns the live sessions of a user, pruning index entries whose session expired.
+func (a *Auth) userSessions(userID string) ([]*sessionRecord, error) {
+	ids, err := a.redis.SMembers(context.Background(), userSessionsKey(userID))
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to list sessions")
+	}
+
+	sessions := make([]*sessionRecord, 0, len(ids))
+	for _, id := range ids {
+		sess, err := a.getSession(id)
+		if err != nil {
+			return nil, err
+		}
+		if sess == nil {
+			_ = a.redis.SRem(context.Background(), userSessionsKey(userID), id)
+			continue
+		}
+		sessions = append(sessions, sess)
+	}
+	return sessions, nil
+}
+
+// revokeSessions deletes every session of the user except the one given (if any).
+func (a *Auth) revokeSessions(userID string, exceptID string) error {
+	sessions, err := a.userSessions(userID)
+	if err != nil {
+		return err
+	}
+	for _, sess := range sessions {
+		if sess.ID == exceptID {
+			continue
+		}
+		if err := a.deleteSession(userID, sess.ID); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ListSessions returns the caller's sessions, most recently used first.
+func (a *Auth) ListSessions(caller *entity.Principal) ([]*entity.Session, error) {
+	records, err := a.userSessions(caller.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]*entity.Session, 0, len(records))
+	for _, r := range records {
+		sessions = append(sessions, &r.Session)
+	}
+	sort.Slice(sessions, func(i, j int) bool {
+		return sessions[i].LastUsedAt.After(sessions[j].LastUsedAt)
+	})
+
+	return sessions, nil
+}
+
+// RevokeSession signs out one of the caller's sessions.
+func (a *Auth) RevokeSession(caller *entity.Principal, sessionID string) error {
+	sess, err := a.getSession(sessionID)
+	if err != nil {
+		return err
+	}
+	if sess == nil || sess.UserID != caller.UserID {
+		return entity.ErrorNotFound("session not found")
+	}
+
+	return a.deleteSession(caller.UserID, sessionID)
+}
+
+// RevokeOtherSessions signs out every session of the caller except the current one.
+func (a *Auth) RevokeOtherSessions(caller *entity.Principal) error {
+	return a.revokeSessions(caller.UserID, caller.SessionID)
+}
+
+// RevokeAllSessions signs the user out everywhere, e.g. after deactivation or a role change.
+func (a *Auth) RevokeAllSessions(userID string) error {
+	return a.revokeSessions(userID, "")
+}
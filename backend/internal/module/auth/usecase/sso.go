@@ -0,0 +1,210 @@
+package usecase
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"log"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/oidc"
+	goredis "github.com/redis/go-redis/v9"
+)
+
+const (
+	// SSOStateTTL is how long the browser has to come back from the IdP.
+	SSOStateTTL          = 10 * time.Minute
+	maxDisplayNameLength = 100
+)
+
+// SSO configures sign-in through an OpenID Connect provider. It is disabled when Provider is nil.
+type SSO struct {
+	Provider *oidc.Provider
+	// Roles maps IdP groups to roles. For existing users a match also updates their role.
+	Roles oidc.RoleMapping
+	// DefaultRole is given to provisioned users whose groups match no mapping;
+	// when empty such users are refused.
+	DefaultRole string
+	// AutoProvision creates a user on first sign-in when no account has the IdP email.
+	AutoProvision bool
+	// MFAACRValues are the acr claims that mean the IdP checked a second factor.
+	// An amr claim containing "mfa" (RFC 8176) means the same.
+	MFAACRValues []string
+}
+
+// ssoState is what the state parameter stands for while the browser is at the IdP.
+type ssoState struct {
+	Verifier string `json:"verifier"`
+	Nonce    string `json:"nonce"`
+}
+
+func ssoStateKey(state string) string {
+	return "ssostate:" + hashToken(state)
+}
+
+// BeginSSOLogin starts an authorization code flow with PKCE. The frontend sends
+// the browser to the returned URL; the handler also binds the state to the
+// browser with a cookie that CompleteSSOLogin checks.
+func (a *Auth) BeginSSOLogin() (*entity.SSOAuthorization, error) {
+	if a.sso.Provider == nil {
+		return nil, entity.ErrorNotFound("single sign-on is not configured")
+	}
+
+	state, err := randomToken()
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to generate sso state")
+	}
+	verifier, err := oidc.NewPKCEVerifier()
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to generate sso state")
+	}
+	nonce, err := oidc.NewNonce()
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to generate sso state")
+	}
+
+	ctx := context.Background()
+	authURL, err := a.sso.Provider.AuthCodeURL(ctx, state, nonce, oidc.PKCEChallenge(verifier))
+	if err != nil {
+		log.Printf("auth: failed to start sso login: %v", err)
+		return nil, entity.ErrorInternal("identity provider is unavailable")
+	}
+
+	data, err := json.Marshal(ssoState{Verifier: verifier, Nonce: nonce})
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to encode sso state")
+	}
+	if err := a.redis.Set(ctx, ssoStateKey(state), string(data), SSOStateTTL); err != nil {
+		return nil, entity.ErrorInternal("failed to persist sso state")
+	}
+	return &entity.SSOAuthorization{URL: authURL, State: state}, nil
+}
+
+// CompleteSSOLogin redeems the authorization code the IdP sent back and opens a session.
+// browserState is the state from the cookie set by the authorize request and must
+// match state, so a callback cannot be replayed into another browser.
+// The user is found by their linked IdP account, then by verified email, and is
+// otherwise provisioned when AutoProvision is on. Users who need 2FA get the same
+// MFA challenge as a password login unless the IdP reports it checked a second factor.
+func (a *Auth) CompleteSSOLogin(code string, state string, browserState string, client entity.ClientInfo) (*entity.LoginResult, error) {
+	if a.sso.Provider == nil {
+		return nil, entity.ErrorNotFound("single sign-on is not configured")
+	}
+	if state == "" || subtle.ConstantTimeCompare([]byte(state), []byte(browserState)) != 1 {
+		return nil, entity.ErrorBadRequest("sso state does not belong to this browser")
+	}
+
+	ctx := context.Background()
+	data, err := a.redis.GetDel(ctx, ssoStateKey(state))
+	if err == goredis.Nil {
+		return nil, entity.ErrorBadRequest("sso state is invalid or has expired")
+	}
+	if err != nil {
+		return nil, entity.ErrorInternal("failed to validate sso state")
+	}
+	var st ssoState
+	if err := json.Unmarshal([]byte(data), &st); err != nil {
+		return nil, entity.ErrorInternal("failed to decode sso state")
+	}
+
+	claims, err := a.sso.Provider.Exchange(ctx, code, st.Verifier, st.Nonce)
+	if err != nil {
+		log.Printf("auth: sso login failed: %v", err)
+		return nil, entity.ErrorUnauthorized("single sign-on failed")
+	}
+
+	user, err := a.ssoUser(claims)
+	if err != nil {
+		return nil, err
+	}
+	if !user.Active {
+		return nil, entity.ErrorForbidden("account is deactivated")
+	}
+	if !a.ssoCheckedSecondFactor(claims) {
+		if challenge, err := a.secondFactorChallenge(user); challenge != nil || err != nil {
+			return challenge, err
+		}
+	}
+
+	accessToken, refreshToken, err := a.openSession(user, client)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.LoginResult{User: user, AccessToken: accessToken, RefreshToken: refreshToken}, nil
+}
+
+// ssoCheckedSecondFactor reports whether the IdP says it authenticated the user with more than one factor.
+func (a *Auth) ssoCheckedSecondFactor(claims *oidc.Claims) bool {
+	return slices.Contains(claims.AMR, "mfa") ||
+		(claims.ACR != "" && slices.Contains(a.sso.MFAACRValues, claims.ACR))
+}
+
+// ssoUser resolves the dashboard user for the IdP account, linking or creating it as needed.
+func (a *Auth) ssoUser(claims *oidc.Claims) (*entity.User, error) {
+	issuer := a.sso.Provider.Issuer()
+	role, mapped := a.sso.Roles.Role(claims.Groups)
+
+	user, err := a.repo.GetUserByIdentity(issuer, claims.Subject)
+	if appErr, ok := err.(*entity.AppError); ok && appErr.Code == entity.ErrorCodeNotFound {
+		user, err = a.linkSSOUser(issuer, claims, role, mapped)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if mapped && user.Role != role {
+		if user, err = a.repo.UpdateRole(user.ID, role); err != nil {
+			return nil, err
+		}
+		// tokens of the old role must not outlive the change
+		if err := a.RevokeAllSessions(user.ID); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// linkSSOUser links an IdP account seen for the first time to the user with the
+// same email, or provisions a new user.
+func (a *Auth) linkSSOUser(issuer string, claims *oidc.Claims, role string, mapped bool) (*entity.User, error) {
+	// an unverified email could belong to someone else, so it must not reach an
+	// existing account or claim a new one; IdPs that omit email_verified are not trusted
+	if claims.Email == "" || claims.EmailVerified == nil || !*claims.EmailVerified {
+		return nil, entity.ErrorForbidden("identity provider did not return a verified email")
+	}
+
+	user, err := a.repo.GetUserByEmail(normalizeEmail(claims.Email))
+	if appErr, ok := err.(*entity.AppError); ok && appErr.Code == entity.ErrorCodeNotFound {
+		if !a.sso.AutoProvision {
+			return nil, entity.ErrorForbidden("no dashboard account for this identity")
+		}
+		if !mapped {
+			if a.sso.DefaultRole == "" {
+				return nil, entity.ErrorForbidden("no dashboard role for this identity")
+			}
+			role = a.sso.DefaultRole
+		}
+		name := []rune(strings.TrimSpace(claims.Name))
+		if len(name) > maxDisplayNameLength {
+			name = name[:maxDisplayNameLength]
+		}
+		// no password hash, so the account can only sign in through SSO
+		user, err = a.repo.CreateUser(&entity.User{
+			Email:       normalizeEmail(claims.Email),
+			Role:        role,
+			DisplayName: string(name),
+			Active:      true,
+		})
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if err := a.repo.LinkIdentity(user.ID, issuer, claims.Subject); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
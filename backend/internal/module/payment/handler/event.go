@@ -0,0 +1,47 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+)
+
+func toPaymentEvent(e *entity.PaymentEvent) openapigen.PaymentEvent {
+	eventType := openapigen.PaymentEventType(e.Type)
+	source := openapigen.PaymentEventSource(e.Source)
+	event := openapigen.PaymentEvent{
+		Id:         &e.ID,
+		PaymentId:  &e.PaymentID,
+		Type:       &eventType,
+		ActorEmail: &e.ActorEmail,
+		Source:     &source,
+		OldValue:   &e.OldValue,
+		NewValue:   &e.NewValue,
+		Reason:     &e.Reason,
+		CreatedAt:  &e.CreatedAt,
+	}
+	if e.ActorID != "" {
+		event.ActorId = &e.ActorID
+	}
+	if e.RefundID != "" {
+		event.RefundId = &e.RefundID
+	}
+	return event
+}
+
+// GetDashboardV1PaymentsIdEvents lists a payment's history, oldest first.
+func (h *PaymentHandler) GetDashboardV1PaymentsIdEvents(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId) {
+	events, err := h.paymentUC.ListPaymentEvents(id)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	response := openapigen.PaymentEventListResponse{Events: make([]openapigen.PaymentEvent, len(events))}
+	for i, e := range events {
+		response.Events[i] = toPaymentEvent(e)
+	}
+	transport.WriteJSON(w, http.StatusOK, response)
+}
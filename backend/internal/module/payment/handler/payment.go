@@ -1,11 +1,16 @@
 package handler
 
 import (
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"strings"
 
 	"github.com/durianpay/fullstack-boilerplate/internal/entity"
 	"github.com/durianpay/fullstack-boilerplate/internal/module/payment/usecase"
 	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/principal"
 	"github.com/durianpay/fullstack-boilerplate/internal/transport"
 )
 
@@ -19,6 +24,36 @@ func NewPaymentHandler(paymentUC usecase.PaymentUsecase) *PaymentHandler {
 	}
 }
 
+func toPayment(p *entity.Payment) openapigen.Payment {
+	statusStr := string(p.Status)
+	amount := p.Amount.String()
+	refunded := p.RefundedAmount().String()
+	net := p.NetAmount().String()
+	payment := openapigen.Payment{
+		Id:             &p.ID,
+		Merchant:       &p.Merchant,
+		Status:         &statusStr,
+		Amount:         &amount,
+		Currency:       &p.Amount.Currency,
+		Reference:      &p.Reference,
+		Notes:          &p.Notes,
+		CreatedAt:      &p.CreatedAt,
+		Version:        &p.Version,
+		RefundedAmount: &refunded,
+		NetAmount:      &net,
+	}
+	if p.Highlights != nil {
+		payment.Highlights = &p.Highlights
+	}
+	if p.ReportAmount != nil {
+		reportAmount := p.ReportAmount.String()
+		payment.ReportAmount = &reportAmount
+		payment.ReportCurrency = &p.ReportAmount.Currency
+		payment.FxRate = &p.FXRate
+	}
+	return payment
+}
+
 // GetDashboardV1Payments handles listing payments with optional filters and sorting
 func (h *PaymentHandler) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1PaymentsParams) {
 	filters := make(map[string]interface{})
@@ -31,33 +66,250 @@ func (h *PaymentHandler) GetDashboardV1Payments(w http.ResponseWriter, r *http.R
 		filters["id"] = *params.Id
 	}
 
+	if params.CreatedFrom != nil {
+		filters["created_from"] = *params.CreatedFrom
+	}
+
+	if params.CreatedTo != nil {
+		filters["created_to"] = *params.CreatedTo
+	}
+
+	currency := ""
+	if params.Currency != nil {
+		currency = *params.Currency
+		filters["currency"] = currency
+	}
+
+	// amounts in different currencies cannot be compared, so bounds need a currency
+	amounts := []struct {
+		name  string
+		value *string
+	}{{"amount_min", params.AmountMin}, {"amount_max", params.AmountMax}}
+	for _, a := range amounts {
+		if a.value == nil {
+			continue
+		}
+		if currency == "" {
+			transport.WriteAppError(w, entity.ErrorBadRequest(a.name+" needs a currency filter"))
+			return
+		}
+		exp, ok := entity.CurrencyExponent(currency)
+		if !ok {
+			transport.WriteAppError(w, entity.ErrorBadRequest("currency is not a supported currency"))
+			return
+		}
+		amount, err := entity.ParseMinorUnits(*a.value, exp)
+		if err != nil {
+			transport.WriteAppError(w, entity.ErrorBadRequest(fmt.Sprintf(
+				"%s must be a decimal number with at most %d decimal places in %s", a.name, exp, currency)))
+			return
+		}
+		filters[a.name] = amount
+	}
+
+	if params.Merchant != nil {
+		filters["merchant"] = *params.Merchant
+	}
+
+	if params.MerchantPrefix != nil {
+		filters["merchant_prefix"] = *params.MerchantPrefix
+	}
+
+	if params.Q != nil {
+		filters["q"] = *params.Q
+	}
+
 	sortBy := ""
 	if params.Sort != nil {
 		sortBy = *params.Sort
 	}
 
-	payments, err := h.paymentUC.ListPayments(filters, sortBy)
+	limit, cursor := 0, ""
+	if params.Limit != nil {
+		limit = *params.Limit
+	}
+	if params.Cursor != nil {
+		cursor = *params.Cursor
+	}
+
+	reportCurrency := ""
+	if params.ReportCurrency != nil {
+		reportCurrency = *params.ReportCurrency
+	}
+
+	page, err := h.paymentUC.ListPayments(filters, sortBy, limit, cursor, reportCurrency)
 	if err != nil {
+		// bad filters, a bad cursor or a missing rate are the caller's to fix; anything else is ours
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeBadRequest {
+			transport.WriteAppError(w, appErr)
+			return
+		}
 		transport.WriteError(w, entity.ErrorInternal("failed to fetch payments"))
 		return
 	}
 
 	paymentsList := []openapigen.Payment{}
-
-	for _, p := range payments {
-		statusStr := string(p.Status)
-		paymentsList = append(paymentsList, openapigen.Payment{
-			Id:        &p.ID,
-			Merchant:  &p.Merchant,
-			Status:    &statusStr,
-			Amount:    &p.Amount,
-			CreatedAt: &p.CreatedAt,
-		})
+	for _, p := range page.Payments {
+		paymentsList = append(paymentsList, toPayment(p))
 	}
 
 	response := openapigen.PaymentListResponse{
 		Payments: &paymentsList,
+		HasMore:  &page.HasMore,
+	}
+	if page.NextCursor != "" {
+		response.NextCursor = &page.NextCursor
 	}
 
 	transport.WriteJSON(w, http.StatusOK, response)
 }
+
+// toPaymentDetail is toPayment with the payment's events and refunds, mapped
+// as their own endpoints map them.
+func toPaymentDetail(p *entity.Payment, events []*entity.PaymentEvent, refunds []*entity.Refund) openapigen.PaymentDetail {
+	payment := toPayment(p)
+	detail := openapigen.PaymentDetail{
+		Id:             payment.Id,
+		Merchant:       payment.Merchant,
+		Status:         payment.Status,
+		Amount:         payment.Amount,
+		Currency:       payment.Currency,
+		Reference:      payment.Reference,
+		Notes:          payment.Notes,
+		CreatedAt:      payment.CreatedAt,
+		Version:        payment.Version,
+		RefundedAmount: payment.RefundedAmount,
+		NetAmount:      payment.NetAmount,
+		Highlights:     payment.Highlights,
+		ReportAmount:   payment.ReportAmount,
+		ReportCurrency: payment.ReportCurrency,
+		FxRate:         payment.FxRate,
+		Events:         make([]openapigen.PaymentEvent, len(events)),
+		Refunds:        make([]openapigen.Refund, len(refunds)),
+	}
+	for i, e := range events {
+		detail.Events[i] = toPaymentEvent(e)
+	}
+	for i, rf := range refunds {
+		detail.Refunds[i] = toRefund(rf)
+	}
+	return detail
+}
+
+// GetDashboardV1PaymentsId returns a single payment with its history and refunds.
+func (h *PaymentHandler) GetDashboardV1PaymentsId(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId) {
+	payment, err := h.paymentUC.GetPayment(id)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	events, err := h.paymentUC.ListPaymentEvents(id)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+	refunds, err := h.paymentUC.ListRefunds(id)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(payment.Version))
+	transport.WriteJSON(w, http.StatusOK, toPaymentDetail(payment, events, refunds))
+}
+
+// etag is a payment's entity tag: its version, quoted.
+func etag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseIfMatch reads the version out of an If-Match header holding one ETag.
+func parseIfMatch(header string) (int64, error) {
+	tag := strings.TrimPrefix(strings.TrimSpace(header), "W/")
+	unquoted, err := strconv.Unquote(tag)
+	if err != nil {
+		unquoted = tag
+	}
+	version, err := strconv.ParseInt(unquoted, 10, 64)
+	if err != nil || version < 1 {
+		return 0, entity.ErrorBadRequest("If-Match must be the ETag of the payment")
+	}
+	return version, nil
+}
+
+// PatchDashboardV1PaymentsIdStatus changes a payment's status, provided it is
+// unchanged since the caller read the ETag sent in If-Match.
+func (h *PaymentHandler) PatchDashboardV1PaymentsIdStatus(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId, params openapigen.PatchDashboardV1PaymentsIdStatusParams) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+	if params.IfMatch == nil {
+		transport.WriteAppError(w, entity.ErrorPreconditionRequired("If-Match is required: send the ETag of the payment as you last read it"))
+		return
+	}
+	version, err := parseIfMatch(*params.IfMatch)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	var req openapigen.PaymentStatusUpdate
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	payment, err := h.paymentUC.UpdatePaymentStatus(caller, id, version, entity.PaymentStatus(req.Status), req.Reason)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", etag(payment.Version))
+	transport.WriteJSON(w, http.StatusOK, toPayment(payment))
+}
+
+// PostDashboardV1Payments records a payment. A replayed idempotent request is
+// answered with the original payment and an Idempotent-Replayed header.
+func (h *PaymentHandler) PostDashboardV1Payments(w http.ResponseWriter, r *http.Request, params openapigen.PostDashboardV1PaymentsParams) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.NewPayment
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	in := entity.NewPayment{
+		Merchant: req.Merchant,
+		Amount:   req.Amount,
+		Currency: req.Currency,
+	}
+	if req.Reference != nil {
+		in.Reference = *req.Reference
+	}
+	if req.Notes != nil {
+		in.Notes = *req.Notes
+	}
+	var idempotencyKey string
+	if params.IdempotencyKey != nil {
+		idempotencyKey = *params.IdempotencyKey
+	}
+
+	payment, replayed, err := h.paymentUC.CreatePayment(caller, idempotencyKey, in)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	if replayed {
+		w.Header().Set("Idempotent-Replayed", "true")
+	}
+	w.Header().Set("ETag", etag(payment.Version))
+	transport.WriteJSON(w, http.StatusCreated, toPayment(payment))
+}
@@ -0,0 +1,65 @@
+package handler
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/module/payment/usecase"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/principal"
+)
+
+// statusUsecase answers UpdatePaymentStatus as if the payment were at version 3.
+type statusUsecase struct {
+	usecase.PaymentUsecase
+}
+
+func (statusUsecase) UpdatePaymentStatus(_ *entity.Principal, id string, version int64, status entity.PaymentStatus, _ string) (*entity.Payment, error) {
+	if version != 3 {
+		return nil, entity.ErrorPreconditionFailed("payment was changed by someone else; fetch it again")
+	}
+	return &entity.Payment{ID: id, Status: status, Amount: entity.Money{Currency: "IDR"}, Version: version + 1}, nil
+}
+
+func TestPatchPaymentStatusIfMatch(t *testing.T) {
+	tests := []struct {
+		name     string
+		ifMatch  *string
+		want     int
+		wantETag string
+	}{
+		{"missing", nil, http.StatusPreconditionRequired, ""},
+		{"current etag", ptr(`"3"`), http.StatusOK, `"4"`},
+		{"weak current etag", ptr(`W/"3"`), http.StatusOK, `"4"`},
+		{"unquoted current version", ptr(`3`), http.StatusOK, `"4"`},
+		{"stale etag", ptr(`"2"`), http.StatusPreconditionFailed, ""},
+		{"not a version", ptr(`"abc"`), http.StatusBadRequest, ""},
+		{"version zero", ptr(`"0"`), http.StatusBadRequest, ""},
+		{"a list of etags", ptr(`"2", "3"`), http.StatusBadRequest, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			h := NewPaymentHandler(statusUsecase{})
+			r := httptest.NewRequest(http.MethodPatch, "/dashboard/v1/payments/pay_1/status",
+				strings.NewReader(`{"status":"completed","reason":"settled"}`))
+			r = r.WithContext(principal.NewContext(r.Context(), &entity.Principal{UserID: "1", Role: "operation"}))
+			w := httptest.NewRecorder()
+
+			h.PatchDashboardV1PaymentsIdStatus(w, r, "pay_1", openapigen.PatchDashboardV1PaymentsIdStatusParams{IfMatch: tt.ifMatch})
+
+			if w.Code != tt.want {
+				t.Fatalf("status = %d, want %d: %s", w.Code, tt.want, w.Body)
+			}
+			if got := w.Header().Get("ETag"); got != tt.wantETag {
+				t.Errorf("ETag = %q, want %q", got, tt.wantETag)
+			}
+		})
+	}
+}
+
+func ptr(s string) *string {
+	return &s
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/principal"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+)
+
+func toRefund(rf *entity.Refund) openapigen.Refund {
+	amount := rf.Amount.String()
+	status := openapigen.RefundStatus(rf.Status)
+	refund := openapigen.Refund{
+		Id:               &rf.ID,
+		PaymentId:        &rf.PaymentID,
+		Amount:           &amount,
+		Currency:         &rf.Amount.Currency,
+		Status:           &status,
+		Reason:           &rf.Reason,
+		RequestedByEmail: &rf.RequestedByEmail,
+		CreatedAt:        &rf.CreatedAt,
+		DecidedAt:        rf.DecidedAt,
+	}
+	if rf.RequestedBy != "" {
+		refund.RequestedBy = &rf.RequestedBy
+	}
+	if rf.DecidedBy != "" {
+		refund.DecidedBy = &rf.DecidedBy
+	}
+	if rf.DecidedByEmail != "" {
+		refund.DecidedByEmail = &rf.DecidedByEmail
+	}
+	return refund
+}
+
+// GetDashboardV1PaymentsIdRefunds lists a payment's refunds, oldest first.
+func (h *PaymentHandler) GetDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId) {
+	refunds, err := h.paymentUC.ListRefunds(id)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	response := openapigen.RefundListResponse{Refunds: make([]openapigen.Refund, len(refunds))}
+	for i, rf := range refunds {
+		response.Refunds[i] = toRefund(rf)
+	}
+	transport.WriteJSON(w, http.StatusOK, response)
+}
+
+// PostDashboardV1PaymentsIdRefunds requests a full or partial refund.
+func (h *PaymentHandler) PostDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.RefundRequest
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	var amount string
+	if req.Amount != nil {
+		amount = *req.Amount
+	}
+
+	refund, err := h.paymentUC.RequestRefund(caller, id, amount, req.Reason)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusCreated, toRefund(refund))
+}
+
+// PostDashboardV1PaymentsIdRefundsRefundIdApprove approves a requested refund.
+func (h *PaymentHandler) PostDashboardV1PaymentsIdRefundsRefundIdApprove(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId, refundId openapigen.RefundId) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	refund, err := h.paymentUC.ApproveRefund(caller, id, refundId)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toRefund(refund))
+}
+
+// PostDashboardV1PaymentsIdRefundsRefundIdReject rejects a requested refund.
+func (h *PaymentHandler) PostDashboardV1PaymentsIdRefundsRefundIdReject(w http.ResponseWriter, r *http.Request, id openapigen.PaymentId, refundId openapigen.RefundId) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.RefundRejection
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	refund, err := h.paymentUC.RejectRefund(caller, id, refundId, req.Reason)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toRefund(refund))
+}
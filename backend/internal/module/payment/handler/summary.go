@@ -0,0 +1,69 @@
+package handler
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+)
+
+func toPaymentSummary(s *entity.PaymentSummary) openapigen.PaymentSummary {
+	summary := openapigen.PaymentSummary{
+		ReportCurrency: s.Currency,
+		Total:          s.Total,
+		Completed:      s.Completed,
+		Processing:     s.Processing,
+		Failed:         s.Failed,
+		From:           s.From,
+		To:             s.To,
+		Days:           make([]openapigen.PaymentDayTotals, len(s.Days)),
+		Unconverted:    make([]openapigen.UnconvertedTotal, len(s.Unconverted)),
+	}
+	for i, d := range s.Days {
+		summary.Days[i] = openapigen.PaymentDayTotals{
+			Date:       d.Date,
+			Total:      d.Total.String(),
+			Completed:  d.Completed.String(),
+			Processing: d.Processing.String(),
+			Failed:     d.Failed.String(),
+		}
+	}
+	for i, u := range s.Unconverted {
+		summary.Unconverted[i] = openapigen.UnconvertedTotal{
+			Currency: u.Amount.Currency,
+			Count:    u.Count,
+			Amount:   u.Amount.String(),
+		}
+	}
+	return summary
+}
+
+// GetDashboardV1PaymentsSummary counts the payments of a range of days by status and totals them per day.
+func (h *PaymentHandler) GetDashboardV1PaymentsSummary(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1PaymentsSummaryParams) {
+	var timezone, from, to string
+	if params.Tz != nil {
+		timezone = *params.Tz
+	}
+	if params.From != nil {
+		from = params.From.String()
+	}
+	if params.To != nil {
+		to = params.To.String()
+	}
+
+	summary, err := h.paymentUC.SummarizePayments(params.ReportCurrency, timezone, from, to)
+	if err != nil {
+		// a bad currency, time zone or range is the caller's to fix; anything else is ours
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeBadRequest {
+			transport.WriteAppError(w, appErr)
+			return
+		}
+		transport.WriteError(w, entity.ErrorInternal("failed to summarize payments"))
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toPaymentSummary(summary))
+}
@@ -0,0 +1,137 @@
+package repository
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// sortField is one ORDER BY term. key is the SQL expression rows are ordered
+// and compared by, so the keyset condition orders rows exactly like the sort.
+type sortField struct {
+	name string
+	key  string
+	desc bool
+}
+
+// sortKeys maps sortable fields to their SQL. created_at is RFC 3339 text with
+// an offset, so it is compared as a Julian day number.
+var sortKeys = map[string]string{
+	"id":         "id",
+	"merchant":   "merchant",
+	"status":     "status",
+	"currency":   "currency",
+	"amount":     "amount",
+	"created_at": "julianday(created_at)",
+}
+
+// pageCursor is what a next_cursor stands for: the sort it belongs to and the
+// sort key values of the last payment on the page.
+type pageCursor struct {
+	Sort string `json:"s"`
+	Keys []any  `json:"k"`
+}
+
+// sortString gives the canonical form of the sort, which a cursor must match.
+func sortString(fields []sortField) string {
+	parts := make([]string, len(fields))
+	for i, f := range fields {
+		parts[i] = f.name
+		if f.desc {
+			parts[i] = "-" + f.name
+		}
+	}
+	return strings.Join(parts, ",")
+}
+
+func orderByClause(fields []sortField) string {
+	terms := make([]string, len(fields))
+	for i, f := range fields {
+		dir := "ASC"
+		if f.desc {
+			dir = "DESC"
+		}
+		terms[i] = f.key + " " + dir
+	}
+	return strings.Join(terms, ", ")
+}
+
+// keysetCondition selects the rows that sort after the cursor's row:
+// (k1 > v1) OR (k1 = v1 AND k2 > v2) OR ..., with < for descending fields.
+func keysetCondition(fields []sortField, keys []any) (string, []any) {
+	var (
+		terms []string
+		args  []any
+	)
+	for i, f := range fields {
+		var parts []string
+		for j := 0; j < i; j++ {
+			parts = append(parts, fields[j].key+" = ?")
+			args = append(args, keys[j])
+		}
+		op := ">"
+		if f.desc {
+			op = "<"
+		}
+		parts = append(parts, f.key+" "+op+" ?")
+		args = append(args, keys[i])
+		terms = append(terms, "("+strings.Join(parts, " AND ")+")")
+	}
+	return "(" + strings.Join(terms, " OR ") + ")", args
+}
+
+func encodeCursor(fields []sortField, keys []any) (string, error) {
+	data, err := json.Marshal(pageCursor{Sort: sortString(fields), Keys: keys})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode cursor: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(data), nil
+}
+
+func decodeCursor(cursor string, fields []sortField) ([]any, error) {
+	data, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, entity.ErrorBadRequest("cursor is invalid")
+	}
+	// numbers are decoded as json.Number so that amounts beyond 2^53 minor units stay exact
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.UseNumber()
+	var c pageCursor
+	if err := dec.Decode(&c); err != nil || len(c.Keys) != len(fields) {
+		return nil, entity.ErrorBadRequest("cursor is invalid")
+	}
+	if c.Sort != sortString(fields) {
+		return nil, entity.ErrorBadRequest("cursor was issued for a different sort")
+	}
+	keys := make([]any, len(c.Keys))
+	for i, k := range c.Keys {
+		key, ok := cursorKey(k)
+		if !ok {
+			return nil, entity.ErrorBadRequest("cursor is invalid")
+		}
+		keys[i] = key
+	}
+	return keys, nil
+}
+
+// cursorKey turns a decoded key into a query argument: integers as int64 and
+// other numbers as float64, since a json.Number would be bound as text, which
+// SQLite orders after every number.
+func cursorKey(k any) (any, bool) {
+	switch k := k.(type) {
+	case string:
+		return k, true
+	case json.Number:
+		if n, err := k.Int64(); err == nil {
+			return n, true
+		}
+		if f, err := k.Float64(); err == nil {
+			return f, true
+		}
+	}
+	return nil, false
+}
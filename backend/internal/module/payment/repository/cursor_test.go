@@ -0,0 +1,70 @@
+package repository
+
+import (
+	"encoding/base64"
+	"reflect"
+	"testing"
+)
+
+func TestCursorRoundTrip(t *testing.T) {
+	// keys as they are scanned from SQLite for each sort key
+	keys := map[string]any{
+		"id":         "pay_001",
+		"merchant":   "Tokopedia",
+		"status":     "completed",
+		"currency":   "USD",
+		"amount":     int64(9007199254740993), // 2^53 + 1, not representable as float64
+		"created_at": 2461330.8190625,
+		"rank":       -1.25,
+	}
+	// rank is only used when searching without an explicit sort
+	sorts := [][]sortField{parseSortBy("", true)}
+	for name := range sortKeys {
+		sorts = append(sorts, parseSortBy(name, false), parseSortBy("-"+name, false))
+	}
+
+	for _, fields := range sorts {
+		want := make([]any, len(fields))
+		for i, f := range fields {
+			want[i] = keys[f.name]
+		}
+
+		cursor, err := encodeCursor(fields, want)
+		if err != nil {
+			t.Fatalf("encodeCursor(%s): %v", sortString(fields), err)
+		}
+		got, err := decodeCursor(cursor, fields)
+		if err != nil {
+			t.Fatalf("decodeCursor(%s): %v", sortString(fields), err)
+		}
+		if !reflect.DeepEqual(got, want) {
+			t.Errorf("sort %s: keys = %#v, want %#v", sortString(fields), got, want)
+		}
+	}
+}
+
+func TestDecodeCursorRejects(t *testing.T) {
+	fields := parseSortBy("amount", false)
+	other, err := encodeCursor(parseSortBy("-amount", false), []any{"IDR", int64(1), "pay_001"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	raw := func(s string) string { return base64.RawURLEncoding.EncodeToString([]byte(s)) }
+
+	tests := []struct {
+		name   string
+		cursor string
+	}{
+		{"not base64", "%%%"},
+		{"not json", raw("nope")},
+		{"other sort", other},
+		{"too few keys", raw(`{"s":"currency,amount,id","k":["IDR",1]}`)},
+		{"object key", raw(`{"s":"currency,amount,id","k":["IDR",{},"pay_001"]}`)},
+		{"null key", raw(`{"s":"currency,amount,id","k":["IDR",null,"pay_001"]}`)},
+	}
+	for _, tt := range tests {
+		if keys, err := decodeCursor(tt.cursor, fields); err == nil {
+			t.Errorf("%s: decodeCursor = %v, want an error", tt.name, keys)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package repository
+
+import (
+	"database/sql"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+type FXRateRepository interface {
+	SaveFXRates(rates []*entity.FXRate) error
+	ListFXRates(currency string) ([]*entity.FXRate, error)
+}
+
+type fxRateRepo struct {
+	db *sql.DB
+}
+
+func NewFXRateRepo(db *sql.DB) FXRateRepository {
+	return &fxRateRepo{db: db}
+}
+
+// SaveFXRates stores rates in one transaction. A rate for a pair and effective
+// time that is already stored is replaced.
+func (r *fxRateRepo) SaveFXRates(rates []*entity.FXRate) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	for _, rate := range rates {
+		if _, err := tx.Exec(
+			`INSERT INTO fx_rates(base, quote, rate, effective_from) VALUES (?, ?, ?, ?)
+			 ON CONFLICT (base, quote, effective_from) DO UPDATE SET rate = excluded.rate`,
+			rate.Base, rate.Quote, rate.Rate, rate.EffectiveFrom.UTC(),
+		); err != nil {
+			return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return nil
+}
+
+// ListFXRates returns the rates into or out of currency, oldest first.
+func (r *fxRateRepo) ListFXRates(currency string) ([]*entity.FXRate, error) {
+	rows, err := r.db.Query(
+		`SELECT base, quote, rate, effective_from FROM fx_rates WHERE base = ? OR quote = ? ORDER BY effective_from`,
+		currency, currency,
+	)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	defer rows.Close()
+
+	rates := []*entity.FXRate{}
+	for rows.Next() {
+		var rate entity.FXRate
+		if err := rows.Scan(&rate.Base, &rate.Quote, &rate.Rate, &rate.EffectiveFrom); err != nil {
+			return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+		}
+		rates = append(rates, &rate)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "db error")
+	}
+	return rates, nil
+}
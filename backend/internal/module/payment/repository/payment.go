@@ -2,14 +2,39 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/mattn/go-sqlite3"
 )
 
+var likeEscaper = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+
 type PaymentRepository interface {
-	ListPayments(filters map[string]interface{}, sortBy string) ([]*entity.Payment, error)
+	ListPayments(filters map[string]interface{}, sortBy string, limit int, cursor string) (*entity.PaymentPage, error)
+	GetPaymentByID(id string) (*entity.Payment, error)
+	EachPayment(from, to time.Time, fn func(*entity.Payment) error) error
+	CreatePayment(p *entity.Payment, event *entity.PaymentEvent, idem *entity.IdempotencyRecord) error
+	GetIdempotencyRecord(userID, credential, key string, at time.Time) (*entity.IdempotencyRecord, error)
+	UpdatePaymentStatus(change entity.PaymentStatusChange, event *entity.PaymentEvent) error
+	ListPaymentEvents(paymentID string) ([]*entity.PaymentEvent, error)
+	CreateRefund(refund *entity.Refund, event *entity.PaymentEvent) error
+	GetRefund(paymentID, refundID string) (*entity.Refund, error)
+	ListRefunds(paymentID string) ([]*entity.Refund, error)
+	DecideRefund(refund *entity.Refund, event *entity.PaymentEvent) error
+}
+
+// paymentColumns ends with the sum of the approved refunds, which is derived
+// rather than stored so that it cannot drift from the refunds table.
+const paymentColumns = "id, merchant, status, amount, currency, reference, notes, created_at, version, " +
+	"(SELECT COALESCE(SUM(r.amount), 0) FROM refunds r WHERE r.payment_id = payments.id AND r.status = 'approved')"
+
+// paymentFields are the scan destinations for paymentColumns.
+func paymentFields(p *entity.Payment) []any {
+	return []any{&p.ID, &p.Merchant, &p.Status, &p.Amount.Amount, &p.Amount.Currency, &p.Reference, &p.Notes, &p.CreatedAt, &p.Version, &p.Refunded}
 }
 
 type paymentRepo struct {
@@ -20,11 +45,36 @@ func NewPaymentRepo(db *sql.DB) PaymentRepository {
 	return &paymentRepo{db: db}
 }
 
-// ListPayments retrieves payments with optional filtering and sorting
-func (r *paymentRepo) ListPayments(filters map[string]interface{}, sortBy string) ([]*entity.Payment, error) {
-	query := "SELECT id, merchant, status, amount, created_at FROM payments WHERE 1=1"
+// ListPayments retrieves one page of payments with optional filtering and sorting.
+// Pages are cut by keyset pagination: cursor holds the sort keys of the previous
+// page's last row, and the page starts right after it.
+func (r *paymentRepo) ListPayments(filters map[string]interface{}, sortBy string, limit int, cursor string) (*entity.PaymentPage, error) {
+	q, _ := filters["q"].(string)
+	search := q != ""
+	fields := parseSortBy(sortBy, search)
+
+	keyColumns := make([]string, len(fields))
+	for i, f := range fields {
+		keyColumns[i] = f.key
+	}
+	columns := paymentColumns + ", " + strings.Join(keyColumns, ", ")
+	table := "payments"
 	args := []any{}
 
+	if search {
+		if !searchEnabled {
+			return nil, entity.ErrorBadRequest("search is not available: the server was built without the sqlite_fts5 tag")
+		}
+		match, err := matchQuery(q)
+		if err != nil {
+			return nil, err
+		}
+		columns += ", " + searchColumns
+		table += searchJoin
+		args = append(args, match)
+	}
+	query := "SELECT " + columns + " FROM " + table + " WHERE 1=1"
+
 	// Apply filters
 	if status, ok := filters["status"]; ok && status != "" {
 		query += " AND status = ?"
@@ -36,78 +86,330 @@ func (r *paymentRepo) ListPayments(filters map[string]interface{}, sortBy string
 		args = append(args, id)
 	}
 
-	// Apply sorting
-	if sortBy != "" {
-		query += " ORDER BY " + parseSortBy(sortBy)
-	} else {
-		query += " ORDER BY created_at DESC"
+	// created_at is RFC 3339 text that may carry any offset, so times are compared as Julian days
+	if from, ok := filters["created_from"].(time.Time); ok {
+		query += " AND julianday(created_at) >= julianday(?)"
+		args = append(args, from.Format(time.RFC3339Nano))
+	}
+
+	if to, ok := filters["created_to"].(time.Time); ok {
+		query += " AND julianday(created_at) <= julianday(?)"
+		args = append(args, to.Format(time.RFC3339Nano))
+	}
+
+	if currency, ok := filters["currency"].(string); ok && currency != "" {
+		query += " AND currency = ?"
+		args = append(args, currency)
+	}
+
+	// amount bounds are minor units of the currency filter, which the handler requires with them
+	if amountMin, ok := filters["amount_min"].(int64); ok {
+		query += " AND amount >= ?"
+		args = append(args, amountMin)
+	}
+
+	if amountMax, ok := filters["amount_max"].(int64); ok {
+		query += " AND amount <= ?"
+		args = append(args, amountMax)
+	}
+
+	if merchant, ok := filters["merchant"]; ok && merchant != "" {
+		query += " AND merchant = ?"
+		args = append(args, merchant)
 	}
 
+	// LIKE ignores ASCII case in SQLite; wildcards in the prefix are matched literally
+	if prefix, ok := filters["merchant_prefix"].(string); ok && prefix != "" {
+		query += ` AND merchant LIKE ? ESCAPE '\'`
+		args = append(args, likeEscaper.Replace(prefix)+"%")
+	}
+
+	if cursor != "" {
+		keys, err := decodeCursor(cursor, fields)
+		if err != nil {
+			return nil, err
+		}
+		condition, keyArgs := keysetCondition(fields, keys)
+		query += " AND " + condition
+		args = append(args, keyArgs...)
+	}
+
+	// Apply sorting; one extra row tells whether another page follows
+	query += " ORDER BY " + orderByClause(fields) + " LIMIT ?"
+	args = append(args, limit+1)
+
 	rows, err := r.db.Query(query, args...)
 	if err != nil {
 		return nil, fmt.Errorf("failed to query payments: %w", err)
 	}
 	defer rows.Close()
 
-	var payments []*entity.Payment
+	page := &entity.PaymentPage{Payments: []*entity.Payment{}}
+	var lastKeys []any
 	for rows.Next() {
+		if len(page.Payments) == limit {
+			page.HasMore = true
+			break
+		}
+
 		var p entity.Payment
-		if err := rows.Scan(&p.ID, &p.Merchant, &p.Status, &p.Amount, &p.CreatedAt); err != nil {
+		keys := make([]any, len(fields))
+		dest := paymentFields(&p)
+		for i := range keys {
+			dest = append(dest, &keys[i])
+		}
+		var marked []string
+		if search {
+			marked = make([]string, len(searchFields))
+			for i := range marked {
+				dest = append(dest, &marked[i])
+			}
+		}
+		if err := rows.Scan(dest...); err != nil {
 			return nil, fmt.Errorf("failed to scan payment: %w", err)
 		}
-		payments = append(payments, &p)
+		if search {
+			p.Highlights = highlights(marked)
+		}
+		page.Payments = append(page.Payments, &p)
+		lastKeys = keys
 	}
 
 	if err := rows.Err(); err != nil {
 		return nil, fmt.Errorf("error iterating payments: %w", err)
 	}
 
-	return payments, nil
+	if page.HasMore {
+		if page.NextCursor, err = encodeCursor(fields, lastKeys); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+// GetPaymentByID returns one payment, or a not found error.
+func (r *paymentRepo) GetPaymentByID(id string) (*entity.Payment, error) {
+	var p entity.Payment
+	err := r.db.QueryRow("SELECT "+paymentColumns+" FROM payments WHERE id = ?", id).Scan(paymentFields(&p)...)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrorNotFound("payment not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment: %w", err)
+	}
+	return &p, nil
+}
+
+// EachPayment calls fn with every payment created from up to but not including
+// to, oldest first, and stops at the first error fn returns. Rows are scanned one
+// at a time rather than loaded together.
+func (r *paymentRepo) EachPayment(from, to time.Time, fn func(*entity.Payment) error) error {
+	rows, err := r.db.Query(
+		"SELECT "+paymentColumns+" FROM payments"+
+			" WHERE julianday(created_at) >= julianday(?) AND julianday(created_at) < julianday(?)"+
+			" ORDER BY julianday(created_at), id",
+		from.Format(time.RFC3339Nano), to.Format(time.RFC3339Nano),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to query payments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var p entity.Payment
+		if err := rows.Scan(paymentFields(&p)...); err != nil {
+			return fmt.Errorf("failed to scan payment: %w", err)
+		}
+		if err := fn(&p); err != nil {
+			return err
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to iterate payments: %w", err)
+	}
+	return nil
+}
+
+// CreatePayment stores p together with its creation event and idem, the record
+// of the request that created it, in one transaction; idem may be nil. Expired
+// records are swept first, so only a live record with the same user and key is
+// a conflict.
+func (r *paymentRepo) CreatePayment(p *entity.Payment, event *entity.PaymentEvent, idem *entity.IdempotencyRecord) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	// created_at is kept as RFC 3339 text, like the seeded payments
+	if _, err := tx.Exec(
+		"INSERT INTO payments(id, merchant, status, amount, currency, reference, notes, created_at, version) VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)",
+		p.ID, p.Merchant, p.Status, p.Amount.Amount, p.Amount.Currency, p.Reference, p.Notes, p.CreatedAt.Format(time.RFC3339), p.Version,
+	); err != nil {
+		return fmt.Errorf("failed to insert payment: %w", err)
+	}
+	if err := insertPaymentEvent(tx, event); err != nil {
+		return err
+	}
+
+	if idem != nil {
+		if _, err := tx.Exec("DELETE FROM idempotency_keys WHERE expires_at <= ?", idem.CreatedAt.UTC()); err != nil {
+			return fmt.Errorf("failed to sweep idempotency keys: %w", err)
+		}
+		if _, err := tx.Exec(
+			"INSERT INTO idempotency_keys(user_id, credential, idempotency_key, request_hash, payment_id, created_at, expires_at) VALUES (?, ?, ?, ?, ?, ?, ?)",
+			idem.UserID, idem.Credential, idem.Key, idem.RequestHash, idem.PaymentID, idem.CreatedAt.UTC(), idem.ExpiresAt.UTC(),
+		); err != nil {
+			var sqliteErr sqlite3.Error
+			if errors.As(err, &sqliteErr) && sqliteErr.ExtendedCode == sqlite3.ErrConstraintPrimaryKey {
+				return entity.ErrorConflict("idempotency key is already in use")
+			}
+			return fmt.Errorf("failed to insert idempotency key: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit payment: %w", err)
+	}
+	return nil
+}
+
+// UpdatePaymentStatus applies change, bumps the payment's version and records
+// event, in one transaction. It is a precondition failure when the payment is
+// no longer at change.Version in change.From, i.e. someone else changed it first.
+func (r *paymentRepo) UpdatePaymentStatus(change entity.PaymentStatusChange, event *entity.PaymentEvent) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(
+		"UPDATE payments SET status = ?, version = version + 1 WHERE id = ? AND version = ? AND status = ?",
+		change.To, change.PaymentID, change.Version, change.From,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update payment status: %w", err)
+	}
+	if n == 0 {
+		return entity.ErrorPreconditionFailed("payment was changed by someone else; fetch it again")
+	}
+	if err := insertPaymentEvent(tx, event); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit payment status: %w", err)
+	}
+	return nil
+}
+
+func insertPaymentEvent(tx *sql.Tx, e *entity.PaymentEvent) error {
+	if _, err := tx.Exec(
+		`INSERT INTO payment_events(payment_id, type, actor_id, actor_email, source, old_value, new_value, reason, refund_id, created_at)
+		 VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)`,
+		e.PaymentID, e.Type, sql.NullString{String: e.ActorID, Valid: e.ActorID != ""}, e.ActorEmail,
+		e.Source, e.OldValue, e.NewValue, e.Reason, e.RefundID, e.CreatedAt.UTC(),
+	); err != nil {
+		return fmt.Errorf("failed to insert payment event: %w", err)
+	}
+	return nil
+}
+
+// ListPaymentEvents returns the history of a payment, oldest first.
+func (r *paymentRepo) ListPaymentEvents(paymentID string) ([]*entity.PaymentEvent, error) {
+	rows, err := r.db.Query(
+		`SELECT id, payment_id, type, actor_id, actor_email, source, old_value, new_value, reason, refund_id, created_at
+		 FROM payment_events WHERE payment_id = ? ORDER BY id`, paymentID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query payment events: %w", err)
+	}
+	defer rows.Close()
+
+	events := []*entity.PaymentEvent{}
+	for rows.Next() {
+		var (
+			e       entity.PaymentEvent
+			actorID sql.NullString
+		)
+		if err := rows.Scan(&e.ID, &e.PaymentID, &e.Type, &actorID, &e.ActorEmail, &e.Source, &e.OldValue, &e.NewValue, &e.Reason, &e.RefundID, &e.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan payment event: %w", err)
+		}
+		e.ActorID = actorID.String
+		events = append(events, &e)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating payment events: %w", err)
+	}
+	return events, nil
+}
+
+// GetIdempotencyRecord returns the record of an earlier request with the same
+// user, credential and key that is still live at at, or a not found error.
+func (r *paymentRepo) GetIdempotencyRecord(userID, credential, key string, at time.Time) (*entity.IdempotencyRecord, error) {
+	rec := entity.IdempotencyRecord{UserID: userID, Credential: credential, Key: key}
+	err := r.db.QueryRow(
+		"SELECT request_hash, payment_id, created_at, expires_at FROM idempotency_keys WHERE user_id = ? AND credential = ? AND idempotency_key = ? AND expires_at > ?",
+		userID, credential, key, at.UTC(),
+	).Scan(&rec.RequestHash, &rec.PaymentID, &rec.CreatedAt, &rec.ExpiresAt)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrorNotFound("idempotency key not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query idempotency key: %w", err)
+	}
+	return &rec, nil
 }
 
-// Format: "-field" for descending, "field" for ascending
-func parseSortBy(sortBy string) string {
-	fields := strings.Split(sortBy, ",")
-	var orderClauses []string
+// Format: "-field" for descending, "field" for ascending.
+// Unknown fields sort by created_at, repeated fields are dropped, and id is
+// always added last so that no two rows tie, which keyset pagination needs.
+// Without fields a search sorts by relevance and anything else newest first.
+func parseSortBy(sortBy string, search bool) []sortField {
+	var fields []sortField
+	seen := map[string]bool{}
 
-	for _, field := range fields {
+	for _, field := range strings.Split(sortBy, ",") {
 		field = strings.TrimSpace(field)
 		if field == "" {
 			continue
 		}
 
-		if after, ok :=strings.CutPrefix(field, "-"); ok  {
-			// Descending
-			fieldName := after
-			orderClauses = append(orderClauses, fmt.Sprintf("%s DESC", sanitizeFieldName(fieldName)))
-		} else {
-			// Ascending
-			orderClauses = append(orderClauses, fmt.Sprintf("%s ASC", sanitizeFieldName(field)))
+		name, desc := strings.CutPrefix(field, "-")
+		name = sanitizeFieldName(name)
+		if seen[name] {
+			continue
+		}
+		// amounts are in minor units of their own currency, so they are only ordered within one
+		if name == "amount" && !seen["currency"] {
+			seen["currency"] = true
+			fields = append(fields, sortField{name: "currency", key: sortKeys["currency"]})
 		}
+		seen[name] = true
+		fields = append(fields, sortField{name: name, key: sortKeys[name], desc: desc})
 	}
 
-	if len(orderClauses) == 0 {
-		return "created_at DESC"
+	if len(fields) == 0 && search {
+		fields = append(fields, sortField{name: "rank", key: "fts_rank"})
+	}
+	if len(fields) == 0 {
+		fields = append(fields, sortField{name: "created_at", key: sortKeys["created_at"], desc: true})
+	}
+	if !seen["id"] {
+		fields = append(fields, sortField{name: "id", key: sortKeys["id"]})
 	}
 
-	return strings.Join(orderClauses, ", ")
+	return fields
 }
 
 // sanitizeFieldName ensures only valid field names can be used.
 func sanitizeFieldName(field string) string {
-	// amount is stored as TEXT; cast to REAL for correct numeric ordering
-	if field == "amount" {
-		return "CAST(amount AS REAL)"
-	}
-
-	validFields := map[string]bool{
-		"id":         true,
-		"merchant":   true,
-		"status":     true,
-		"created_at": true,
-	}
-
-	if validFields[field] {
+	if _, ok := sortKeys[field]; ok {
 		return field
 	}
 
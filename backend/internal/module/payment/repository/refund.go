@@ -0,0 +1,143 @@
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+const refundColumns = "id, payment_id, amount, currency, status, reason, requested_by, requested_by_email, decided_by, decided_by_email, created_at, decided_at"
+
+func scanRefund(scan func(dest ...any) error) (*entity.Refund, error) {
+	var (
+		rf                     entity.Refund
+		requestedBy, decidedBy sql.NullString
+		decidedAt              sql.NullTime
+	)
+	if err := scan(&rf.ID, &rf.PaymentID, &rf.Amount.Amount, &rf.Amount.Currency, &rf.Status, &rf.Reason,
+		&requestedBy, &rf.RequestedByEmail, &decidedBy, &rf.DecidedByEmail, &rf.CreatedAt, &decidedAt); err != nil {
+		return nil, err
+	}
+	rf.RequestedBy = requestedBy.String
+	rf.DecidedBy = decidedBy.String
+	if decidedAt.Valid {
+		rf.DecidedAt = &decidedAt.Time
+	}
+	return &rf, nil
+}
+
+// CreateRefund stores a requested refund and its event in one transaction.
+// The insert itself checks that the payment is completed and that the refunds
+// holding part of it, this one included, stay within its amount, so concurrent
+// requests cannot overdraw it; when they would, it is a conflict.
+func (r *paymentRepo) CreateRefund(refund *entity.Refund, event *entity.PaymentEvent) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(
+		`INSERT INTO refunds(id, payment_id, amount, currency, status, reason, requested_by, requested_by_email, created_at)
+		 SELECT ?, p.id, ?, p.currency, ?, ?, ?, ?, ? FROM payments p
+		 WHERE p.id = ? AND p.status = ? AND p.currency = ?
+		   AND p.amount >= ? + (SELECT COALESCE(SUM(r.amount), 0) FROM refunds r WHERE r.payment_id = p.id AND r.status IN (?, ?))`,
+		refund.ID, refund.Amount.Amount, refund.Status, refund.Reason,
+		sql.NullString{String: refund.RequestedBy, Valid: refund.RequestedBy != ""}, refund.RequestedByEmail, refund.CreatedAt.UTC(),
+		refund.PaymentID, entity.PaymentStatusCompleted, refund.Amount.Currency,
+		refund.Amount.Amount, entity.RefundStatusRequested, entity.RefundStatusApproved,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert refund: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to insert refund: %w", err)
+	}
+	if n == 0 {
+		return entity.ErrorConflict("refund exceeds the amount that is left to refund")
+	}
+	if err := insertPaymentEvent(tx, event); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit refund: %w", err)
+	}
+	return nil
+}
+
+// GetRefund returns one refund of a payment, or a not found error.
+func (r *paymentRepo) GetRefund(paymentID, refundID string) (*entity.Refund, error) {
+	rf, err := scanRefund(r.db.QueryRow(
+		"SELECT "+refundColumns+" FROM refunds WHERE id = ? AND payment_id = ?", refundID, paymentID,
+	).Scan)
+	if err == sql.ErrNoRows {
+		return nil, entity.ErrorNotFound("refund not found")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query refund: %w", err)
+	}
+	return rf, nil
+}
+
+// ListRefunds returns the refunds of a payment, oldest first.
+func (r *paymentRepo) ListRefunds(paymentID string) ([]*entity.Refund, error) {
+	rows, err := r.db.Query(
+		"SELECT "+refundColumns+" FROM refunds WHERE payment_id = ? ORDER BY created_at, id", paymentID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query refunds: %w", err)
+	}
+	defer rows.Close()
+
+	refunds := []*entity.Refund{}
+	for rows.Next() {
+		rf, err := scanRefund(rows.Scan)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan refund: %w", err)
+		}
+		refunds = append(refunds, rf)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating refunds: %w", err)
+	}
+	return refunds, nil
+}
+
+// DecideRefund stores the decision on a requested refund (its new status and
+// decider) and its event in one transaction. It is a conflict when the refund
+// was decided in the meantime.
+func (r *paymentRepo) DecideRefund(refund *entity.Refund, event *entity.PaymentEvent) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	res, err := tx.Exec(
+		`UPDATE refunds SET status = ?, decided_by = ?, decided_by_email = ?, decided_at = ?
+		 WHERE id = ? AND payment_id = ? AND status = ?`,
+		refund.Status, sql.NullString{String: refund.DecidedBy, Valid: refund.DecidedBy != ""}, refund.DecidedByEmail, refund.DecidedAt.UTC(),
+		refund.ID, refund.PaymentID, entity.RefundStatusRequested,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to update refund: %w", err)
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to update refund: %w", err)
+	}
+	if n == 0 {
+		return entity.ErrorConflict("refund was already decided")
+	}
+	if err := insertPaymentEvent(tx, event); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit refund: %w", err)
+	}
+	return nil
+}
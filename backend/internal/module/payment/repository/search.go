@@ -0,0 +1,60 @@
+package repository
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// searchFields are the payments_fts columns, in index order.
+var searchFields = []string{"id", "merchant", "reference", "notes"}
+
+// highlight() wraps matches in these control characters, which are swapped for
+// <mark> tags only after the text is HTML-escaped.
+const markOpen = "\x02"
+
+var markReplacer = strings.NewReplacer(markOpen, "<mark>", "\x03", "</mark>")
+
+// searchJoin restricts payments to the rows matching the search and adds their
+// relevance (bm25, lower is better) and highlighted fields as fts_* columns.
+const searchJoin = ` JOIN (SELECT rowid AS fts_rowid, rank AS fts_rank,
+	  highlight(payments_fts, 0, char(2), char(3)) AS fts_id,
+	  highlight(payments_fts, 1, char(2), char(3)) AS fts_merchant,
+	  highlight(payments_fts, 2, char(2), char(3)) AS fts_reference,
+	  highlight(payments_fts, 3, char(2), char(3)) AS fts_notes
+	FROM payments_fts WHERE payments_fts MATCH ?) AS fts ON fts.fts_rowid = payments.rowid`
+
+// searchColumns are the highlighted fields selected from searchJoin, in searchFields order.
+const searchColumns = "fts_id, fts_merchant, fts_reference, fts_notes"
+
+// matchQuery turns free text into an FTS5 query in which every word must be
+// the prefix of an indexed word. Words are quoted, so the query syntax of FTS5
+// never applies to user input.
+func matchQuery(q string) (string, error) {
+	words := strings.FieldsFunc(q, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	if len(words) == 0 {
+		return "", entity.ErrorBadRequest("q must contain at least one letter or digit")
+	}
+
+	terms := make([]string, len(words))
+	for i, w := range words {
+		terms[i] = `"` + w + `"*`
+	}
+	return strings.Join(terms, " "), nil
+}
+
+// highlights keeps the fields that contain a match, escaped and with the matches marked.
+func highlights(marked []string) map[string]string {
+	out := map[string]string{}
+	for i, text := range marked {
+		if !strings.Contains(text, markOpen) {
+			continue
+		}
+		out[searchFields[i]] = markReplacer.Replace(html.EscapeString(text))
+	}
+	return out
+}
@@ -0,0 +1,6 @@
+//go:build !sqlite_fts5
+
+package repository
+
+// searchEnabled reports whether the SQLite driver was built with FTS5, which the q filter needs.
+const searchEnabled = false
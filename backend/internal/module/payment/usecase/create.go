@@ -0,0 +1,191 @@
+package usecase
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+const (
+	// a retry with the same Idempotency-Key gets the first request's payment for this long
+	idempotencyTTL       = 24 * time.Hour
+	maxIdempotencyKeyLen = 255
+	maxMerchantLength    = 100
+	maxReferenceLength   = 100
+	maxNotesLength       = 1000
+)
+
+// newID returns a random id with the given prefix, such as pay_ (the namespace
+// of the seeded payments) or rf_.
+func newID(prefix string) (string, error) {
+	b := make([]byte, 12)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return prefix + hex.EncodeToString(b), nil
+}
+
+// validatePayment checks a submitted payment and builds the payment to store.
+func validatePayment(in entity.NewPayment) (*entity.Payment, error) {
+	merchant := strings.TrimSpace(in.Merchant)
+	if merchant == "" {
+		return nil, entity.ErrorBadRequest("merchant is required")
+	}
+	if utf8.RuneCountInString(merchant) > maxMerchantLength {
+		return nil, entity.ErrorBadRequest("merchant is too long")
+	}
+	reference := strings.TrimSpace(in.Reference)
+	if utf8.RuneCountInString(reference) > maxReferenceLength {
+		return nil, entity.ErrorBadRequest("reference is too long")
+	}
+	notes := strings.TrimSpace(in.Notes)
+	if utf8.RuneCountInString(notes) > maxNotesLength {
+		return nil, entity.ErrorBadRequest("notes are too long")
+	}
+
+	amount, err := entity.ParseMoney(strings.TrimSpace(in.Amount), in.Currency)
+	if err != nil {
+		return nil, err
+	}
+	if amount.Amount <= 0 {
+		return nil, entity.ErrorBadRequest("amount must be greater than zero")
+	}
+
+	return &entity.Payment{
+		Merchant:  merchant,
+		Status:    entity.PaymentStatusProcessing,
+		Amount:    amount,
+		Reference: reference,
+		Notes:     notes,
+	}, nil
+}
+
+// requestHash fingerprints a validated payment, so that retries whose bodies
+// differ only in formatting (spacing, "50000" vs "50000.00") still match.
+func requestHash(p *entity.Payment) (string, error) {
+	data, err := json.Marshal(struct {
+		Merchant  string       `json:"merchant"`
+		Amount    entity.Money `json:"amount"`
+		Reference string       `json:"reference"`
+		Notes     string       `json:"notes"`
+	}{p.Merchant, p.Amount, p.Reference, p.Notes})
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:]), nil
+}
+
+// idempotencyCredential names the credential a caller's idempotency keys belong
+// to, so that two API keys or sessions of one user cannot replay each other's.
+func idempotencyCredential(caller *entity.Principal) string {
+	if caller.APIKeyID != "" {
+		return "apikey:" + caller.APIKeyID
+	}
+	return "session:" + caller.SessionID
+}
+
+// CreatePayment records a new payment, which starts out processing.
+//
+// With an idempotency key the payment is remembered for a day: a retry with the
+// same credential, key and body gets it back with replayed set, and a different
+// body with the same key is a conflict. The replay returns the payment as it is
+// now, so it carries the current status and version rather than the first
+// response.
+func (p *Payment) CreatePayment(caller *entity.Principal, idempotencyKey string, in entity.NewPayment) (payment *entity.Payment, replayed bool, err error) {
+	if len(idempotencyKey) > maxIdempotencyKeyLen {
+		return nil, false, entity.ErrorBadRequest("Idempotency-Key is too long")
+	}
+
+	payment, err = validatePayment(in)
+	if err != nil {
+		return nil, false, err
+	}
+	hash, err := requestHash(payment)
+	if err != nil {
+		return nil, false, entity.ErrorInternal("failed to hash request")
+	}
+
+	now := time.Now()
+	credential := idempotencyCredential(caller)
+	if idempotencyKey != "" {
+		if prior, ok, err := p.replay(caller.UserID, credential, idempotencyKey, hash, now); ok || err != nil {
+			return prior, ok, err
+		}
+	}
+
+	if payment.ID, err = newID("pay_"); err != nil {
+		return nil, false, entity.ErrorInternal("failed to generate payment id")
+	}
+	payment.CreatedAt = now.Truncate(time.Second)
+	payment.Version = 1
+
+	var idem *entity.IdempotencyRecord
+	if idempotencyKey != "" {
+		idem = &entity.IdempotencyRecord{
+			UserID:      caller.UserID,
+			Credential:  credential,
+			Key:         idempotencyKey,
+			RequestHash: hash,
+			PaymentID:   payment.ID,
+			CreatedAt:   now,
+			ExpiresAt:   now.Add(idempotencyTTL),
+		}
+	}
+
+	event := newPaymentEvent(caller, payment.ID, entity.PaymentEventCreated, now)
+	event.NewValue = string(payment.Status)
+
+	err = p.repo.CreatePayment(payment, event, idem)
+	if appErr, ok := err.(*entity.AppError); ok && appErr.Code == entity.ErrorCodeConflict {
+		// a concurrent request with the same key got there first
+		if prior, ok, err := p.replay(caller.UserID, credential, idempotencyKey, hash, now); ok || err != nil {
+			return prior, ok, err
+		}
+	}
+	if err != nil {
+		return nil, false, err
+	}
+
+	log.Printf("payment: %s created %s (%s %s)", caller.Email, payment.ID, payment.Amount, payment.Amount.Currency)
+	p.invalidateListCache()
+	return payment, false, nil
+}
+
+// replay looks for an earlier request with the same key. ok reports that its
+// payment, read afresh, is returned; a different body under the key is a
+// conflict error.
+func (p *Payment) replay(userID, credential, key, hash string, now time.Time) (payment *entity.Payment, ok bool, err error) {
+	prior, err := p.repo.GetIdempotencyRecord(userID, credential, key, now)
+	if appErr, isApp := err.(*entity.AppError); isApp && appErr.Code == entity.ErrorCodeNotFound {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	if prior.RequestHash != hash {
+		return nil, false, entity.ErrorConflict("Idempotency-Key was already used with a different request body")
+	}
+
+	payment, err = p.repo.GetPaymentByID(prior.PaymentID)
+	if err != nil {
+		return nil, false, err
+	}
+	return payment, true, nil
+}
+
+// invalidateListCache moves listings to a new cache generation, so pages cached
+// before a payment was added or changed are no longer served.
+func (p *Payment) invalidateListCache() {
+	if _, err := p.redis.Incr(context.Background(), cacheGenerationKey); err != nil {
+		log.Printf("payment: failed to invalidate list cache: %v", err)
+	}
+}
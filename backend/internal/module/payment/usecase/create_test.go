@@ -0,0 +1,90 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+var testNewPayment = entity.NewPayment{Merchant: "Test Merchant", Amount: "50000", Currency: "IDR", Reference: "INV-1"}
+
+func TestIdempotencyKeysAreScopedToTheCredential(t *testing.T) {
+	tests := []struct {
+		name     string
+		retry    func(first *entity.Principal) *entity.Principal
+		replayed bool
+	}{
+		{"same session", func(first *entity.Principal) *entity.Principal { return first }, true},
+		{"same session, new access token", func(first *entity.Principal) *entity.Principal {
+			c := *first
+			c.TokenID = "second-token"
+			return &c
+		}, true},
+		{"another session of the user", func(first *entity.Principal) *entity.Principal {
+			c := *first
+			c.SessionID = "other-session"
+			return &c
+		}, false},
+		{"an API key of the user", func(first *entity.Principal) *entity.Principal {
+			c := *first
+			c.SessionID = ""
+			c.APIKeyID = "key-1"
+			return &c
+		}, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPayment(t)
+			caller := p.principal(t, "operation@test.com", "session-1")
+
+			first, replayed, err := p.CreatePayment(caller, "key-1", testNewPayment)
+			if err != nil || replayed {
+				t.Fatalf("first CreatePayment: replayed %v, err %v", replayed, err)
+			}
+			retry, replayed, err := p.CreatePayment(tt.retry(caller), "key-1", testNewPayment)
+			if err != nil {
+				t.Fatalf("retry: %v", err)
+			}
+			if replayed != tt.replayed || (retry.ID == first.ID) != tt.replayed {
+				t.Errorf("retry got %s (replayed %v), first was %s; want replayed %v", retry.ID, replayed, first.ID, tt.replayed)
+			}
+		})
+	}
+}
+
+func TestIdempotentReplayReadsThePaymentAfresh(t *testing.T) {
+	p := newTestPayment(t)
+	caller := p.principal(t, "operation@test.com", "session-1")
+
+	first, _, err := p.CreatePayment(caller, "key-1", testNewPayment)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := p.UpdatePaymentStatus(caller, first.ID, first.Version, entity.PaymentStatusCompleted, "settled"); err != nil {
+		t.Fatalf("UpdatePaymentStatus: %v", err)
+	}
+
+	// a retry that differs only in formatting is the same request
+	retry := testNewPayment
+	retry.Amount = " 50000.00"
+	replay, replayed, err := p.CreatePayment(caller, "key-1", retry)
+	if err != nil || !replayed {
+		t.Fatalf("replay: replayed %v, err %v", replayed, err)
+	}
+	if replay.ID != first.ID || replay.Status != entity.PaymentStatusCompleted || replay.Version != first.Version+1 {
+		t.Errorf("replay = %s %s v%d, want %s completed v%d", replay.ID, replay.Status, replay.Version, first.ID, first.Version+1)
+	}
+}
+
+func TestIdempotencyKeyWithAnotherBodyConflicts(t *testing.T) {
+	p := newTestPayment(t)
+	caller := p.principal(t, "operation@test.com", "session-1")
+	if _, _, err := p.CreatePayment(caller, "key-1", testNewPayment); err != nil {
+		t.Fatal(err)
+	}
+
+	other := testNewPayment
+	other.Amount = "60000"
+	_, _, err := p.CreatePayment(caller, "key-1", other)
+	wantCode(t, err, entity.ErrorCodeConflict)
+}
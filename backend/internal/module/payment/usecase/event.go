@@ -0,0 +1,43 @@
+package usecase
+
+import (
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// actingUser returns the person behind caller: the superuser when caller is an
+// impersonation token.
+func actingUser(caller *entity.Principal) (id, email string) {
+	if caller.ActorID != "" {
+		return caller.ActorID, caller.ActorEmail
+	}
+	return caller.UserID, caller.Email
+}
+
+// newPaymentEvent starts an event of type typ on a payment, attributed to the
+// acting user behind caller.
+func newPaymentEvent(caller *entity.Principal, paymentID, typ string, at time.Time) *entity.PaymentEvent {
+	event := &entity.PaymentEvent{
+		PaymentID: paymentID,
+		Type:      typ,
+		Source:    entity.PaymentEventSourceDashboard,
+		CreatedAt: at,
+	}
+	event.ActorID, event.ActorEmail = actingUser(caller)
+	switch {
+	case caller.ActorID != "":
+		event.Source = entity.PaymentEventSourceImpersonation
+	case caller.APIKeyID != "":
+		event.Source = entity.PaymentEventSourceAPIKey
+	}
+	return event
+}
+
+// ListPaymentEvents returns the history of a payment, oldest first.
+func (p *Payment) ListPaymentEvents(id string) ([]*entity.PaymentEvent, error) {
+	if _, err := p.repo.GetPaymentByID(id); err != nil {
+		return nil, err
+	}
+	return p.repo.ListPaymentEvents(id)
+}
@@ -0,0 +1,58 @@
+package usecase
+
+import (
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// converter converts amounts into one currency at the rate that was in force
+// when each payment was created.
+type converter struct {
+	to string
+	// rates into or out of to, oldest first
+	rates []*entity.FXRate
+}
+
+// convert returns m in c.to and the rate used. A rate stored the other way round
+// is inverted. With no rate in force ok is false rather than m coming back in its
+// own currency, so converted amounts can always be added up.
+func (c *converter) convert(m entity.Money, at time.Time) (converted entity.Money, rate *big.Rat, ok bool, err error) {
+	if m.Currency == c.to {
+		return m, big.NewRat(1, 1), true, nil
+	}
+
+	var (
+		found   *entity.FXRate
+		inverse bool
+	)
+	for _, r := range c.rates {
+		if r.EffectiveFrom.After(at) {
+			break
+		}
+		switch {
+		case r.Base == m.Currency && r.Quote == c.to:
+			found, inverse = r, false
+		case r.Base == c.to && r.Quote == m.Currency:
+			found, inverse = r, true
+		}
+	}
+	if found == nil {
+		return entity.Money{}, nil, false, nil
+	}
+
+	rate, err = entity.ParseRate(found.Rate)
+	if err != nil {
+		return entity.Money{}, nil, false, entity.ErrorInternal(fmt.Sprintf("stored %s/%s rate is invalid", found.Base, found.Quote))
+	}
+	if inverse {
+		rate.Inv(rate)
+	}
+	converted, err = m.Convert(c.to, rate)
+	if err != nil {
+		return entity.Money{}, nil, false, err
+	}
+	return converted, rate, true, nil
+}
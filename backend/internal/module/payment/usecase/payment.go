@@ -13,23 +13,39 @@ import (
 	redissvc "github.com/durianpay/fullstack-boilerplate/internal/service/redis"
 )
 
-const cacheTTL = 5 * time.Minute
+const (
+	cacheTTL     = 5 * time.Minute
+	defaultLimit = 50
+	maxLimit     = 100
+	// bumped whenever payments change; it is part of every listing's cache key
+	cacheGenerationKey = "payments:generation"
+)
 
 type PaymentUsecase interface {
-	ListPayments(filters map[string]interface{}, sortBy string) ([]*entity.Payment, error)
+	ListPayments(filters map[string]interface{}, sortBy string, limit int, cursor string, reportCurrency string) (*entity.PaymentPage, error)
+	GetPayment(id string) (*entity.Payment, error)
+	SummarizePayments(reportCurrency, timezone, from, to string) (*entity.PaymentSummary, error)
+	CreatePayment(caller *entity.Principal, idempotencyKey string, in entity.NewPayment) (*entity.Payment, bool, error)
+	UpdatePaymentStatus(caller *entity.Principal, id string, version int64, status entity.PaymentStatus, reason string) (*entity.Payment, error)
+	ListPaymentEvents(id string) ([]*entity.PaymentEvent, error)
+	RequestRefund(caller *entity.Principal, paymentID, amount, reason string) (*entity.Refund, error)
+	ApproveRefund(caller *entity.Principal, paymentID, refundID string) (*entity.Refund, error)
+	RejectRefund(caller *entity.Principal, paymentID, refundID, reason string) (*entity.Refund, error)
+	ListRefunds(paymentID string) ([]*entity.Refund, error)
 }
 
 type Payment struct {
-	repo  repository.PaymentRepository
-	redis *redissvc.Client
+	repo    repository.PaymentRepository
+	fxRates repository.FXRateRepository
+	redis   *redissvc.Client
 }
 
-func NewPaymentUsecase(repo repository.PaymentRepository, redis *redissvc.Client) PaymentUsecase {
-	return &Payment{repo: repo, redis: redis}
+func NewPaymentUsecase(repo repository.PaymentRepository, fxRates repository.FXRateRepository, redis *redissvc.Client) PaymentUsecase {
+	return &Payment{repo: repo, fxRates: fxRates, redis: redis}
 }
 
-// cacheKey produces a deterministic key from filters + sort.
-func cacheKey(filters map[string]interface{}, sortBy string) string {
+// cacheKey produces a deterministic key from the cache generation + filters + sort + page.
+func cacheKey(generation string, filters map[string]interface{}, sortBy string, limit int, cursor string) string {
 	// Sort filter keys for determinism
 	keys := make([]string, 0, len(filters))
 	for k := range filters {
@@ -38,38 +54,120 @@ func cacheKey(filters map[string]interface{}, sortBy string) string {
 	sort.Strings(keys)
 
 	var b strings.Builder
-	b.WriteString("payments:")
+	b.WriteString("payments:" + generation + ":")
 	for _, k := range keys {
 		fmt.Fprintf(&b, "%s=%v;", k, filters[k])
 	}
 	b.WriteString("sort=" + sortBy)
+	fmt.Fprintf(&b, ";limit=%d;cursor=%s", limit, cursor)
 	return b.String()
 }
 
-// ListPayments returns payments, checking Redis first.
-func (p *Payment) ListPayments(filters map[string]interface{}, sortBy string) ([]*entity.Payment, error) {
+// validateFilters rejects unknown currencies and ranges that cannot match anything.
+func validateFilters(filters map[string]interface{}) error {
+	from, hasFrom := filters["created_from"].(time.Time)
+	to, hasTo := filters["created_to"].(time.Time)
+	if hasFrom && hasTo && from.After(to) {
+		return entity.ErrorBadRequest("created_from must not be after created_to")
+	}
+
+	if currency, ok := filters["currency"].(string); ok {
+		if _, ok := entity.CurrencyExponent(currency); !ok {
+			return entity.ErrorBadRequest("currency is not a supported currency")
+		}
+	}
+
+	lo, hasMin := filters["amount_min"].(int64)
+	hi, hasMax := filters["amount_max"].(int64)
+	if hasMin && hasMax && lo > hi {
+		return entity.ErrorBadRequest("amount_min must not be greater than amount_max")
+	}
+	return nil
+}
+
+// ListPayments returns one page of payments, checking Redis first.
+// limit is clamped to 1..100; an empty cursor asks for the first page.
+// With a reportCurrency every payment also gets its amount in that currency.
+func (p *Payment) ListPayments(filters map[string]interface{}, sortBy string, limit int, cursor string, reportCurrency string) (*entity.PaymentPage, error) {
+	if err := validateFilters(filters); err != nil {
+		return nil, err
+	}
+	if reportCurrency != "" {
+		if _, ok := entity.CurrencyExponent(reportCurrency); !ok {
+			return nil, entity.ErrorBadRequest("report_currency is not a supported currency")
+		}
+	}
+	if limit < 1 {
+		limit = defaultLimit
+	}
+	if limit > maxLimit {
+		limit = maxLimit
+	}
+
+	page, err := p.listPage(filters, sortBy, limit, cursor)
+	if err != nil {
+		return nil, err
+	}
+	if reportCurrency != "" {
+		if err := p.convert(page, reportCurrency); err != nil {
+			return nil, err
+		}
+	}
+	return page, nil
+}
+
+func (p *Payment) listPage(filters map[string]interface{}, sortBy string, limit int, cursor string) (*entity.PaymentPage, error) {
 	ctx := context.Background()
-	key := cacheKey(filters, sortBy)
+	// a missing generation reads as "" until the first payment is written
+	generation, _ := p.redis.Get(ctx, cacheGenerationKey)
+	key := cacheKey(generation, filters, sortBy, limit, cursor)
 
 	// Try cache
 	cached, err := p.redis.Get(ctx, key)
 	if err == nil && cached != "" {
-		var payments []*entity.Payment
-		if json.Unmarshal([]byte(cached), &payments) == nil {
-			return payments, nil
+		var page entity.PaymentPage
+		if json.Unmarshal([]byte(cached), &page) == nil {
+			return &page, nil
 		}
 	}
 
 	// If cache miss — hit DB
-	payments, err := p.repo.ListPayments(filters, sortBy)
+	page, err := p.repo.ListPayments(filters, sortBy, limit, cursor)
 	if err != nil {
 		return nil, err
 	}
 
 	// Store in cache
-	if data, marshalErr := json.Marshal(payments); marshalErr == nil {
+	if data, marshalErr := json.Marshal(page); marshalErr == nil {
 		_ = p.redis.Set(ctx, key, string(data), cacheTTL)
 	}
 
-	return payments, nil
+	return page, nil
+}
+
+// GetPayment returns one payment straight from the database.
+func (p *Payment) GetPayment(id string) (*entity.Payment, error) {
+	return p.repo.GetPaymentByID(id)
+}
+
+// convert fills in the report amounts of a page. Payments with no rate in force
+// are left without one rather than failing the whole page.
+func (p *Payment) convert(page *entity.PaymentPage, currency string) error {
+	rates, err := p.fxRates.ListFXRates(currency)
+	if err != nil {
+		return err
+	}
+	c := &converter{to: currency, rates: rates}
+	for _, payment := range page.Payments {
+		amount, rate, ok, err := c.convert(payment.Amount, payment.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+		payment.ReportAmount = &amount
+		payment.FXRate = entity.FormatRate(rate)
+	}
+	return nil
 }
@@ -0,0 +1,62 @@
+package usecase
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/module/payment/repository"
+	"github.com/durianpay/fullstack-boilerplate/internal/seeder"
+	passwordsvc "github.com/durianpay/fullstack-boilerplate/internal/service/password"
+	redissvc "github.com/durianpay/fullstack-boilerplate/internal/service/redis"
+	_ "github.com/mattn/go-sqlite3"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type testPayment struct {
+	*Payment
+	db *sql.DB
+}
+
+// newTestPayment returns a Payment on a freshly seeded database and an in-memory Redis.
+func newTestPayment(t *testing.T) *testPayment {
+	t.Helper()
+	db, err := sql.Open("sqlite3", filepath.Join(t.TempDir(), "test.db")+"?_foreign_keys=1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	passwords, err := passwordsvc.NewHasher(passwordsvc.AlgBcrypt, bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := seeder.Seed(db, passwords); err != nil {
+		t.Fatalf("Seed: %v", err)
+	}
+	mr := miniredis.RunT(t)
+
+	p := NewPaymentUsecase(repository.NewPaymentRepo(db), repository.NewFXRateRepo(db), redissvc.NewClient(mr.Addr())).(*Payment)
+	return &testPayment{Payment: p, db: db}
+}
+
+// principal returns the caller a handler would see for a session of email.
+func (p *testPayment) principal(t *testing.T, email string, sessionID string) *entity.Principal {
+	t.Helper()
+	var id, role string
+	if err := p.db.QueryRow("SELECT id, role FROM users WHERE email = ?", email).Scan(&id, &role); err != nil {
+		t.Fatalf("user %s: %v", email, err)
+	}
+	return &entity.Principal{UserID: id, Email: email, Role: role, SessionID: sessionID}
+}
+
+// wantCode fails unless err is an AppError with the given code.
+func wantCode(t *testing.T, err error, code entity.Code) {
+	t.Helper()
+	appErr, ok := err.(*entity.AppError)
+	if !ok || appErr.Code != code {
+		t.Fatalf("err = %v, want %s", err, code)
+	}
+}
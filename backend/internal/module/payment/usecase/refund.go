@@ -0,0 +1,147 @@
+package usecase
+
+import (
+	"log"
+	"strings"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// refundable returns how much of payment is left to refund: its amount less the
+// refunds that are requested or approved.
+func refundable(payment *entity.Payment, refunds []*entity.Refund) entity.Money {
+	left := payment.Amount
+	for _, rf := range refunds {
+		if rf.Status.Reserves() {
+			left.Amount -= rf.Amount.Amount
+		}
+	}
+	return left
+}
+
+// RequestRefund asks to give back amount of a completed payment, or all that
+// is left to refund when amount is empty. The refund waits for approval.
+func (p *Payment) RequestRefund(caller *entity.Principal, paymentID, amount, reason string) (*entity.Refund, error) {
+	reason, err := validateReason(reason)
+	if err != nil {
+		return nil, err
+	}
+
+	payment, err := p.repo.GetPaymentByID(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Status != entity.PaymentStatusCompleted {
+		return nil, entity.ErrorConflict("only completed payments can be refunded; this one is " + string(payment.Status))
+	}
+	refunds, err := p.repo.ListRefunds(paymentID)
+	if err != nil {
+		return nil, err
+	}
+	left := refundable(payment, refunds)
+	if left.Amount <= 0 {
+		return nil, entity.ErrorConflict("payment has nothing left to refund")
+	}
+
+	value := left
+	if amount = strings.TrimSpace(amount); amount != "" {
+		if value, err = entity.ParseMoney(amount, payment.Amount.Currency); err != nil {
+			return nil, err
+		}
+		if value.Amount <= 0 {
+			return nil, entity.ErrorBadRequest("amount must be greater than zero")
+		}
+		if value.Amount > left.Amount {
+			return nil, entity.ErrorConflict("refund of " + value.String() + " exceeds the " + left.String() + " " + left.Currency + " left to refund")
+		}
+	}
+
+	refund := &entity.Refund{
+		PaymentID: paymentID,
+		Amount:    value,
+		Status:    entity.RefundStatusRequested,
+		Reason:    reason,
+		CreatedAt: time.Now(),
+	}
+	if refund.ID, err = newID("rf_"); err != nil {
+		return nil, entity.ErrorInternal("failed to generate refund id")
+	}
+	refund.RequestedBy, refund.RequestedByEmail = actingUser(caller)
+
+	event := newPaymentEvent(caller, paymentID, entity.PaymentEventRefundRequested, refund.CreatedAt)
+	event.RefundID = refund.ID
+	event.NewValue = string(refund.Status)
+	event.Reason = reason
+
+	if err := p.repo.CreateRefund(refund, event); err != nil {
+		return nil, err
+	}
+
+	log.Printf("payment: %s requested refund %s of %s %s on %s", caller.Email, refund.ID, refund.Amount, refund.Amount.Currency, paymentID)
+	return refund, nil
+}
+
+// ApproveRefund approves a requested refund, which then counts as refunded. The
+// user who requested a refund cannot approve it.
+func (p *Payment) ApproveRefund(caller *entity.Principal, paymentID, refundID string) (*entity.Refund, error) {
+	return p.decideRefund(caller, paymentID, refundID, entity.RefundStatusApproved, "")
+}
+
+// RejectRefund rejects a requested refund, which frees its amount again.
+func (p *Payment) RejectRefund(caller *entity.Principal, paymentID, refundID, reason string) (*entity.Refund, error) {
+	reason, err := validateReason(reason)
+	if err != nil {
+		return nil, err
+	}
+	return p.decideRefund(caller, paymentID, refundID, entity.RefundStatusRejected, reason)
+}
+
+func (p *Payment) decideRefund(caller *entity.Principal, paymentID, refundID string, status entity.RefundStatus, reason string) (*entity.Refund, error) {
+	refund, err := p.repo.GetRefund(paymentID, refundID)
+	if err != nil {
+		return nil, err
+	}
+	if !refund.Status.CanTransitionTo(status) {
+		return nil, entity.ErrorConflict("refund is already " + string(refund.Status))
+	}
+	userID, email := actingUser(caller)
+	if status == entity.RefundStatusApproved && refund.RequestedBy == userID {
+		return nil, entity.ErrorForbidden("a refund must be approved by someone other than who requested it")
+	}
+
+	now := time.Now()
+	from := refund.Status
+	refund.Status = status
+	refund.DecidedBy, refund.DecidedByEmail = userID, email
+	refund.DecidedAt = &now
+
+	eventType := entity.PaymentEventRefundApproved
+	if status == entity.RefundStatusRejected {
+		eventType = entity.PaymentEventRefundRejected
+	}
+	event := newPaymentEvent(caller, paymentID, eventType, now)
+	event.RefundID = refund.ID
+	event.OldValue = string(from)
+	event.NewValue = string(status)
+	event.Reason = reason
+
+	if err := p.repo.DecideRefund(refund, event); err != nil {
+		return nil, err
+	}
+
+	log.Printf("payment: %s %s refund %s on %s", caller.Email, status, refund.ID, paymentID)
+	if status == entity.RefundStatusApproved {
+		// approved refunds change refunded_amount and net_amount in the listings
+		p.invalidateListCache()
+	}
+	return refund, nil
+}
+
+// ListRefunds returns the refunds of a payment, oldest first.
+func (p *Payment) ListRefunds(paymentID string) ([]*entity.Refund, error) {
+	if _, err := p.repo.GetPaymentByID(paymentID); err != nil {
+		return nil, err
+	}
+	return p.repo.ListRefunds(paymentID)
+}
@@ -0,0 +1,196 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// completedPayment records a payment of amount IDR and completes it.
+func (p *testPayment) completedPayment(t *testing.T, caller *entity.Principal, amount string) *entity.Payment {
+	t.Helper()
+	payment := p.newPayment(t, caller, amount)
+	payment, err := p.UpdatePaymentStatus(caller, payment.ID, payment.Version, entity.PaymentStatusCompleted, "settled")
+	if err != nil {
+		t.Fatalf("UpdatePaymentStatus: %v", err)
+	}
+	return payment
+}
+
+// impersonating returns the caller a handler would see for a superuser's
+// impersonation token of user.
+func (p *testPayment) impersonating(t *testing.T, superuser string, user string) *entity.Principal {
+	t.Helper()
+	actor := p.principal(t, superuser, "superuser-session")
+	caller := p.principal(t, user, actor.SessionID)
+	caller.ActorID, caller.ActorEmail = actor.UserID, actor.Email
+	return caller
+}
+
+func TestRequestRefundAmount(t *testing.T) {
+	tests := []struct {
+		name   string
+		amount string
+		want   int64       // refund amount in minor units when it is accepted
+		code   entity.Code // empty when the refund is accepted
+	}{
+		{"all that is left", "", 3000000, ""},
+		{"part of what is left", "100", 10000, ""},
+		{"exactly what is left", "30000.00", 3000000, ""},
+		{"over what is left", "30000.01", 0, entity.ErrorCodeConflict},
+		{"over the payment amount", "60000", 0, entity.ErrorCodeConflict},
+		{"zero", "0", 0, entity.ErrorCodeBadRequest},
+		{"negative", "-100", 0, entity.ErrorCodeBadRequest},
+		{"more decimals than the currency", "100.001", 0, entity.ErrorCodeBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPayment(t)
+			cs := p.principal(t, "cs@test.com", "session-1")
+			payment := p.completedPayment(t, p.principal(t, "operation@test.com", "session-2"), "50000")
+			// a pending refund already holds part of the payment
+			if _, err := p.RequestRefund(cs, payment.ID, "20000", "duplicate charge"); err != nil {
+				t.Fatal(err)
+			}
+
+			refund, err := p.RequestRefund(cs, payment.ID, tt.amount, "customer request")
+			if tt.code != "" {
+				wantCode(t, err, tt.code)
+				return
+			}
+			if err != nil {
+				t.Fatalf("RequestRefund: %v", err)
+			}
+			if refund.Amount.Amount != tt.want || refund.Status != entity.RefundStatusRequested {
+				t.Errorf("refund of %d %s, want %d requested", refund.Amount.Amount, refund.Status, tt.want)
+			}
+		})
+	}
+}
+
+func TestRefundableAmountFollowsDecisions(t *testing.T) {
+	p := newTestPayment(t)
+	cs := p.principal(t, "cs@test.com", "session-1")
+	operation := p.principal(t, "operation@test.com", "session-2")
+	payment := p.completedPayment(t, operation, "50000")
+
+	approved, err := p.RequestRefund(cs, payment.ID, "20000", "damaged")
+	if err != nil {
+		t.Fatal(err)
+	}
+	rejected, err := p.RequestRefund(cs, payment.ID, "30000", "duplicate")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = p.RequestRefund(cs, payment.ID, "", "anything left")
+	wantCode(t, err, entity.ErrorCodeConflict)
+
+	if _, err := p.ApproveRefund(operation, payment.ID, approved.ID); err != nil {
+		t.Fatalf("ApproveRefund: %v", err)
+	}
+	if _, err := p.RejectRefund(operation, payment.ID, rejected.ID, "not a duplicate"); err != nil {
+		t.Fatalf("RejectRefund: %v", err)
+	}
+	// the rejected refund frees its amount, the approved one keeps it
+	_, err = p.RequestRefund(cs, payment.ID, "30000.01", "too much")
+	wantCode(t, err, entity.ErrorCodeConflict)
+	if _, err := p.RequestRefund(cs, payment.ID, "30000", "the rest"); err != nil {
+		t.Fatalf("RequestRefund of the rest: %v", err)
+	}
+
+	stored, err := p.GetPayment(payment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stored.Refunded != 2000000 {
+		t.Errorf("refunded = %d, want only the approved 2000000", stored.Refunded)
+	}
+}
+
+func TestOnlyCompletedPaymentsAreRefunded(t *testing.T) {
+	p := newTestPayment(t)
+	operation := p.principal(t, "operation@test.com", "session-1")
+	processing := p.newPayment(t, operation, "50000")
+	failed, err := p.UpdatePaymentStatus(operation, p.newPayment(t, operation, "50000").ID, 1, entity.PaymentStatusFailed, "declined")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, payment := range []*entity.Payment{processing, failed} {
+		_, err := p.RequestRefund(operation, payment.ID, "", "customer request")
+		wantCode(t, err, entity.ErrorCodeConflict)
+	}
+}
+
+func TestRefundApproverIsNotTheRequester(t *testing.T) {
+	tests := []struct {
+		name      string
+		requester func(p *testPayment, t *testing.T) *entity.Principal
+		approver  func(p *testPayment, t *testing.T) *entity.Principal
+		code      entity.Code // empty when the approval goes through
+	}{
+		{"another user",
+			func(p *testPayment, t *testing.T) *entity.Principal { return p.principal(t, "cs@test.com", "s1") },
+			func(p *testPayment, t *testing.T) *entity.Principal {
+				return p.principal(t, "operation@test.com", "s2")
+			},
+			""},
+		{"the requester",
+			func(p *testPayment, t *testing.T) *entity.Principal {
+				return p.principal(t, "operation@test.com", "s1")
+			},
+			func(p *testPayment, t *testing.T) *entity.Principal {
+				return p.principal(t, "operation@test.com", "s1")
+			},
+			entity.ErrorCodeForbidden},
+		{"the requester from another session",
+			func(p *testPayment, t *testing.T) *entity.Principal {
+				return p.principal(t, "operation@test.com", "s1")
+			},
+			func(p *testPayment, t *testing.T) *entity.Principal {
+				return p.principal(t, "operation@test.com", "s2")
+			},
+			entity.ErrorCodeForbidden},
+		{"a superuser impersonating the requester",
+			func(p *testPayment, t *testing.T) *entity.Principal {
+				return p.principal(t, "operation@test.com", "s1")
+			},
+			func(p *testPayment, t *testing.T) *entity.Principal {
+				return p.impersonating(t, "superuser@test.com", "operation@test.com")
+			},
+			""},
+		{"a superuser who requested while impersonating",
+			func(p *testPayment, t *testing.T) *entity.Principal {
+				return p.impersonating(t, "superuser@test.com", "cs@test.com")
+			},
+			func(p *testPayment, t *testing.T) *entity.Principal {
+				return p.principal(t, "superuser@test.com", "s2")
+			},
+			entity.ErrorCodeForbidden},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPayment(t)
+			payment := p.completedPayment(t, p.principal(t, "operation@test.com", "setup"), "50000")
+			refund, err := p.RequestRefund(tt.requester(p, t), payment.ID, "10000", "customer request")
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			approved, err := p.ApproveRefund(tt.approver(p, t), payment.ID, refund.ID)
+			if tt.code != "" {
+				wantCode(t, err, tt.code)
+				return
+			}
+			if err != nil {
+				t.Fatalf("ApproveRefund: %v", err)
+			}
+			if approved.Status != entity.RefundStatusApproved {
+				t.Errorf("refund is %s, want approved", approved.Status)
+			}
+			// a refund is decided once
+			_, err = p.RejectRefund(tt.approver(p, t), payment.ID, refund.ID, "changed my mind")
+			wantCode(t, err, entity.ErrorCodeConflict)
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package usecase
+
+import (
+	"log"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+const maxReasonLength = 500
+
+// validateReason trims the reason given for a change, which must not be empty.
+func validateReason(reason string) (string, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return "", entity.ErrorBadRequest("reason is required")
+	}
+	if utf8.RuneCountInString(reason) > maxReasonLength {
+		return "", entity.ErrorBadRequest("reason is too long")
+	}
+	return reason, nil
+}
+
+// UpdatePaymentStatus moves payment id to status if the move is allowed and the
+// payment is still at version, the version the caller based the decision on.
+func (p *Payment) UpdatePaymentStatus(caller *entity.Principal, id string, version int64, status entity.PaymentStatus, reason string) (*entity.Payment, error) {
+	reason, err := validateReason(reason)
+	if err != nil {
+		return nil, err
+	}
+	if !status.Valid() {
+		return nil, entity.ErrorBadRequest("unknown status " + string(status))
+	}
+
+	payment, err := p.repo.GetPaymentByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if payment.Version != version {
+		return nil, entity.ErrorPreconditionFailed("payment was changed by someone else; fetch it again")
+	}
+	if payment.Status == status {
+		return nil, entity.ErrorConflict("payment is already " + string(status))
+	}
+	if !payment.Status.CanTransitionTo(status) {
+		if payment.Status.Final() {
+			return nil, entity.ErrorConflict("payment is " + string(payment.Status) + ", which is final")
+		}
+		return nil, entity.ErrorConflict("a " + string(payment.Status) + " payment cannot become " + string(status))
+	}
+
+	change := entity.PaymentStatusChange{
+		PaymentID: id,
+		From:      payment.Status,
+		To:        status,
+		Version:   version,
+		Reason:    reason,
+	}
+	event := newPaymentEvent(caller, id, entity.PaymentEventStatusChanged, time.Now())
+	event.OldValue = string(change.From)
+	event.NewValue = string(change.To)
+	event.Reason = reason
+
+	if err := p.repo.UpdatePaymentStatus(change, event); err != nil {
+		return nil, err
+	}
+
+	log.Printf("payment: %s moved %s from %s to %s: %s", caller.Email, id, change.From, change.To, reason)
+	p.invalidateListCache()
+
+	payment.Status = status
+	payment.Version++
+	return payment, nil
+}
@@ -0,0 +1,109 @@
+package usecase
+
+import (
+	"testing"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// newPayment records a processing payment of amount IDR.
+func (p *testPayment) newPayment(t *testing.T, caller *entity.Principal, amount string) *entity.Payment {
+	t.Helper()
+	payment, _, err := p.CreatePayment(caller, "", entity.NewPayment{Merchant: "Test Merchant", Amount: amount, Currency: "IDR"})
+	if err != nil {
+		t.Fatalf("CreatePayment: %v", err)
+	}
+	return payment
+}
+
+func TestUpdatePaymentStatusTransitions(t *testing.T) {
+	tests := []struct {
+		name   string
+		from   entity.PaymentStatus
+		to     entity.PaymentStatus
+		reason string
+		want   entity.Code // empty when the change is applied
+	}{
+		{"processing to completed", entity.PaymentStatusProcessing, entity.PaymentStatusCompleted, "settled", ""},
+		{"processing to failed", entity.PaymentStatusProcessing, entity.PaymentStatusFailed, "declined", ""},
+		{"to the same status", entity.PaymentStatusProcessing, entity.PaymentStatusProcessing, "again", entity.ErrorCodeConflict},
+		{"completed to failed", entity.PaymentStatusCompleted, entity.PaymentStatusFailed, "chargeback", entity.ErrorCodeConflict},
+		{"completed back to processing", entity.PaymentStatusCompleted, entity.PaymentStatusProcessing, "retry", entity.ErrorCodeConflict},
+		{"failed to completed", entity.PaymentStatusFailed, entity.PaymentStatusCompleted, "late", entity.ErrorCodeConflict},
+		{"unknown status", entity.PaymentStatusProcessing, "refunded", "refund", entity.ErrorCodeBadRequest},
+		{"without a reason", entity.PaymentStatusProcessing, entity.PaymentStatusCompleted, "  ", entity.ErrorCodeBadRequest},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := newTestPayment(t)
+			caller := p.principal(t, "operation@test.com", "session-1")
+			payment := p.newPayment(t, caller, "50000")
+			if tt.from != entity.PaymentStatusProcessing {
+				var err error
+				if payment, err = p.UpdatePaymentStatus(caller, payment.ID, payment.Version, tt.from, "setup"); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			updated, err := p.UpdatePaymentStatus(caller, payment.ID, payment.Version, tt.to, tt.reason)
+			if tt.want != "" {
+				wantCode(t, err, tt.want)
+				stored, err := p.GetPayment(payment.ID)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if stored.Status != tt.from || stored.Version != payment.Version {
+					t.Errorf("payment is %s v%d after a refused change, want %s v%d", stored.Status, stored.Version, tt.from, payment.Version)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("UpdatePaymentStatus: %v", err)
+			}
+			if updated.Status != tt.to || updated.Version != payment.Version+1 {
+				t.Errorf("got %s v%d, want %s v%d", updated.Status, updated.Version, tt.to, payment.Version+1)
+			}
+		})
+	}
+}
+
+func TestUpdatePaymentStatusNeedsTheCurrentVersion(t *testing.T) {
+	p := newTestPayment(t)
+	caller := p.principal(t, "operation@test.com", "session-1")
+	payment := p.newPayment(t, caller, "50000")
+
+	tests := []struct {
+		name    string
+		version int64
+	}{
+		{"older version", payment.Version - 1},
+		{"newer version", payment.Version + 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := p.UpdatePaymentStatus(caller, payment.ID, tt.version, entity.PaymentStatusCompleted, "settled")
+			wantCode(t, err, entity.ErrorCodePreconditionFailed)
+		})
+	}
+
+	// a change read at the same version as another one that landed first
+	if _, err := p.UpdatePaymentStatus(caller, payment.ID, payment.Version, entity.PaymentStatusCompleted, "settled"); err != nil {
+		t.Fatal(err)
+	}
+	err := p.repo.UpdatePaymentStatus(entity.PaymentStatusChange{
+		PaymentID: payment.ID,
+		From:      entity.PaymentStatusProcessing,
+		To:        entity.PaymentStatusFailed,
+		Version:   payment.Version,
+		Reason:    "declined",
+	}, newPaymentEvent(caller, payment.ID, entity.PaymentEventStatusChanged, payment.CreatedAt))
+	wantCode(t, err, entity.ErrorCodePreconditionFailed)
+
+	events, err := p.ListPaymentEvents(payment.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 2 {
+		t.Errorf("got %d events, want the creation and one status change", len(events))
+	}
+}
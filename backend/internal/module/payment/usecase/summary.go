@@ -0,0 +1,175 @@
+package usecase
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+const (
+	// defaultSummaryDays is the range summarized when the caller gives no from date.
+	defaultSummaryDays = 30
+	// maxSummaryDays bounds how many payments one summary reads.
+	maxSummaryDays = 366
+)
+
+// SummarizePayments counts the payments created on the days from through to
+// (YYYY-MM-DD in timezone, UTC when empty) by status and adds up their amounts
+// per day. to defaults to today and from to the 30 days ending on to. Each
+// amount is converted into reportCurrency as report_amount is, so the totals
+// match the listed payments; payments with no rate in force are reported in
+// their own currency instead. Summaries are cached like listings.
+func (p *Payment) SummarizePayments(reportCurrency, timezone, from, to string) (*entity.PaymentSummary, error) {
+	if _, ok := entity.CurrencyExponent(reportCurrency); !ok {
+		return nil, entity.ErrorBadRequest("report_currency is not a supported currency")
+	}
+	loc := time.UTC
+	if timezone != "" {
+		var err error
+		if loc, err = time.LoadLocation(timezone); err != nil || timezone == "Local" {
+			return nil, entity.ErrorBadRequest("tz must be an IANA time zone name, e.g. Asia/Jakarta")
+		}
+	}
+	start, end, err := summaryRange(from, to, loc)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx := context.Background()
+	generation, _ := p.redis.Get(ctx, cacheGenerationKey)
+	key := fmt.Sprintf("payments:%s:summary:%s:%s:%s:%s",
+		generation, reportCurrency, loc, start.Format(time.DateOnly), end.Format(time.DateOnly))
+	if cached, err := p.redis.Get(ctx, key); err == nil && cached != "" {
+		var summary entity.PaymentSummary
+		if json.Unmarshal([]byte(cached), &summary) == nil {
+			return &summary, nil
+		}
+	}
+
+	summary, err := p.summarize(reportCurrency, loc, start, end)
+	if err != nil {
+		return nil, err
+	}
+	if data, marshalErr := json.Marshal(summary); marshalErr == nil {
+		_ = p.redis.Set(ctx, key, string(data), cacheTTL)
+	}
+	return summary, nil
+}
+
+// summaryRange parses the from and to dates into the start of from and the start
+// of the day after to in loc.
+func summaryRange(from, to string, loc *time.Location) (time.Time, time.Time, error) {
+	var end time.Time
+	if to == "" {
+		now := time.Now().In(loc)
+		end = time.Date(now.Year(), now.Month(), now.Day()+1, 0, 0, 0, 0, loc)
+	} else {
+		day, err := time.ParseInLocation(time.DateOnly, to, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, entity.ErrorBadRequest("to must be a date, e.g. 2026-10-14")
+		}
+		end = day.AddDate(0, 0, 1)
+	}
+
+	var start time.Time
+	if from == "" {
+		start = end.AddDate(0, 0, -defaultSummaryDays)
+	} else {
+		day, err := time.ParseInLocation(time.DateOnly, from, loc)
+		if err != nil {
+			return time.Time{}, time.Time{}, entity.ErrorBadRequest("from must be a date, e.g. 2026-10-01")
+		}
+		start = day
+	}
+
+	if !start.Before(end) {
+		return time.Time{}, time.Time{}, entity.ErrorBadRequest("from must not be after to")
+	}
+	if start.AddDate(0, 0, maxSummaryDays).Before(end) {
+		return time.Time{}, time.Time{}, entity.ErrorBadRequest(fmt.Sprintf("a summary covers at most %d days", maxSummaryDays))
+	}
+	return start, end, nil
+}
+
+func (p *Payment) summarize(reportCurrency string, loc *time.Location, start, end time.Time) (*entity.PaymentSummary, error) {
+	rates, err := p.fxRates.ListFXRates(reportCurrency)
+	if err != nil {
+		return nil, err
+	}
+	c := &converter{to: reportCurrency, rates: rates}
+
+	summary := &entity.PaymentSummary{
+		Currency:    reportCurrency,
+		From:        start.Format(time.DateOnly),
+		To:          end.AddDate(0, 0, -1).Format(time.DateOnly),
+		Days:        []*entity.PaymentDayTotals{},
+		Unconverted: []*entity.UnconvertedTotal{},
+	}
+	unconverted := map[string]*entity.UnconvertedTotal{}
+	var day *entity.PaymentDayTotals
+	err = p.repo.EachPayment(start, end, func(payment *entity.Payment) error {
+		var count *int
+		switch payment.Status {
+		case entity.PaymentStatusCompleted:
+			count = &summary.Completed
+		case entity.PaymentStatusProcessing:
+			count = &summary.Processing
+		case entity.PaymentStatusFailed:
+			count = &summary.Failed
+		}
+		summary.Total++
+		if count != nil {
+			*count++
+		}
+
+		amount, _, ok, err := c.convert(payment.Amount, payment.CreatedAt)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			u := unconverted[payment.Amount.Currency]
+			if u == nil {
+				u = &entity.UnconvertedTotal{Amount: entity.Money{Currency: payment.Amount.Currency}}
+				unconverted[payment.Amount.Currency] = u
+				summary.Unconverted = append(summary.Unconverted, u)
+			}
+			u.Count++
+			u.Amount, err = u.Amount.Add(payment.Amount)
+			return err
+		}
+
+		// payments come oldest first, so each day's payments are consecutive
+		date := payment.CreatedAt.In(loc).Format(time.DateOnly)
+		if day == nil || day.Date != date {
+			zero := entity.Money{Currency: reportCurrency}
+			day = &entity.PaymentDayTotals{Date: date, Total: zero, Completed: zero, Processing: zero, Failed: zero}
+			summary.Days = append(summary.Days, day)
+		}
+
+		var sum *entity.Money
+		switch payment.Status {
+		case entity.PaymentStatusCompleted:
+			sum = &day.Completed
+		case entity.PaymentStatusProcessing:
+			sum = &day.Processing
+		case entity.PaymentStatusFailed:
+			sum = &day.Failed
+		}
+		if day.Total, err = day.Total.Add(amount); err != nil {
+			return err
+		}
+		if sum != nil {
+			if *sum, err = sum.Add(amount); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return summary, nil
+}
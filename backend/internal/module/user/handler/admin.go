@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/principal"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+)
+
+// GetDashboardV1Users lists dashboard users page by page
+func (h *UserHandler) GetDashboardV1Users(w http.ResponseWriter, r *http.Request, params openapigen.GetDashboardV1UsersParams) {
+	page, pageSize := 0, 0
+	if params.Page != nil {
+		page = *params.Page
+	}
+	if params.PageSize != nil {
+		pageSize = *params.PageSize
+	}
+
+	result, err := h.userUC.ListUsers(page, pageSize)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	userList := []openapigen.UserProfile{}
+	for _, u := range result.Users {
+		userList = append(userList, toUserProfile(u))
+	}
+
+	response := openapigen.UserListResponse{
+		Users:    &userList,
+		Page:     &result.Page,
+		PageSize: &result.PageSize,
+		Total:    &result.Total,
+	}
+
+	transport.WriteJSON(w, http.StatusOK, response)
+}
+
+func (h *UserHandler) PostDashboardV1Users(w http.ResponseWriter, r *http.Request) {
+	var req openapigen.PostDashboardV1UsersJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	displayName := ""
+	if req.DisplayName != nil {
+		displayName = *req.DisplayName
+	}
+
+	user, err := h.userUC.CreateUser(req.Email, req.Password, string(req.Role), displayName)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusCreated, toUserProfile(user))
+}
+
+func (h *UserHandler) PatchDashboardV1UsersIdRole(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.PatchDashboardV1UsersIdRoleJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	user, err := h.userUC.ChangeRole(caller, id, string(req.Role))
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toUserProfile(user))
+}
+
+func (h *UserHandler) PostDashboardV1UsersIdDeactivate(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	h.setActive(w, r, id, false)
+}
+
+func (h *UserHandler) PostDashboardV1UsersIdReactivate(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	h.setActive(w, r, id, true)
+}
+
+func (h *UserHandler) setActive(w http.ResponseWriter, r *http.Request, id string, active bool) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	user, err := h.userUC.SetActive(caller, id, active)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toUserProfile(user))
+}
+
+func (h *UserHandler) DeleteDashboardV1UsersId(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	if err := h.userUC.DeleteUser(caller, id); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func (h *UserHandler) PostDashboardV1UsersIdUnlock(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	if err := h.userUC.UnlockUser(id); err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
+
+func toRolePolicy(p *entity.RolePolicy) openapigen.RolePolicy {
+	return openapigen.RolePolicy{
+		Role:        openapigen.Role(p.Role),
+		MfaRequired: p.MFARequired,
+	}
+}
+
+func (h *UserHandler) GetDashboardV1RolePolicies(w http.ResponseWriter, r *http.Request) {
+	policies, err := h.userUC.ListRolePolicies()
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	policyList := make([]openapigen.RolePolicy, 0, len(policies))
+	for _, p := range policies {
+		policyList = append(policyList, toRolePolicy(p))
+	}
+
+	response := openapigen.RolePolicyListResponse{
+		Policies: &policyList,
+	}
+
+	transport.WriteJSON(w, http.StatusOK, response)
+}
+
+func (h *UserHandler) PutDashboardV1RolePoliciesRole(w http.ResponseWriter, r *http.Request, role openapigen.Role) {
+	var req openapigen.PutDashboardV1RolePoliciesRoleJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	policy, err := h.userUC.SetRolePolicy(string(role), req.MfaRequired)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toRolePolicy(policy))
+}
+
+func (h *UserHandler) PostDashboardV1UsersIdImpersonate(w http.ResponseWriter, r *http.Request, id openapigen.UserId) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.PostDashboardV1UsersIdImpersonateJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+	client := entity.ClientInfo{
+		UserAgent: r.UserAgent(),
+		IP:        transport.ClientIP(r),
+	}
+
+	result, err := h.userUC.ImpersonateUser(caller, id, req.Reason, client)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, openapigen.ImpersonationResponse{
+		Token:     result.AccessToken,
+		ExpiresAt: result.ExpiresAt,
+		User:      toUserProfile(result.User),
+	})
+}
@@ -0,0 +1,75 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/module/user/usecase"
+	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/principal"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+)
+
+type UserHandler struct {
+	userUC usecase.UserUsecase
+}
+
+func NewUserHandler(userUC usecase.UserUsecase) *UserHandler {
+	return &UserHandler{
+		userUC: userUC,
+	}
+}
+
+func toUserProfile(u *entity.User) openapigen.UserProfile {
+	return openapigen.UserProfile{
+		Id:          &u.ID,
+		Email:       &u.Email,
+		Role:        &u.Role,
+		DisplayName: &u.DisplayName,
+		Timezone:    &u.Timezone,
+		Locale:      &u.Locale,
+		Active:      &u.Active,
+		MfaEnabled:  &u.MFAEnabled,
+	}
+}
+
+func (h *UserHandler) GetDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	user, err := h.userUC.GetProfile(caller)
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toUserProfile(user))
+}
+
+func (h *UserHandler) PatchDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request) {
+	caller, ok := principal.FromContext(r.Context())
+	if !ok {
+		transport.WriteAppError(w, entity.ErrorUnauthorized("missing or invalid token"))
+		return
+	}
+
+	var req openapigen.PatchDashboardV1UsersProfileJSONBody
+	if !transport.DecodeJSONBody(w, r, &req) {
+		return
+	}
+
+	user, err := h.userUC.UpdateProfile(caller, entity.ProfileUpdate{
+		DisplayName: req.DisplayName,
+		Timezone:    req.Timezone,
+		Locale:      req.Locale,
+	})
+	if err != nil {
+		transport.WriteError(w, err)
+		return
+	}
+
+	transport.WriteJSON(w, http.StatusOK, toUserProfile(user))
+}
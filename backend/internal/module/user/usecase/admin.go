@@ -0,0 +1,163 @@
+package usecase
+
+import (
+	"net/mail"
+	"strings"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+const (
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+// ListUsers returns one page of users; page is 1-based.
+func (u *User) ListUsers(page int, pageSize int) (*entity.UserPage, error) {
+	if page < 1 {
+		page = 1
+	}
+	if pageSize < 1 {
+		pageSize = defaultPageSize
+	}
+	if pageSize > maxPageSize {
+		pageSize = maxPageSize
+	}
+
+	users, total, err := u.repo.ListUsers((page-1)*pageSize, pageSize)
+	if err != nil {
+		return nil, err
+	}
+	return &entity.UserPage{Users: users, Page: page, PageSize: pageSize, Total: total}, nil
+}
+
+// CreateUser adds an active dashboard user with an initial password.
+func (u *User) CreateUser(email string, password string, role string, displayName string) (*entity.User, error) {
+	email = strings.ToLower(strings.TrimSpace(email))
+	if _, err := mail.ParseAddress(email); err != nil {
+		return nil, entity.ErrorBadRequest("email is invalid")
+	}
+	if !entity.ValidRole(role) {
+		return nil, entity.ErrorBadRequest("role must be one of cs, operation, superuser")
+	}
+
+	if err := u.policy.Check(password); err != nil {
+		return nil, entity.ErrorBadRequest(err.Error())
+	}
+
+	hash, err := u.passwords.Hash(password)
+	if err != nil {
+		return nil, entity.WrapError(err, entity.ErrorCodeInternal, "failed to hash password")
+	}
+
+	return u.repo.CreateUser(&entity.User{
+		Email:        email,
+		PasswordHash: hash,
+		Role:         role,
+		DisplayName:  strings.TrimSpace(displayName),
+		Active:       true,
+	})
+}
+
+// ChangeRole updates a user's role and signs them out so new tokens carry it.
+func (u *User) ChangeRole(caller *entity.Principal, id string, role string) (*entity.User, error) {
+	if !entity.ValidRole(role) {
+		return nil, entity.ErrorBadRequest("role must be one of cs, operation, superuser")
+	}
+	if id == caller.UserID {
+		return nil, entity.ErrorBadRequest("you cannot change your own role")
+	}
+
+	user, err := u.repo.UpdateRole(id, role)
+	if err != nil {
+		return nil, err
+	}
+	if err := u.sessions.RevokeAllSessions(id); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SetActive deactivates or reactivates a user. Deactivation revokes every session.
+func (u *User) SetActive(caller *entity.Principal, id string, active bool) (*entity.User, error) {
+	if id == caller.UserID && !active {
+		return nil, entity.ErrorBadRequest("you cannot deactivate your own account")
+	}
+
+	user, err := u.repo.SetActive(id, active)
+	if err != nil {
+		return nil, err
+	}
+	if !active {
+		if err := u.sessions.RevokeAllSessions(id); err != nil {
+			return nil, err
+		}
+	}
+	return user, nil
+}
+
+// DeleteUser removes a user after revoking their sessions.
+func (u *User) DeleteUser(caller *entity.Principal, id string) error {
+	if id == caller.UserID {
+		return entity.ErrorBadRequest("you cannot delete your own account")
+	}
+
+	if _, err := u.repo.GetUserByID(id); err != nil {
+		return err
+	}
+	if err := u.sessions.RevokeAllSessions(id); err != nil {
+		return err
+	}
+	return u.repo.DeleteUser(id)
+}
+
+// UnlockUser lifts a failed-login lockout so the user can sign in again right away.
+func (u *User) UnlockUser(id string) error {
+	user, err := u.repo.GetUserByID(id)
+	if err != nil {
+		return err
+	}
+	return u.logins.UnlockLogin(user.Email)
+}
+
+func (u *User) ListRolePolicies() ([]*entity.RolePolicy, error) {
+	return u.repo.ListRolePolicies()
+}
+
+// SetRolePolicy updates what a role enforces. Requiring 2FA takes effect at each user's next login.
+func (u *User) SetRolePolicy(role string, mfaRequired bool) (*entity.RolePolicy, error) {
+	if !entity.ValidRole(role) {
+		return nil, entity.ErrorBadRequest("role must be one of cs, operation, superuser")
+	}
+	policy := &entity.RolePolicy{Role: role, MFARequired: mfaRequired}
+	if err := u.repo.SetRolePolicy(policy); err != nil {
+		return nil, err
+	}
+	return policy, nil
+}
+
+// ImpersonateUser gives the caller a short-lived token acting as the user, so
+// support can see the dashboard as they do. Superusers cannot be impersonated,
+// which keeps the token from granting more than the caller already has.
+func (u *User) ImpersonateUser(caller *entity.Principal, id string, reason string, client entity.ClientInfo) (*entity.Impersonation, error) {
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, entity.ErrorBadRequest("reason is required")
+	}
+	if id == caller.UserID {
+		return nil, entity.ErrorBadRequest("you cannot impersonate yourself")
+	}
+
+	target, err := u.repo.GetUserByID(id)
+	if err != nil {
+		return nil, err
+	}
+	if target.Role == entity.RoleSuperuser {
+		return nil, entity.ErrorForbidden("superusers cannot be impersonated")
+	}
+	if !target.Active {
+		return nil, entity.ErrorBadRequest("user is deactivated")
+	}
+
+	return u.impersonator.Impersonate(caller, target, reason, client)
+}
@@ -0,0 +1,89 @@
+package usecase
+
+import (
+	"strings"
+	"time"
+	_ "time/tzdata" // timezone validation must not depend on the host's zoneinfo
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/durianpay/fullstack-boilerplate/internal/module/auth/repository"
+	passwordsvc "github.com/durianpay/fullstack-boilerplate/internal/service/password"
+	"golang.org/x/text/language"
+)
+
+const maxDisplayNameLength = 100
+
+type UserUsecase interface {
+	GetProfile(caller *entity.Principal) (*entity.User, error)
+	UpdateProfile(caller *entity.Principal, update entity.ProfileUpdate) (*entity.User, error)
+	ListUsers(page int, pageSize int) (*entity.UserPage, error)
+	CreateUser(email string, password string, role string, displayName string) (*entity.User, error)
+	ChangeRole(caller *entity.Principal, id string, role string) (*entity.User, error)
+	SetActive(caller *entity.Principal, id string, active bool) (*entity.User, error)
+	DeleteUser(caller *entity.Principal, id string) error
+	UnlockUser(id string) error
+	ImpersonateUser(caller *entity.Principal, id string, reason string, client entity.ClientInfo) (*entity.Impersonation, error)
+	ListRolePolicies() ([]*entity.RolePolicy, error)
+	SetRolePolicy(role string, mfaRequired bool) (*entity.RolePolicy, error)
+}
+
+// SessionRevoker signs a user out of every session.
+type SessionRevoker interface {
+	RevokeAllSessions(userID string) error
+}
+
+// LoginUnlocker clears the failed-login lockout of an account.
+type LoginUnlocker interface {
+	UnlockLogin(email string) error
+}
+
+// Impersonator issues access tokens that let a superuser act as another user.
+type Impersonator interface {
+	Impersonate(actor *entity.Principal, target *entity.User, reason string, client entity.ClientInfo) (*entity.Impersonation, error)
+}
+
+type User struct {
+	repo         repository.UserRepository
+	sessions     SessionRevoker
+	logins       LoginUnlocker
+	impersonator Impersonator
+	passwords    passwordsvc.Hasher
+	policy       *passwordsvc.Policy
+}
+
+func NewUserUsecase(repo repository.UserRepository, sessions SessionRevoker, logins LoginUnlocker, impersonator Impersonator, passwords passwordsvc.Hasher, policy *passwordsvc.Policy) *User {
+	return &User{repo: repo, sessions: sessions, logins: logins, impersonator: impersonator, passwords: passwords, policy: policy}
+}
+
+// GetProfile returns the caller's own user record.
+func (u *User) GetProfile(caller *entity.Principal) (*entity.User, error) {
+	return u.repo.GetUserByID(caller.UserID)
+}
+
+// UpdateProfile validates and saves the caller's profile preferences.
+func (u *User) UpdateProfile(caller *entity.Principal, update entity.ProfileUpdate) (*entity.User, error) {
+	if update.DisplayName != nil {
+		name := strings.TrimSpace(*update.DisplayName)
+		if len([]rune(name)) > maxDisplayNameLength {
+			return nil, entity.ErrorBadRequest("display_name must be at most 100 characters")
+		}
+		update.DisplayName = &name
+	}
+
+	if update.Timezone != nil {
+		if _, err := time.LoadLocation(*update.Timezone); err != nil || *update.Timezone == "" || *update.Timezone == "Local" {
+			return nil, entity.ErrorBadRequest("timezone must be an IANA time zone name, e.g. Asia/Jakarta")
+		}
+	}
+
+	if update.Locale != nil {
+		tag, err := language.Parse(*update.Locale)
+		if err != nil {
+			return nil, entity.ErrorBadRequest("locale must be a BCP 47 language tag, e.g. id-ID")
+		}
+		locale := tag.String()
+		update.Locale = &locale
+	}
+
+	return u.repo.UpdateProfile(caller.UserID, update)
+}
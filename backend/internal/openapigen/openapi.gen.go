@@ -18,133 +18,2504 @@ import (
 	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/go-chi/chi/v5"
 	"github.com/oapi-codegen/runtime"
+	openapi_types "github.com/oapi-codegen/runtime/types"
 )
 
 const (
+	ApiKeyScopes     = "apiKey.Scopes"
 	BearerAuthScopes = "bearerAuth.Scopes"
 )
 
+// Defines values for ApiKeyScope.
+const (
+	PaymentsRead  ApiKeyScope = "payments:read"
+	PaymentsWrite ApiKeyScope = "payments:write"
+)
+
+// Defines values for JWKKty.
+const (
+	OKP JWKKty = "OKP"
+	RSA JWKKty = "RSA"
+)
+
+// Defines values for PaymentEventSource.
+const (
+	PaymentEventSourceApiKey        PaymentEventSource = "api_key"
+	PaymentEventSourceDashboard     PaymentEventSource = "dashboard"
+	PaymentEventSourceImpersonation PaymentEventSource = "impersonation"
+)
+
+// Defines values for PaymentEventType.
+const (
+	Created         PaymentEventType = "created"
+	RefundApproved  PaymentEventType = "refund_approved"
+	RefundRejected  PaymentEventType = "refund_rejected"
+	RefundRequested PaymentEventType = "refund_requested"
+	StatusChanged   PaymentEventType = "status_changed"
+)
+
+// Defines values for PaymentStatusUpdateStatus.
+const (
+	Completed  PaymentStatusUpdateStatus = "completed"
+	Failed     PaymentStatusUpdateStatus = "failed"
+	Processing PaymentStatusUpdateStatus = "processing"
+)
+
+// Defines values for RefundStatus.
+const (
+	Approved  RefundStatus = "approved"
+	Rejected  RefundStatus = "rejected"
+	Requested RefundStatus = "requested"
+)
+
+// Defines values for Role.
+const (
+	Cs        Role = "cs"
+	Operation Role = "operation"
+	Superuser Role = "superuser"
+)
+
+// Defines values for SecurityEventType.
+const (
+	ImpersonatedRequest  SecurityEventType = "impersonated_request"
+	ImpersonationStarted SecurityEventType = "impersonation_started"
+	RefreshTokenReuse    SecurityEventType = "refresh_token_reuse"
+)
+
+// Defines values for SigningKeyAlg.
+const (
+	EdDSA SigningKeyAlg = "EdDSA"
+	RS256 SigningKeyAlg = "RS256"
+)
+
+// ApiKey defines model for ApiKey.
+type ApiKey struct {
+	CreatedAt  *time.Time `json:"created_at,omitempty"`
+	ExpiresAt  *time.Time `json:"expires_at,omitempty"`
+	Id         *string    `json:"id,omitempty"`
+	Label      *string    `json:"label,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	OwnerEmail *string    `json:"owner_email,omitempty"`
+	OwnerId    *string    `json:"owner_id,omitempty"`
+
+	// Prefix Public part of the key, to tell keys apart
+	Prefix    *string        `json:"prefix,omitempty"`
+	RevokedAt *time.Time     `json:"revoked_at,omitempty"`
+	Scopes    *[]ApiKeyScope `json:"scopes,omitempty"`
+}
+
+// ApiKeyScope defines model for ApiKeyScope.
+type ApiKeyScope string
+
 // Error defines model for Error.
 type Error struct {
 	Code    int    `json:"code"`
 	Message string `json:"message"`
 }
 
+// JWK defines model for JWK.
+type JWK struct {
+	Alg string `json:"alg"`
+
+	// Crv OKP curve, always Ed25519
+	Crv *string `json:"crv,omitempty"`
+
+	// E RSA exponent
+	E   *string `json:"e,omitempty"`
+	Kid string  `json:"kid"`
+	Kty JWKKty  `json:"kty"`
+
+	// N RSA modulus
+	N   *string `json:"n,omitempty"`
+	Use string  `json:"use"`
+
+	// X Ed25519 public key
+	X *string `json:"x,omitempty"`
+}
+
+// JWKKty defines model for JWK.Kty.
+type JWKKty string
+
+// MFAEnrollment defines model for MFAEnrollment.
+type MFAEnrollment struct {
+	// OtpauthUri otpauth:// URI to render as a QR code
+	OtpauthUri *string `json:"otpauthUri,omitempty"`
+
+	// Secret Base32 TOTP secret, for manual entry
+	Secret *string `json:"secret,omitempty"`
+}
+
+// NewPayment defines model for NewPayment.
+type NewPayment struct {
+	// Amount Decimal amount greater than zero, with at most as many decimals as the currency has minor units
+	Amount string `json:"amount"`
+
+	// Currency ISO 4217 currency code
+	Currency  string  `json:"currency"`
+	Merchant  string  `json:"merchant"`
+	Notes     *string `json:"notes,omitempty"`
+	Reference *string `json:"reference,omitempty"`
+}
+
 // Payment defines model for Payment.
 type Payment struct {
+	// Amount Decimal amount with as many decimals as the currency has minor units; stored as an integer count of minor units
+	Amount    *string    `json:"amount,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Currency ISO 4217 currency code
+	Currency *string `json:"currency,omitempty"`
+
+	// FxRate Only present with `report_currency`: units of the report currency per unit of `currency` used for `report_amount`
+	FxRate *string `json:"fx_rate,omitempty"`
+
+	// Highlights Only present when searching with `q`. Maps each field that matched (id, merchant, reference, notes) to its HTML-escaped text with the matching words wrapped in `<mark>` tags.
+	Highlights *map[string]string `json:"highlights,omitempty"`
+	Id         *string            `json:"id,omitempty"`
+	Merchant   *string            `json:"merchant,omitempty"`
+
+	// NetAmount `amount` less `refunded_amount`
+	NetAmount *string `json:"net_amount,omitempty"`
+
+	// Notes Free-form notes; may be empty
+	Notes *string `json:"notes,omitempty"`
+
+	// Reference Merchant's own reference for the payment; may be empty
+	Reference *string `json:"reference,omitempty"`
+
+	// RefundedAmount Sum of the approved refunds, in `currency`
+	RefundedAmount *string `json:"refunded_amount,omitempty"`
+
+	// ReportAmount Only present with `report_currency`: the amount converted at the rate in force when the payment was created, rounded half away from zero to the report currency's minor unit. Absent when no rate was in force.
+	ReportAmount *string `json:"report_amount,omitempty"`
+
+	// ReportCurrency Only present with `report_currency`
+	ReportCurrency *string `json:"report_currency,omitempty"`
+	Status         *string `json:"status,omitempty"`
+
+	// Version Goes up by one on every change; the payment's `ETag` is this number in quotes
+	Version *int64 `json:"version,omitempty"`
+}
+
+// PaymentDayTotals Amounts of the payments created on one day, in `report_currency`
+type PaymentDayTotals struct {
+	Completed string `json:"completed"`
+
+	// Date The day, YYYY-MM-DD in `tz`
+	Date       string `json:"date"`
+	Failed     string `json:"failed"`
+	Processing string `json:"processing"`
+	Total      string `json:"total"`
+}
+
+// PaymentDetail defines model for PaymentDetail.
+type PaymentDetail struct {
+	// Amount Decimal amount with as many decimals as the currency has minor units; stored as an integer count of minor units
 	Amount    *string    `json:"amount,omitempty"`
 	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Currency ISO 4217 currency code
+	Currency *string `json:"currency,omitempty"`
+
+	// Events The payment's history, oldest first
+	Events []PaymentEvent `json:"events"`
+
+	// FxRate Only present with `report_currency`: units of the report currency per unit of `currency` used for `report_amount`
+	FxRate *string `json:"fx_rate,omitempty"`
+
+	// Highlights Only present when searching with `q`. Maps each field that matched (id, merchant, reference, notes) to its HTML-escaped text with the matching words wrapped in `<mark>` tags.
+	Highlights *map[string]string `json:"highlights,omitempty"`
+	Id         *string            `json:"id,omitempty"`
+	Merchant   *string            `json:"merchant,omitempty"`
+
+	// NetAmount `amount` less `refunded_amount`
+	NetAmount *string `json:"net_amount,omitempty"`
+
+	// Notes Free-form notes; may be empty
+	Notes *string `json:"notes,omitempty"`
+
+	// Reference Merchant's own reference for the payment; may be empty
+	Reference *string `json:"reference,omitempty"`
+
+	// RefundedAmount Sum of the approved refunds, in `currency`
+	RefundedAmount *string `json:"refunded_amount,omitempty"`
+
+	// Refunds The payment's refunds, oldest first
+	Refunds []Refund `json:"refunds"`
+
+	// ReportAmount Only present with `report_currency`: the amount converted at the rate in force when the payment was created, rounded half away from zero to the report currency's minor unit. Absent when no rate was in force.
+	ReportAmount *string `json:"report_amount,omitempty"`
+
+	// ReportCurrency Only present with `report_currency`
+	ReportCurrency *string `json:"report_currency,omitempty"`
+	Status         *string `json:"status,omitempty"`
+
+	// Version Goes up by one on every change; the payment's `ETag` is this number in quotes
+	Version *int64 `json:"version,omitempty"`
+}
+
+// PaymentEvent defines model for PaymentEvent.
+type PaymentEvent struct {
+	// ActorEmail The actor's email at the time of the change
+	ActorEmail *string `json:"actor_email,omitempty"`
+
+	// ActorId User who made the change; empty once that user is deleted. For impersonated requests, the superuser behind them.
+	ActorId   *string    `json:"actor_id,omitempty"`
+	CreatedAt *time.Time `json:"created_at,omitempty"`
 	Id        *string    `json:"id,omitempty"`
-	Merchant  *string    `json:"merchant,omitempty"`
-	Status    *string    `json:"status,omitempty"`
+
+	// NewValue Status after the change (of the refund for refund events)
+	NewValue *string `json:"new_value,omitempty"`
+
+	// OldValue Status before the change (of the refund for refund events); empty for `created` and `refund_requested`
+	OldValue  *string `json:"old_value,omitempty"`
+	PaymentId *string `json:"payment_id,omitempty"`
+	Reason    *string `json:"reason,omitempty"`
+
+	// RefundId The refund, for refund events
+	RefundId *string `json:"refund_id,omitempty"`
+
+	// Source How the actor made the change
+	Source *PaymentEventSource `json:"source,omitempty"`
+	Type   *PaymentEventType   `json:"type,omitempty"`
+}
+
+// PaymentEventSource How the actor made the change
+type PaymentEventSource string
+
+// PaymentEventType defines model for PaymentEvent.Type.
+type PaymentEventType string
+
+// PaymentStatusUpdate defines model for PaymentStatusUpdate.
+type PaymentStatusUpdate struct {
+	// Reason Why the status is changed
+	Reason string `json:"reason"`
+
+	// Status The new status. Only a `processing` payment can move, to `completed` or `failed`; those two are final.
+	Status PaymentStatusUpdateStatus `json:"status"`
+}
+
+// PaymentStatusUpdateStatus The new status. Only a `processing` payment can move, to `completed` or `failed`; those two are final.
+type PaymentStatusUpdateStatus string
+
+// PaymentSummary defines model for PaymentSummary.
+type PaymentSummary struct {
+	Completed int `json:"completed"`
+
+	// Days One entry per day with converted payments, oldest first
+	Days   []PaymentDayTotals `json:"days"`
+	Failed int                `json:"failed"`
+
+	// From First day summarized, YYYY-MM-DD in `tz`
+	From           string `json:"from"`
+	Processing     int    `json:"processing"`
+	ReportCurrency string `json:"report_currency"`
+
+	// To Last day summarized, YYYY-MM-DD in `tz`
+	To string `json:"to"`
+
+	// Total Number of payments
+	Total int `json:"total"`
+
+	// Unconverted Payments with no rate into `report_currency` in force, added up per currency; they are in the counts but not in `days`
+	Unconverted []UnconvertedTotal `json:"unconverted"`
+}
+
+// Refund defines model for Refund.
+type Refund struct {
+	Amount         *string    `json:"amount,omitempty"`
+	CreatedAt      *time.Time `json:"created_at,omitempty"`
+	Currency       *string    `json:"currency,omitempty"`
+	DecidedAt      *time.Time `json:"decided_at,omitempty"`
+	DecidedBy      *string    `json:"decided_by,omitempty"`
+	DecidedByEmail *string    `json:"decided_by_email,omitempty"`
+	Id             *string    `json:"id,omitempty"`
+	PaymentId      *string    `json:"payment_id,omitempty"`
+	Reason         *string    `json:"reason,omitempty"`
+
+	// RequestedBy User who requested it; empty once that user is deleted
+	RequestedBy      *string `json:"requested_by,omitempty"`
+	RequestedByEmail *string `json:"requested_by_email,omitempty"`
+
+	// Status A refund is `requested` until it is `approved` or `rejected`, which are final. Requested and approved refunds hold part of the payment.
+	Status *RefundStatus `json:"status,omitempty"`
+}
+
+// RefundStatus A refund is `requested` until it is `approved` or `rejected`, which are final. Requested and approved refunds hold part of the payment.
+type RefundStatus string
+
+// RefundRejection defines model for RefundRejection.
+type RefundRejection struct {
+	Reason string `json:"reason"`
+}
+
+// RefundRequest defines model for RefundRequest.
+type RefundRequest struct {
+	// Amount Decimal amount in the payment's currency; without it, everything that is left to refund
+	Amount *string `json:"amount,omitempty"`
+	Reason string  `json:"reason"`
+}
+
+// Role defines model for Role.
+type Role string
+
+// RolePolicy defines model for RolePolicy.
+type RolePolicy struct {
+	MfaRequired bool `json:"mfa_required"`
+	Role        Role `json:"role"`
+}
+
+// SSOAuthorization defines model for SSOAuthorization.
+type SSOAuthorization struct {
+	// AuthorizationUrl IdP sign-in page to send the browser to
+	AuthorizationUrl string `json:"authorizationUrl"`
+
+	// State Must match the state the IdP sends back to the redirect URL
+	State string `json:"state"`
+}
+
+// SecurityEvent defines model for SecurityEvent.
+type SecurityEvent struct {
+	// ActorEmail The superuser's email when the event was recorded
+	ActorEmail *string `json:"actor_email,omitempty"`
+
+	// ActorId Superuser who acted as user_id, for impersonation events
+	ActorId   *string            `json:"actor_id,omitempty"`
+	CreatedAt *time.Time         `json:"created_at,omitempty"`
+	Detail    *string            `json:"detail,omitempty"`
+	Id        *string            `json:"id,omitempty"`
+	Ip        *string            `json:"ip,omitempty"`
+	SessionId *string            `json:"session_id,omitempty"`
+	Type      *SecurityEventType `json:"type,omitempty"`
+	UserAgent *string            `json:"user_agent,omitempty"`
+
+	// UserEmail The user's email when the event was recorded; events are kept when the user is deleted
+	UserEmail *string `json:"user_email,omitempty"`
+	UserId    *string `json:"user_id,omitempty"`
+}
+
+// SecurityEventType defines model for SecurityEvent.Type.
+type SecurityEventType string
+
+// Session defines model for Session.
+type Session struct {
+	CreatedAt *time.Time `json:"created_at,omitempty"`
+
+	// Current true for the session the request was made with
+	Current    *bool      `json:"current,omitempty"`
+	Id         *string    `json:"id,omitempty"`
+	Ip         *string    `json:"ip,omitempty"`
+	LastUsedAt *time.Time `json:"last_used_at,omitempty"`
+	UserAgent  *string    `json:"user_agent,omitempty"`
+}
+
+// SigningKey defines model for SigningKey.
+type SigningKey struct {
+	Active    *bool          `json:"active,omitempty"`
+	Alg       *SigningKeyAlg `json:"alg,omitempty"`
+	CreatedAt *time.Time     `json:"created_at,omitempty"`
+	Kid       *string        `json:"kid,omitempty"`
+
+	// RetiredAt Absent for the active key; retired keys only verify tokens issued before the rotation
+	RetiredAt *time.Time `json:"retired_at,omitempty"`
+}
+
+// SigningKeyAlg defines model for SigningKey.Alg.
+type SigningKeyAlg string
+
+// UnconvertedTotal defines model for UnconvertedTotal.
+type UnconvertedTotal struct {
+	// Amount Sum of the payments' amounts, in `currency`
+	Amount   string `json:"amount"`
+	Count    int    `json:"count"`
+	Currency string `json:"currency"`
 }
 
 // User defines model for User.
 type User struct {
-	Email        *string `json:"email,omitempty"`
-	RefreshToken *string `json:"refreshToken,omitempty"`
-	Role         *string `json:"role,omitempty"`
-	Token        *string `json:"token,omitempty"`
+	Email *string `json:"email,omitempty"`
+
+	// MfaEnrollmentRequired The user's role requires 2FA but the user has none yet; call /auth/login/mfa/enroll with the mfaToken to set it up first
+	MfaEnrollmentRequired *bool `json:"mfaEnrollmentRequired,omitempty"`
+
+	// MfaRequired true when a second factor is needed; token and refreshToken are then absent and the login is finished via /auth/login/mfa
+	MfaRequired *bool `json:"mfaRequired,omitempty"`
+
+	// MfaToken Short-lived token for the second login step
+	MfaToken *string `json:"mfaToken,omitempty"`
+
+	// RecoveryCodes One-time recovery codes, only returned when the login completed an enrolment
+	RecoveryCodes *[]string `json:"recoveryCodes,omitempty"`
+	RefreshToken  *string   `json:"refreshToken,omitempty"`
+	Role          *string   `json:"role,omitempty"`
+	Token         *string   `json:"token,omitempty"`
+}
+
+// UserProfile defines model for UserProfile.
+type UserProfile struct {
+	// Active false once a superuser deactivates the account
+	Active      *bool   `json:"active,omitempty"`
+	DisplayName *string `json:"display_name,omitempty"`
+	Email       *string `json:"email,omitempty"`
+	Id          *string `json:"id,omitempty"`
+
+	// Locale BCP 47 language tag
+	Locale *string `json:"locale,omitempty"`
+
+	// MfaEnabled true once the user has confirmed a TOTP authenticator
+	MfaEnabled *bool   `json:"mfa_enabled,omitempty"`
+	Role       *string `json:"role,omitempty"`
+
+	// Timezone IANA time zone name
+	Timezone *string `json:"timezone,omitempty"`
 }
 
+// Page defines model for page.
+type Page = int
+
+// PageSize defines model for pageSize.
+type PageSize = int
+
+// PaymentId defines model for paymentId.
+type PaymentId = string
+
+// RefundId defines model for refundId.
+type RefundId = string
+
 // Sort defines model for sort.
 type Sort = string
 
-// LoginResponse defines model for LoginResponse.
-type LoginResponse = User
+// UserId defines model for userId.
+type UserId = string
+
+// ApiKeyCreatedResponse defines model for ApiKeyCreatedResponse.
+type ApiKeyCreatedResponse struct {
+	ApiKey ApiKey `json:"api_key"`
+	Secret string `json:"secret"`
+}
+
+// ApiKeyListResponse defines model for ApiKeyListResponse.
+type ApiKeyListResponse struct {
+	ApiKeys *[]ApiKey `json:"api_keys,omitempty"`
+}
+
+// ErrorResponse defines model for ErrorResponse.
+type ErrorResponse = Error
+
+// ForbiddenError defines model for ForbiddenError.
+type ForbiddenError = Error
+
+// ImpersonationResponse defines model for ImpersonationResponse.
+type ImpersonationResponse struct {
+	ExpiresAt time.Time `json:"expires_at"`
+
+	// Token Access token for the user with an `act` claim naming the superuser. There is no refresh token.
+	Token string      `json:"token"`
+	User  UserProfile `json:"user"`
+}
+
+// JWKSetResponse defines model for JWKSetResponse.
+type JWKSetResponse struct {
+	Keys []JWK `json:"keys"`
+}
+
+// LoginResponse defines model for LoginResponse.
+type LoginResponse = User
+
+// MFAEnrollmentResponse defines model for MFAEnrollmentResponse.
+type MFAEnrollmentResponse = MFAEnrollment
+
+// PaymentDetailResponse A payment with its history and refunds
+type PaymentDetailResponse = PaymentDetail
+
+// PaymentEventListResponse defines model for PaymentEventListResponse.
+type PaymentEventListResponse struct {
+	Events []PaymentEvent `json:"events"`
+}
+
+// PaymentListResponse defines model for PaymentListResponse.
+type PaymentListResponse struct {
+	// HasMore true when another page follows
+	HasMore *bool `json:"has_more,omitempty"`
+
+	// NextCursor Pass as `cursor` to get the next page; absent on the last page
+	NextCursor *string    `json:"next_cursor,omitempty"`
+	Payments   *[]Payment `json:"payments,omitempty"`
+}
+
+// PaymentResponse defines model for PaymentResponse.
+type PaymentResponse = Payment
+
+// RecoveryCodesResponse defines model for RecoveryCodesResponse.
+type RecoveryCodesResponse struct {
+	RecoveryCodes *[]string `json:"recoveryCodes,omitempty"`
+}
+
+// RefreshTokenResponse defines model for RefreshTokenResponse.
+type RefreshTokenResponse struct {
+	RefreshToken *string `json:"refreshToken,omitempty"`
+	Token        *string `json:"token,omitempty"`
+}
+
+// RefundListResponse defines model for RefundListResponse.
+type RefundListResponse struct {
+	Refunds []Refund `json:"refunds"`
+}
+
+// RefundResponse defines model for RefundResponse.
+type RefundResponse = Refund
+
+// RolePolicyListResponse defines model for RolePolicyListResponse.
+type RolePolicyListResponse struct {
+	Policies *[]RolePolicy `json:"policies,omitempty"`
+}
+
+// SSOAuthorizationResponse defines model for SSOAuthorizationResponse.
+type SSOAuthorizationResponse = SSOAuthorization
+
+// SecurityEventListResponse defines model for SecurityEventListResponse.
+type SecurityEventListResponse struct {
+	Events   *[]SecurityEvent `json:"events,omitempty"`
+	Page     *int             `json:"page,omitempty"`
+	PageSize *int             `json:"page_size,omitempty"`
+	Total    *int             `json:"total,omitempty"`
+}
+
+// SessionListResponse defines model for SessionListResponse.
+type SessionListResponse struct {
+	Sessions *[]Session `json:"sessions,omitempty"`
+}
+
+// SigningKeyListResponse defines model for SigningKeyListResponse.
+type SigningKeyListResponse struct {
+	Keys *[]SigningKey `json:"keys,omitempty"`
+}
+
+// SigningKeyResponse defines model for SigningKeyResponse.
+type SigningKeyResponse = SigningKey
+
+// TooManyRequestsError defines model for TooManyRequestsError.
+type TooManyRequestsError = Error
+
+// UnauthorizedError defines model for UnauthorizedError.
+type UnauthorizedError = Error
+
+// UserListResponse defines model for UserListResponse.
+type UserListResponse struct {
+	Page     *int           `json:"page,omitempty"`
+	PageSize *int           `json:"page_size,omitempty"`
+	Total    *int           `json:"total,omitempty"`
+	Users    *[]UserProfile `json:"users,omitempty"`
+}
+
+// UserProfileResponse defines model for UserProfileResponse.
+type UserProfileResponse = UserProfile
+
+// PostDashboardV1ApiKeysJSONBody defines parameters for PostDashboardV1ApiKeys.
+type PostDashboardV1ApiKeysJSONBody struct {
+	ExpiresAt *time.Time    `json:"expires_at,omitempty"`
+	Label     string        `json:"label"`
+	OwnerId   *string       `json:"owner_id,omitempty"`
+	Scopes    []ApiKeyScope `json:"scopes"`
+}
+
+// PostDashboardV1AuthLoginJSONBody defines parameters for PostDashboardV1AuthLogin.
+type PostDashboardV1AuthLoginJSONBody struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// PostDashboardV1AuthLoginMfaJSONBody defines parameters for PostDashboardV1AuthLoginMfa.
+type PostDashboardV1AuthLoginMfaJSONBody struct {
+	Code     string `json:"code"`
+	MfaToken string `json:"mfaToken"`
+}
+
+// PostDashboardV1AuthLoginMfaEnrollJSONBody defines parameters for PostDashboardV1AuthLoginMfaEnroll.
+type PostDashboardV1AuthLoginMfaEnrollJSONBody struct {
+	MfaToken string `json:"mfaToken"`
+}
+
+// PostDashboardV1AuthMfaActivateJSONBody defines parameters for PostDashboardV1AuthMfaActivate.
+type PostDashboardV1AuthMfaActivateJSONBody struct {
+	Code string `json:"code"`
+}
+
+// PostDashboardV1AuthMfaDisableJSONBody defines parameters for PostDashboardV1AuthMfaDisable.
+type PostDashboardV1AuthMfaDisableJSONBody struct {
+	// Code Current TOTP code or an unused recovery code
+	Code     string  `json:"code"`
+	Password *string `json:"password,omitempty"`
+}
+
+// PostDashboardV1AuthMfaRecoveryCodesJSONBody defines parameters for PostDashboardV1AuthMfaRecoveryCodes.
+type PostDashboardV1AuthMfaRecoveryCodesJSONBody struct {
+	// Code Current TOTP code
+	Code string `json:"code"`
+}
+
+// PostDashboardV1AuthPasswordJSONBody defines parameters for PostDashboardV1AuthPassword.
+type PostDashboardV1AuthPasswordJSONBody struct {
+	CurrentPassword string `json:"currentPassword"`
+
+	// NewPassword Must meet the password policy: a minimum length, a mix of character classes, and not a known breached password.
+	NewPassword string `json:"newPassword"`
+}
+
+// PostDashboardV1AuthPasswordForgotJSONBody defines parameters for PostDashboardV1AuthPasswordForgot.
+type PostDashboardV1AuthPasswordForgotJSONBody struct {
+	Email string `json:"email"`
+}
+
+// PostDashboardV1AuthPasswordResetJSONBody defines parameters for PostDashboardV1AuthPasswordReset.
+type PostDashboardV1AuthPasswordResetJSONBody struct {
+	// NewPassword Must meet the password policy: a minimum length, a mix of character classes, and not a known breached password.
+	NewPassword string `json:"newPassword"`
+	Token       string `json:"token"`
+}
+
+// PostDashboardV1AuthRefreshJSONBody defines parameters for PostDashboardV1AuthRefresh.
+type PostDashboardV1AuthRefreshJSONBody struct {
+	RefreshToken string `json:"refreshToken"`
+}
+
+// GetDashboardV1AuthSecurityEventsParams defines parameters for GetDashboardV1AuthSecurityEvents.
+type GetDashboardV1AuthSecurityEventsParams struct {
+	// Page 1-based page number
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// PostDashboardV1AuthSsoCallbackJSONBody defines parameters for PostDashboardV1AuthSsoCallback.
+type PostDashboardV1AuthSsoCallbackJSONBody struct {
+	Code  string `json:"code"`
+	State string `json:"state"`
+}
+
+// PostDashboardV1AuthSsoCallbackParams defines parameters for PostDashboardV1AuthSsoCallback.
+type PostDashboardV1AuthSsoCallbackParams struct {
+	// SsoState Set by the authorize request; the callback is refused without it
+	SsoState *string `form:"sso_state,omitempty" json:"sso_state,omitempty"`
+}
+
+// GetDashboardV1PaymentsParams defines parameters for GetDashboardV1Payments.
+type GetDashboardV1PaymentsParams struct {
+	// Sort Comma-separated sort fields. Common patterns: `-created_at` (prefix `-` = desc) `amount` (no prefix `-` = asc) `amount` sorts by `currency` first, so amounts in different currencies are never compared. Ties are broken by `id`.
+	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+
+	// Status status of payment (completed , processing , or failed)
+	Status *string `form:"status,omitempty" json:"status,omitempty"`
+
+	// Id payment id
+	Id *string `form:"id,omitempty" json:"id,omitempty"`
+
+	// CreatedFrom only payments created at or after this time (RFC 3339)
+	CreatedFrom *time.Time `form:"created_from,omitempty" json:"created_from,omitempty"`
+
+	// CreatedTo only payments created at or before this time (RFC 3339)
+	CreatedTo *time.Time `form:"created_to,omitempty" json:"created_to,omitempty"`
+
+	// Currency only payments in this ISO 4217 currency; required with `amount_min` or `amount_max`
+	Currency *string `form:"currency,omitempty" json:"currency,omitempty"`
+
+	// AmountMin only payments of at least this amount in `currency`, with at most that currency's number of decimals; 400 without `currency`
+	AmountMin *string `form:"amount_min,omitempty" json:"amount_min,omitempty"`
+
+	// AmountMax only payments of at most this amount in `currency`, with at most that currency's number of decimals; 400 without `currency`
+	AmountMax *string `form:"amount_max,omitempty" json:"amount_max,omitempty"`
+
+	// Merchant exact merchant name
+	Merchant *string `form:"merchant,omitempty" json:"merchant,omitempty"`
+
+	// Q Full-text search over id, merchant, reference and notes. Every word must match the start of a word in one of those fields. Without a sort, results come most relevant first. Needs a server built with the `sqlite_fts5` tag; otherwise the request fails with 400.
+	Q *string `form:"q,omitempty" json:"q,omitempty"`
+
+	// MerchantPrefix merchant name prefix, case-insensitive
+	MerchantPrefix *string `form:"merchant_prefix,omitempty" json:"merchant_prefix,omitempty"`
+
+	// ReportCurrency ISO 4217 code to convert every amount into, at the exchange rate in force on each payment's created_at. Payments keep their own amount and currency next to the converted one. A payment with no rate in force has no `report_amount`, so converted amounts never mix currencies.
+	ReportCurrency *string `form:"report_currency,omitempty" json:"report_currency,omitempty"`
+
+	// Limit page size
+	Limit *int `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Cursor next_cursor of the previous page. A cursor only works with the sort it was issued for; filters should stay the same too.
+	Cursor *string `form:"cursor,omitempty" json:"cursor,omitempty"`
+}
+
+// PostDashboardV1PaymentsParams defines parameters for PostDashboardV1Payments.
+type PostDashboardV1PaymentsParams struct {
+	// IdempotencyKey Client-chosen unique key for this payment, e.g. a UUID
+	IdempotencyKey *string `json:"Idempotency-Key,omitempty"`
+}
+
+// GetDashboardV1PaymentsSummaryParams defines parameters for GetDashboardV1PaymentsSummary.
+type GetDashboardV1PaymentsSummaryParams struct {
+	// ReportCurrency ISO 4217 code to add the amounts up in
+	ReportCurrency string `form:"report_currency" json:"report_currency"`
+
+	// Tz IANA time zone whose days the totals are grouped by; UTC by default
+	Tz *string `form:"tz,omitempty" json:"tz,omitempty"`
+
+	// From First day to summarize, in `tz`; 30 days before `to` by default
+	From *openapi_types.Date `form:"from,omitempty" json:"from,omitempty"`
+
+	// To Last day to summarize, in `tz`; today by default
+	To *openapi_types.Date `form:"to,omitempty" json:"to,omitempty"`
+}
+
+// PatchDashboardV1PaymentsIdStatusParams defines parameters for PatchDashboardV1PaymentsIdStatus.
+type PatchDashboardV1PaymentsIdStatusParams struct {
+	// IfMatch The payment's `ETag`; required (428 without it)
+	IfMatch *string `json:"If-Match,omitempty"`
+}
+
+// PutDashboardV1RolePoliciesRoleJSONBody defines parameters for PutDashboardV1RolePoliciesRole.
+type PutDashboardV1RolePoliciesRoleJSONBody struct {
+	MfaRequired bool `json:"mfa_required"`
+}
+
+// GetDashboardV1UsersParams defines parameters for GetDashboardV1Users.
+type GetDashboardV1UsersParams struct {
+	// Page 1-based page number
+	Page     *Page     `form:"page,omitempty" json:"page,omitempty"`
+	PageSize *PageSize `form:"page_size,omitempty" json:"page_size,omitempty"`
+}
+
+// PostDashboardV1UsersJSONBody defines parameters for PostDashboardV1Users.
+type PostDashboardV1UsersJSONBody struct {
+	DisplayName *string `json:"display_name,omitempty"`
+	Email       string  `json:"email"`
+
+	// Password Must meet the password policy: a minimum length, a mix of character classes, and not a known breached password.
+	Password string `json:"password"`
+	Role     Role   `json:"role"`
+}
+
+// PatchDashboardV1UsersProfileJSONBody defines parameters for PatchDashboardV1UsersProfile.
+type PatchDashboardV1UsersProfileJSONBody struct {
+	DisplayName *string `json:"display_name,omitempty"`
+	Locale      *string `json:"locale,omitempty"`
+	Timezone    *string `json:"timezone,omitempty"`
+}
+
+// PostDashboardV1UsersIdImpersonateJSONBody defines parameters for PostDashboardV1UsersIdImpersonate.
+type PostDashboardV1UsersIdImpersonateJSONBody struct {
+	// Reason Why the user is impersonated, e.g. a support ticket reference
+	Reason string `json:"reason"`
+}
+
+// PatchDashboardV1UsersIdRoleJSONBody defines parameters for PatchDashboardV1UsersIdRole.
+type PatchDashboardV1UsersIdRoleJSONBody struct {
+	Role Role `json:"role"`
+}
+
+// PostDashboardV1ApiKeysJSONRequestBody defines body for PostDashboardV1ApiKeys for application/json ContentType.
+type PostDashboardV1ApiKeysJSONRequestBody PostDashboardV1ApiKeysJSONBody
+
+// PostDashboardV1AuthLoginJSONRequestBody defines body for PostDashboardV1AuthLogin for application/json ContentType.
+type PostDashboardV1AuthLoginJSONRequestBody PostDashboardV1AuthLoginJSONBody
+
+// PostDashboardV1AuthLoginMfaJSONRequestBody defines body for PostDashboardV1AuthLoginMfa for application/json ContentType.
+type PostDashboardV1AuthLoginMfaJSONRequestBody PostDashboardV1AuthLoginMfaJSONBody
+
+// PostDashboardV1AuthLoginMfaEnrollJSONRequestBody defines body for PostDashboardV1AuthLoginMfaEnroll for application/json ContentType.
+type PostDashboardV1AuthLoginMfaEnrollJSONRequestBody PostDashboardV1AuthLoginMfaEnrollJSONBody
+
+// PostDashboardV1AuthMfaActivateJSONRequestBody defines body for PostDashboardV1AuthMfaActivate for application/json ContentType.
+type PostDashboardV1AuthMfaActivateJSONRequestBody PostDashboardV1AuthMfaActivateJSONBody
+
+// PostDashboardV1AuthMfaDisableJSONRequestBody defines body for PostDashboardV1AuthMfaDisable for application/json ContentType.
+type PostDashboardV1AuthMfaDisableJSONRequestBody PostDashboardV1AuthMfaDisableJSONBody
+
+// PostDashboardV1AuthMfaRecoveryCodesJSONRequestBody defines body for PostDashboardV1AuthMfaRecoveryCodes for application/json ContentType.
+type PostDashboardV1AuthMfaRecoveryCodesJSONRequestBody PostDashboardV1AuthMfaRecoveryCodesJSONBody
+
+// PostDashboardV1AuthPasswordJSONRequestBody defines body for PostDashboardV1AuthPassword for application/json ContentType.
+type PostDashboardV1AuthPasswordJSONRequestBody PostDashboardV1AuthPasswordJSONBody
+
+// PostDashboardV1AuthPasswordForgotJSONRequestBody defines body for PostDashboardV1AuthPasswordForgot for application/json ContentType.
+type PostDashboardV1AuthPasswordForgotJSONRequestBody PostDashboardV1AuthPasswordForgotJSONBody
+
+// PostDashboardV1AuthPasswordResetJSONRequestBody defines body for PostDashboardV1AuthPasswordReset for application/json ContentType.
+type PostDashboardV1AuthPasswordResetJSONRequestBody PostDashboardV1AuthPasswordResetJSONBody
+
+// PostDashboardV1AuthRefreshJSONRequestBody defines body for PostDashboardV1AuthRefresh for application/json ContentType.
+type PostDashboardV1AuthRefreshJSONRequestBody PostDashboardV1AuthRefreshJSONBody
+
+// PostDashboardV1AuthSsoCallbackJSONRequestBody defines body for PostDashboardV1AuthSsoCallback for application/json ContentType.
+type PostDashboardV1AuthSsoCallbackJSONRequestBody PostDashboardV1AuthSsoCallbackJSONBody
+
+// PostDashboardV1PaymentsJSONRequestBody defines body for PostDashboardV1Payments for application/json ContentType.
+type PostDashboardV1PaymentsJSONRequestBody = NewPayment
+
+// PostDashboardV1PaymentsIdRefundsJSONRequestBody defines body for PostDashboardV1PaymentsIdRefunds for application/json ContentType.
+type PostDashboardV1PaymentsIdRefundsJSONRequestBody = RefundRequest
+
+// PostDashboardV1PaymentsIdRefundsRefundIdRejectJSONRequestBody defines body for PostDashboardV1PaymentsIdRefundsRefundIdReject for application/json ContentType.
+type PostDashboardV1PaymentsIdRefundsRefundIdRejectJSONRequestBody = RefundRejection
+
+// PatchDashboardV1PaymentsIdStatusJSONRequestBody defines body for PatchDashboardV1PaymentsIdStatus for application/json ContentType.
+type PatchDashboardV1PaymentsIdStatusJSONRequestBody = PaymentStatusUpdate
+
+// PutDashboardV1RolePoliciesRoleJSONRequestBody defines body for PutDashboardV1RolePoliciesRole for application/json ContentType.
+type PutDashboardV1RolePoliciesRoleJSONRequestBody PutDashboardV1RolePoliciesRoleJSONBody
+
+// PostDashboardV1UsersJSONRequestBody defines body for PostDashboardV1Users for application/json ContentType.
+type PostDashboardV1UsersJSONRequestBody PostDashboardV1UsersJSONBody
+
+// PatchDashboardV1UsersProfileJSONRequestBody defines body for PatchDashboardV1UsersProfile for application/json ContentType.
+type PatchDashboardV1UsersProfileJSONRequestBody PatchDashboardV1UsersProfileJSONBody
+
+// PostDashboardV1UsersIdImpersonateJSONRequestBody defines body for PostDashboardV1UsersIdImpersonate for application/json ContentType.
+type PostDashboardV1UsersIdImpersonateJSONRequestBody PostDashboardV1UsersIdImpersonateJSONBody
+
+// PatchDashboardV1UsersIdRoleJSONRequestBody defines body for PatchDashboardV1UsersIdRole for application/json ContentType.
+type PatchDashboardV1UsersIdRoleJSONRequestBody PatchDashboardV1UsersIdRoleJSONBody
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Public keys that verify access and refresh tokens
+	// (GET /.well-known/jwks.json)
+	GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request)
+	// List API keys, including revoked and expired ones
+	// (GET /dashboard/v1/api-keys)
+	GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request)
+	// Create an API key for a machine client
+	// (POST /dashboard/v1/api-keys)
+	PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request)
+	// Revoke an API key
+	// (DELETE /dashboard/v1/api-keys/{id})
+	DeleteDashboardV1ApiKeysId(w http.ResponseWriter, r *http.Request, id string)
+	// List the JWT signing keys, active key first
+	// (GET /dashboard/v1/auth/keys)
+	GetDashboardV1AuthKeys(w http.ResponseWriter, r *http.Request)
+	// Start signing tokens with a new key
+	// (POST /dashboard/v1/auth/keys/rotate)
+	PostDashboardV1AuthKeysRotate(w http.ResponseWriter, r *http.Request)
+	// Login with email + password
+	// (POST /dashboard/v1/auth/login)
+	PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request)
+	// Finish a login with a TOTP or recovery code
+	// (POST /dashboard/v1/auth/login/mfa)
+	PostDashboardV1AuthLoginMfa(w http.ResponseWriter, r *http.Request)
+	// Get a TOTP secret during a login that requires enrolment
+	// (POST /dashboard/v1/auth/login/mfa/enroll)
+	PostDashboardV1AuthLoginMfaEnroll(w http.ResponseWriter, r *http.Request)
+	// Logout and revoke the current tokens
+	// (POST /dashboard/v1/auth/logout)
+	PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request)
+	// Confirm the authenticator and turn on two-factor authentication
+	// (POST /dashboard/v1/auth/mfa/activate)
+	PostDashboardV1AuthMfaActivate(w http.ResponseWriter, r *http.Request)
+	// Turn off two-factor authentication
+	// (POST /dashboard/v1/auth/mfa/disable)
+	PostDashboardV1AuthMfaDisable(w http.ResponseWriter, r *http.Request)
+	// Start setting up two-factor authentication
+	// (POST /dashboard/v1/auth/mfa/enroll)
+	PostDashboardV1AuthMfaEnroll(w http.ResponseWriter, r *http.Request)
+	// Replace all recovery codes
+	// (POST /dashboard/v1/auth/mfa/recovery-codes)
+	PostDashboardV1AuthMfaRecoveryCodes(w http.ResponseWriter, r *http.Request)
+	// Change the current user's password
+	// (POST /dashboard/v1/auth/password)
+	PostDashboardV1AuthPassword(w http.ResponseWriter, r *http.Request)
+	// Email a password reset link
+	// (POST /dashboard/v1/auth/password/forgot)
+	PostDashboardV1AuthPasswordForgot(w http.ResponseWriter, r *http.Request)
+	// Set a new password using a reset token
+	// (POST /dashboard/v1/auth/password/reset)
+	PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request)
+	// Refresh access token using refresh token
+	// (POST /dashboard/v1/auth/refresh)
+	PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request)
+	// List recorded security events, newest first
+	// (GET /dashboard/v1/auth/security-events)
+	GetDashboardV1AuthSecurityEvents(w http.ResponseWriter, r *http.Request, params GetDashboardV1AuthSecurityEventsParams)
+	// Revoke every session except the current one
+	// (DELETE /dashboard/v1/auth/sessions)
+	DeleteDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request)
+	// List the current user's sessions
+	// (GET /dashboard/v1/auth/sessions)
+	GetDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request)
+	// Revoke one of the current user's sessions
+	// (DELETE /dashboard/v1/auth/sessions/{sessionId})
+	DeleteDashboardV1AuthSessionsSessionId(w http.ResponseWriter, r *http.Request, sessionId string)
+	// Start a single sign-on login with the company IdP
+	// (POST /dashboard/v1/auth/sso/authorize)
+	PostDashboardV1AuthSsoAuthorize(w http.ResponseWriter, r *http.Request)
+	// Finish a single sign-on login
+	// (POST /dashboard/v1/auth/sso/callback)
+	PostDashboardV1AuthSsoCallback(w http.ResponseWriter, r *http.Request, params PostDashboardV1AuthSsoCallbackParams)
+	// List of payments
+	// (GET /dashboard/v1/payments)
+	GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsParams)
+	// Record a payment
+	// (POST /dashboard/v1/payments)
+	PostDashboardV1Payments(w http.ResponseWriter, r *http.Request, params PostDashboardV1PaymentsParams)
+	// Payment counts and daily totals
+	// (GET /dashboard/v1/payments/summary)
+	GetDashboardV1PaymentsSummary(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsSummaryParams)
+	// Get a payment
+	// (GET /dashboard/v1/payments/{id})
+	GetDashboardV1PaymentsId(w http.ResponseWriter, r *http.Request, id PaymentId)
+	// List a payment's history
+	// (GET /dashboard/v1/payments/{id}/events)
+	GetDashboardV1PaymentsIdEvents(w http.ResponseWriter, r *http.Request, id PaymentId)
+	// List a payment's refunds
+	// (GET /dashboard/v1/payments/{id}/refunds)
+	GetDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request, id PaymentId)
+	// Request a refund
+	// (POST /dashboard/v1/payments/{id}/refunds)
+	PostDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request, id PaymentId)
+	// Approve a refund
+	// (POST /dashboard/v1/payments/{id}/refunds/{refundId}/approve)
+	PostDashboardV1PaymentsIdRefundsRefundIdApprove(w http.ResponseWriter, r *http.Request, id PaymentId, refundId RefundId)
+	// Reject a refund
+	// (POST /dashboard/v1/payments/{id}/refunds/{refundId}/reject)
+	PostDashboardV1PaymentsIdRefundsRefundIdReject(w http.ResponseWriter, r *http.Request, id PaymentId, refundId RefundId)
+	// Change a payment's status
+	// (PATCH /dashboard/v1/payments/{id}/status)
+	PatchDashboardV1PaymentsIdStatus(w http.ResponseWriter, r *http.Request, id PaymentId, params PatchDashboardV1PaymentsIdStatusParams)
+	// List the security policy of every role
+	// (GET /dashboard/v1/role-policies)
+	GetDashboardV1RolePolicies(w http.ResponseWriter, r *http.Request)
+	// Update the security policy of a role
+	// (PUT /dashboard/v1/role-policies/{role})
+	PutDashboardV1RolePoliciesRole(w http.ResponseWriter, r *http.Request, role Role)
+	// List dashboard users
+	// (GET /dashboard/v1/users)
+	GetDashboardV1Users(w http.ResponseWriter, r *http.Request, params GetDashboardV1UsersParams)
+	// Create a dashboard user with an initial password
+	// (POST /dashboard/v1/users)
+	PostDashboardV1Users(w http.ResponseWriter, r *http.Request)
+	// Get the current user's profile
+	// (GET /dashboard/v1/users/profile)
+	GetDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request)
+	// Update the current user's profile preferences
+	// (PATCH /dashboard/v1/users/profile)
+	PatchDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request)
+	// Delete a dashboard user and revoke their sessions
+	// (DELETE /dashboard/v1/users/{id})
+	DeleteDashboardV1UsersId(w http.ResponseWriter, r *http.Request, id UserId)
+	// Deactivate a user and revoke their sessions
+	// (POST /dashboard/v1/users/{id}/deactivate)
+	PostDashboardV1UsersIdDeactivate(w http.ResponseWriter, r *http.Request, id UserId)
+	// Get a short-lived access token acting as another user
+	// (POST /dashboard/v1/users/{id}/impersonate)
+	PostDashboardV1UsersIdImpersonate(w http.ResponseWriter, r *http.Request, id UserId)
+	// Reactivate a deactivated user
+	// (POST /dashboard/v1/users/{id}/reactivate)
+	PostDashboardV1UsersIdReactivate(w http.ResponseWriter, r *http.Request, id UserId)
+	// Change a user's role
+	// (PATCH /dashboard/v1/users/{id}/role)
+	PatchDashboardV1UsersIdRole(w http.ResponseWriter, r *http.Request, id UserId)
+	// Clear a user's failed login attempts and lockout
+	// (POST /dashboard/v1/users/{id}/unlock)
+	PostDashboardV1UsersIdUnlock(w http.ResponseWriter, r *http.Request, id UserId)
+}
+
+// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
+
+type Unimplemented struct{}
+
+// Public keys that verify access and refresh tokens
+// (GET /.well-known/jwks.json)
+func (_ Unimplemented) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List API keys, including revoked and expired ones
+// (GET /dashboard/v1/api-keys)
+func (_ Unimplemented) GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create an API key for a machine client
+// (POST /dashboard/v1/api-keys)
+func (_ Unimplemented) PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke an API key
+// (DELETE /dashboard/v1/api-keys/{id})
+func (_ Unimplemented) DeleteDashboardV1ApiKeysId(w http.ResponseWriter, r *http.Request, id string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the JWT signing keys, active key first
+// (GET /dashboard/v1/auth/keys)
+func (_ Unimplemented) GetDashboardV1AuthKeys(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start signing tokens with a new key
+// (POST /dashboard/v1/auth/keys/rotate)
+func (_ Unimplemented) PostDashboardV1AuthKeysRotate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Login with email + password
+// (POST /dashboard/v1/auth/login)
+func (_ Unimplemented) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Finish a login with a TOTP or recovery code
+// (POST /dashboard/v1/auth/login/mfa)
+func (_ Unimplemented) PostDashboardV1AuthLoginMfa(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a TOTP secret during a login that requires enrolment
+// (POST /dashboard/v1/auth/login/mfa/enroll)
+func (_ Unimplemented) PostDashboardV1AuthLoginMfaEnroll(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Logout and revoke the current tokens
+// (POST /dashboard/v1/auth/logout)
+func (_ Unimplemented) PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Confirm the authenticator and turn on two-factor authentication
+// (POST /dashboard/v1/auth/mfa/activate)
+func (_ Unimplemented) PostDashboardV1AuthMfaActivate(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Turn off two-factor authentication
+// (POST /dashboard/v1/auth/mfa/disable)
+func (_ Unimplemented) PostDashboardV1AuthMfaDisable(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start setting up two-factor authentication
+// (POST /dashboard/v1/auth/mfa/enroll)
+func (_ Unimplemented) PostDashboardV1AuthMfaEnroll(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Replace all recovery codes
+// (POST /dashboard/v1/auth/mfa/recovery-codes)
+func (_ Unimplemented) PostDashboardV1AuthMfaRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change the current user's password
+// (POST /dashboard/v1/auth/password)
+func (_ Unimplemented) PostDashboardV1AuthPassword(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Email a password reset link
+// (POST /dashboard/v1/auth/password/forgot)
+func (_ Unimplemented) PostDashboardV1AuthPasswordForgot(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Set a new password using a reset token
+// (POST /dashboard/v1/auth/password/reset)
+func (_ Unimplemented) PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Refresh access token using refresh token
+// (POST /dashboard/v1/auth/refresh)
+func (_ Unimplemented) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List recorded security events, newest first
+// (GET /dashboard/v1/auth/security-events)
+func (_ Unimplemented) GetDashboardV1AuthSecurityEvents(w http.ResponseWriter, r *http.Request, params GetDashboardV1AuthSecurityEventsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke every session except the current one
+// (DELETE /dashboard/v1/auth/sessions)
+func (_ Unimplemented) DeleteDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the current user's sessions
+// (GET /dashboard/v1/auth/sessions)
+func (_ Unimplemented) GetDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Revoke one of the current user's sessions
+// (DELETE /dashboard/v1/auth/sessions/{sessionId})
+func (_ Unimplemented) DeleteDashboardV1AuthSessionsSessionId(w http.ResponseWriter, r *http.Request, sessionId string) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Start a single sign-on login with the company IdP
+// (POST /dashboard/v1/auth/sso/authorize)
+func (_ Unimplemented) PostDashboardV1AuthSsoAuthorize(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Finish a single sign-on login
+// (POST /dashboard/v1/auth/sso/callback)
+func (_ Unimplemented) PostDashboardV1AuthSsoCallback(w http.ResponseWriter, r *http.Request, params PostDashboardV1AuthSsoCallbackParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List of payments
+// (GET /dashboard/v1/payments)
+func (_ Unimplemented) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Record a payment
+// (POST /dashboard/v1/payments)
+func (_ Unimplemented) PostDashboardV1Payments(w http.ResponseWriter, r *http.Request, params PostDashboardV1PaymentsParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Payment counts and daily totals
+// (GET /dashboard/v1/payments/summary)
+func (_ Unimplemented) GetDashboardV1PaymentsSummary(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsSummaryParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a payment
+// (GET /dashboard/v1/payments/{id})
+func (_ Unimplemented) GetDashboardV1PaymentsId(w http.ResponseWriter, r *http.Request, id PaymentId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a payment's history
+// (GET /dashboard/v1/payments/{id}/events)
+func (_ Unimplemented) GetDashboardV1PaymentsIdEvents(w http.ResponseWriter, r *http.Request, id PaymentId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List a payment's refunds
+// (GET /dashboard/v1/payments/{id}/refunds)
+func (_ Unimplemented) GetDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request, id PaymentId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Request a refund
+// (POST /dashboard/v1/payments/{id}/refunds)
+func (_ Unimplemented) PostDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request, id PaymentId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Approve a refund
+// (POST /dashboard/v1/payments/{id}/refunds/{refundId}/approve)
+func (_ Unimplemented) PostDashboardV1PaymentsIdRefundsRefundIdApprove(w http.ResponseWriter, r *http.Request, id PaymentId, refundId RefundId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reject a refund
+// (POST /dashboard/v1/payments/{id}/refunds/{refundId}/reject)
+func (_ Unimplemented) PostDashboardV1PaymentsIdRefundsRefundIdReject(w http.ResponseWriter, r *http.Request, id PaymentId, refundId RefundId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change a payment's status
+// (PATCH /dashboard/v1/payments/{id}/status)
+func (_ Unimplemented) PatchDashboardV1PaymentsIdStatus(w http.ResponseWriter, r *http.Request, id PaymentId, params PatchDashboardV1PaymentsIdStatusParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List the security policy of every role
+// (GET /dashboard/v1/role-policies)
+func (_ Unimplemented) GetDashboardV1RolePolicies(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update the security policy of a role
+// (PUT /dashboard/v1/role-policies/{role})
+func (_ Unimplemented) PutDashboardV1RolePoliciesRole(w http.ResponseWriter, r *http.Request, role Role) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// List dashboard users
+// (GET /dashboard/v1/users)
+func (_ Unimplemented) GetDashboardV1Users(w http.ResponseWriter, r *http.Request, params GetDashboardV1UsersParams) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Create a dashboard user with an initial password
+// (POST /dashboard/v1/users)
+func (_ Unimplemented) PostDashboardV1Users(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get the current user's profile
+// (GET /dashboard/v1/users/profile)
+func (_ Unimplemented) GetDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Update the current user's profile preferences
+// (PATCH /dashboard/v1/users/profile)
+func (_ Unimplemented) PatchDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Delete a dashboard user and revoke their sessions
+// (DELETE /dashboard/v1/users/{id})
+func (_ Unimplemented) DeleteDashboardV1UsersId(w http.ResponseWriter, r *http.Request, id UserId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Deactivate a user and revoke their sessions
+// (POST /dashboard/v1/users/{id}/deactivate)
+func (_ Unimplemented) PostDashboardV1UsersIdDeactivate(w http.ResponseWriter, r *http.Request, id UserId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Get a short-lived access token acting as another user
+// (POST /dashboard/v1/users/{id}/impersonate)
+func (_ Unimplemented) PostDashboardV1UsersIdImpersonate(w http.ResponseWriter, r *http.Request, id UserId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Reactivate a deactivated user
+// (POST /dashboard/v1/users/{id}/reactivate)
+func (_ Unimplemented) PostDashboardV1UsersIdReactivate(w http.ResponseWriter, r *http.Request, id UserId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Change a user's role
+// (PATCH /dashboard/v1/users/{id}/role)
+func (_ Unimplemented) PatchDashboardV1UsersIdRole(w http.ResponseWriter, r *http.Request, id UserId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// Clear a user's failed login attempts and lockout
+// (POST /dashboard/v1/users/{id}/unlock)
+func (_ Unimplemented) PostDashboardV1UsersIdUnlock(w http.ResponseWriter, r *http.Request, id UserId) {
+	w.WriteHeader(http.StatusNotImplemented)
+}
+
+// ServerInterfaceWrapper converts contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler            ServerInterface
+	HandlerMiddlewares []MiddlewareFunc
+	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
+}
+
+type MiddlewareFunc func(http.Handler) http.Handler
+
+// GetWellKnownJwksJson operation middleware
+func (siw *ServerInterfaceWrapper) GetWellKnownJwksJson(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetWellKnownJwksJson(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1ApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1ApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1ApiKeys operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1ApiKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1ApiKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDashboardV1ApiKeysId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1ApiKeysId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDashboardV1ApiKeysId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1AuthKeys operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1AuthKeys(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1AuthKeys(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthKeysRotate operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthKeysRotate(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthKeysRotate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthLogin operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthLogin(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthLoginMfa operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLoginMfa(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthLoginMfa(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthLoginMfaEnroll operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLoginMfaEnroll(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthLoginMfaEnroll(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthLogout operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLogout(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthLogout(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthMfaActivate operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthMfaActivate(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthMfaActivate(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthMfaDisable operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthMfaDisable(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthMfaDisable(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthMfaEnroll operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthMfaEnroll(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthMfaEnroll(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthMfaRecoveryCodes operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthMfaRecoveryCodes(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthMfaRecoveryCodes(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthPassword operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthPassword(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthPassword(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthPasswordForgot operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthPasswordForgot(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthPasswordForgot(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthPasswordReset operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthPasswordReset(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthPasswordReset(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthRefresh operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthRefresh(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1AuthSecurityEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1AuthSecurityEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1AuthSecurityEventsParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", r.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page_size", r.URL.Query(), &params.PageSize, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page_size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1AuthSecurityEvents(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDashboardV1AuthSessions operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDashboardV1AuthSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1AuthSessions operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1AuthSessions(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1AuthSessions(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// DeleteDashboardV1AuthSessionsSessionId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1AuthSessionsSessionId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "sessionId" -------------
+	var sessionId string
+
+	err = runtime.BindStyledParameterWithOptions("simple", "sessionId", chi.URLParam(r, "sessionId"), &sessionId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sessionId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDashboardV1AuthSessionsSessionId(w, r, sessionId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthSsoAuthorize operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthSsoAuthorize(w http.ResponseWriter, r *http.Request) {
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthSsoAuthorize(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1AuthSsoCallback operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1AuthSsoCallback(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostDashboardV1AuthSsoCallbackParams
+
+	{
+		var cookie *http.Cookie
+
+		if cookie, err = r.Cookie("sso_state"); err == nil {
+			var value string
+			err = runtime.BindStyledParameterWithOptions("simple", "sso_state", cookie.Value, &value, runtime.BindStyledParameterOptions{Explode: true, Required: false, Type: "string", Format: ""})
+			if err != nil {
+				siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sso_state", Err: err})
+				return
+			}
+			params.SsoState = &value
+
+		}
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1AuthSsoCallback(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1Payments operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1PaymentsParams
+
+	// ------------- Optional query parameter "sort" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "sort", r.URL.Query(), &params.Sort, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "status" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "status", r.URL.Query(), &params.Status, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "id" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "id", r.URL.Query(), &params.Id, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_from" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "created_from", r.URL.Query(), &params.CreatedFrom, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "created_to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "created_to", r.URL.Query(), &params.CreatedTo, runtime.BindQueryParameterOptions{Type: "string", Format: "date-time"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "created_to", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "currency" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "currency", r.URL.Query(), &params.Currency, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "currency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_min" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "amount_min", r.URL.Query(), &params.AmountMin, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_min", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "amount_max" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "amount_max", r.URL.Query(), &params.AmountMax, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "amount_max", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merchant" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "merchant", r.URL.Query(), &params.Merchant, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merchant", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "q" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "q", r.URL.Query(), &params.Q, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "q", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "merchant_prefix" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "merchant_prefix", r.URL.Query(), &params.MerchantPrefix, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "merchant_prefix", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "report_currency" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "report_currency", r.URL.Query(), &params.ReportCurrency, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "report_currency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "limit", r.URL.Query(), &params.Limit, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "limit", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "cursor" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "cursor", r.URL.Query(), &params.Cursor, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "cursor", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1Payments(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1Payments operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1Payments(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostDashboardV1PaymentsParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "Idempotency-Key", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "Idempotency-Key", Err: err})
+			return
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1Payments(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1PaymentsSummary operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1PaymentsSummary(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1PaymentsSummaryParams
+
+	// ------------- Required query parameter "report_currency" -------------
+
+	if paramValue := r.URL.Query().Get("report_currency"); paramValue != "" {
+
+	} else {
+		siw.ErrorHandlerFunc(w, r, &RequiredParamError{ParamName: "report_currency"})
+		return
+	}
+
+	err = runtime.BindQueryParameterWithOptions("form", true, true, "report_currency", r.URL.Query(), &params.ReportCurrency, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "report_currency", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "tz" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "tz", r.URL.Query(), &params.Tz, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "tz", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "from" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "from", r.URL.Query(), &params.From, runtime.BindQueryParameterOptions{Type: "string", Format: "date"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "from", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "to" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "to", r.URL.Query(), &params.To, runtime.BindQueryParameterOptions{Type: "string", Format: "date"})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "to", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1PaymentsSummary(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1PaymentsId operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1PaymentsId(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PaymentId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1PaymentsId(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1PaymentsIdEvents operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1PaymentsIdEvents(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PaymentId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1PaymentsIdEvents(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1PaymentsIdRefunds operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PaymentId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	ctx = context.WithValue(ctx, ApiKeyScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1PaymentsIdRefunds(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1PaymentsIdRefunds operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1PaymentsIdRefunds(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PaymentId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1PaymentsIdRefunds(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1PaymentsIdRefundsRefundIdApprove operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1PaymentsIdRefundsRefundIdApprove(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PaymentId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "refundId" -------------
+	var refundId RefundId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "refundId", chi.URLParam(r, "refundId"), &refundId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refundId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1PaymentsIdRefundsRefundIdApprove(w, r, id, refundId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1PaymentsIdRefundsRefundIdReject operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1PaymentsIdRefundsRefundIdReject(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PaymentId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	// ------------- Path parameter "refundId" -------------
+	var refundId RefundId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "refundId", chi.URLParam(r, "refundId"), &refundId, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "refundId", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1PaymentsIdRefundsRefundIdReject(w, r, id, refundId)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchDashboardV1PaymentsIdStatus operation middleware
+func (siw *ServerInterfaceWrapper) PatchDashboardV1PaymentsIdStatus(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id PaymentId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchDashboardV1PaymentsIdStatusParams
+
+	headers := r.Header
+
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch string
+		n := len(valueList)
+		if n != 1 {
+			siw.ErrorHandlerFunc(w, r, &TooManyValuesForParamError{ParamName: "If-Match", Count: n})
+			return
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false, Type: "string", Format: ""})
+		if err != nil {
+			siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "If-Match", Err: err})
+			return
+		}
+
+		params.IfMatch = &IfMatch
+
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchDashboardV1PaymentsIdStatus(w, r, id, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1RolePolicies operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1RolePolicies(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1RolePolicies(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PutDashboardV1RolePoliciesRole operation middleware
+func (siw *ServerInterfaceWrapper) PutDashboardV1RolePoliciesRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "role" -------------
+	var role Role
+
+	err = runtime.BindStyledParameterWithOptions("simple", "role", chi.URLParam(r, "role"), &role, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "role", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PutDashboardV1RolePoliciesRole(w, r, role)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1Users operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1Users(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetDashboardV1UsersParams
+
+	// ------------- Optional query parameter "page" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page", r.URL.Query(), &params.Page, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page", Err: err})
+		return
+	}
+
+	// ------------- Optional query parameter "page_size" -------------
+
+	err = runtime.BindQueryParameterWithOptions("form", true, false, "page_size", r.URL.Query(), &params.PageSize, runtime.BindQueryParameterOptions{Type: "integer", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "page_size", Err: err})
+		return
+	}
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1Users(w, r, params)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PostDashboardV1Users operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1Users(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1Users(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// GetDashboardV1UsersProfile operation middleware
+func (siw *ServerInterfaceWrapper) GetDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.GetDashboardV1UsersProfile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
+
+	handler.ServeHTTP(w, r)
+}
+
+// PatchDashboardV1UsersProfile operation middleware
+func (siw *ServerInterfaceWrapper) PatchDashboardV1UsersProfile(w http.ResponseWriter, r *http.Request) {
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchDashboardV1UsersProfile(w, r)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// PaymentListResponse defines model for PaymentListResponse.
-type PaymentListResponse struct {
-	Payments *[]Payment `json:"payments,omitempty"`
+	handler.ServeHTTP(w, r)
 }
 
-// RefreshTokenResponse defines model for RefreshTokenResponse.
-type RefreshTokenResponse struct {
-	RefreshToken *string `json:"refreshToken,omitempty"`
-	Token        *string `json:"token,omitempty"`
-}
+// DeleteDashboardV1UsersId operation middleware
+func (siw *ServerInterfaceWrapper) DeleteDashboardV1UsersId(w http.ResponseWriter, r *http.Request) {
 
-// UnauthorizedError defines model for UnauthorizedError.
-type UnauthorizedError = Error
+	var err error
 
-// PostDashboardV1AuthLoginJSONBody defines parameters for PostDashboardV1AuthLogin.
-type PostDashboardV1AuthLoginJSONBody struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-}
+	// ------------- Path parameter "id" -------------
+	var id UserId
 
-// PostDashboardV1AuthRefreshJSONBody defines parameters for PostDashboardV1AuthRefresh.
-type PostDashboardV1AuthRefreshJSONBody struct {
-	RefreshToken string `json:"refreshToken"`
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-// GetDashboardV1PaymentsParams defines parameters for GetDashboardV1Payments.
-type GetDashboardV1PaymentsParams struct {
-	// Sort Comma-separated sort fields. Common patterns: `-created_at` (prefix `-` = desc) `amount` (no prefix `-` = asc)
-	Sort *Sort `form:"sort,omitempty" json:"sort,omitempty"`
+	ctx := r.Context()
 
-	// Status status of payment (completed , processing , or failed)
-	Status *string `form:"status,omitempty" json:"status,omitempty"`
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-	// Id payment id
-	Id *string `form:"id,omitempty" json:"id,omitempty"`
-}
+	r = r.WithContext(ctx)
 
-// PostDashboardV1AuthLoginJSONRequestBody defines body for PostDashboardV1AuthLogin for application/json ContentType.
-type PostDashboardV1AuthLoginJSONRequestBody PostDashboardV1AuthLoginJSONBody
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.DeleteDashboardV1UsersId(w, r, id)
+	}))
 
-// PostDashboardV1AuthRefreshJSONRequestBody defines body for PostDashboardV1AuthRefresh for application/json ContentType.
-type PostDashboardV1AuthRefreshJSONRequestBody PostDashboardV1AuthRefreshJSONBody
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-// ServerInterface represents all server handlers.
-type ServerInterface interface {
-	// Login with email + password
-	// (POST /dashboard/v1/auth/login)
-	PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request)
-	// Refresh access token using refresh token
-	// (POST /dashboard/v1/auth/refresh)
-	PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request)
-	// List of payments
-	// (GET /dashboard/v1/payments)
-	GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsParams)
+	handler.ServeHTTP(w, r)
 }
 
-// Unimplemented server implementation that returns http.StatusNotImplemented for each endpoint.
-
-type Unimplemented struct{}
+// PostDashboardV1UsersIdDeactivate operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1UsersIdDeactivate(w http.ResponseWriter, r *http.Request) {
 
-// Login with email + password
-// (POST /dashboard/v1/auth/login)
-func (_ Unimplemented) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	var err error
 
-// Refresh access token using refresh token
-// (POST /dashboard/v1/auth/refresh)
-func (_ Unimplemented) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	// ------------- Path parameter "id" -------------
+	var id UserId
 
-// List of payments
-// (GET /dashboard/v1/payments)
-func (_ Unimplemented) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request, params GetDashboardV1PaymentsParams) {
-	w.WriteHeader(http.StatusNotImplemented)
-}
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
 
-// ServerInterfaceWrapper converts contexts to parameters.
-type ServerInterfaceWrapper struct {
-	Handler            ServerInterface
-	HandlerMiddlewares []MiddlewareFunc
-	ErrorHandlerFunc   func(w http.ResponseWriter, r *http.Request, err error)
-}
+	ctx := r.Context()
 
-type MiddlewareFunc func(http.Handler) http.Handler
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
-// PostDashboardV1AuthLogin operation middleware
-func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLogin(w http.ResponseWriter, r *http.Request) {
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostDashboardV1AuthLogin(w, r)
+		siw.Handler.PostDashboardV1UsersIdDeactivate(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -154,11 +2525,28 @@ func (siw *ServerInterfaceWrapper) PostDashboardV1AuthLogin(w http.ResponseWrite
 	handler.ServeHTTP(w, r)
 }
 
-// PostDashboardV1AuthRefresh operation middleware
-func (siw *ServerInterfaceWrapper) PostDashboardV1AuthRefresh(w http.ResponseWriter, r *http.Request) {
+// PostDashboardV1UsersIdImpersonate operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1UsersIdImpersonate(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
 
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.PostDashboardV1AuthRefresh(w, r)
+		siw.Handler.PostDashboardV1UsersIdImpersonate(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -168,46 +2556,90 @@ func (siw *ServerInterfaceWrapper) PostDashboardV1AuthRefresh(w http.ResponseWri
 	handler.ServeHTTP(w, r)
 }
 
-// GetDashboardV1Payments operation middleware
-func (siw *ServerInterfaceWrapper) GetDashboardV1Payments(w http.ResponseWriter, r *http.Request) {
+// PostDashboardV1UsersIdReactivate operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1UsersIdReactivate(w http.ResponseWriter, r *http.Request) {
 
 	var err error
 
+	// ------------- Path parameter "id" -------------
+	var id UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
+	if err != nil {
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
+		return
+	}
+
 	ctx := r.Context()
 
 	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
 
 	r = r.WithContext(ctx)
 
-	// Parameter object where we will unmarshal all parameters from the context
-	var params GetDashboardV1PaymentsParams
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PostDashboardV1UsersIdReactivate(w, r, id)
+	}))
 
-	// ------------- Optional query parameter "sort" -------------
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
+	}
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "sort", r.URL.Query(), &params.Sort, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+	handler.ServeHTTP(w, r)
+}
+
+// PatchDashboardV1UsersIdRole operation middleware
+func (siw *ServerInterfaceWrapper) PatchDashboardV1UsersIdRole(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
 	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "sort", Err: err})
+		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
-	// ------------- Optional query parameter "status" -------------
+	ctx := r.Context()
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "status", r.URL.Query(), &params.Status, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
-	if err != nil {
-		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "status", Err: err})
-		return
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
+	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		siw.Handler.PatchDashboardV1UsersIdRole(w, r, id)
+	}))
+
+	for _, middleware := range siw.HandlerMiddlewares {
+		handler = middleware(handler)
 	}
 
-	// ------------- Optional query parameter "id" -------------
+	handler.ServeHTTP(w, r)
+}
 
-	err = runtime.BindQueryParameterWithOptions("form", true, false, "id", r.URL.Query(), &params.Id, runtime.BindQueryParameterOptions{Type: "string", Format: ""})
+// PostDashboardV1UsersIdUnlock operation middleware
+func (siw *ServerInterfaceWrapper) PostDashboardV1UsersIdUnlock(w http.ResponseWriter, r *http.Request) {
+
+	var err error
+
+	// ------------- Path parameter "id" -------------
+	var id UserId
+
+	err = runtime.BindStyledParameterWithOptions("simple", "id", chi.URLParam(r, "id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true, Type: "string", Format: ""})
 	if err != nil {
 		siw.ErrorHandlerFunc(w, r, &InvalidParamFormatError{ParamName: "id", Err: err})
 		return
 	}
 
+	ctx := r.Context()
+
+	ctx = context.WithValue(ctx, BearerAuthScopes, []string{})
+
+	r = r.WithContext(ctx)
+
 	handler := http.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		siw.Handler.GetDashboardV1Payments(w, r, params)
+		siw.Handler.PostDashboardV1UsersIdUnlock(w, r, id)
 	}))
 
 	for _, middleware := range siw.HandlerMiddlewares {
@@ -330,15 +2762,144 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 		ErrorHandlerFunc:   options.ErrorHandlerFunc,
 	}
 
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/.well-known/jwks.json", wrapper.GetWellKnownJwksJson)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/api-keys", wrapper.GetDashboardV1ApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/api-keys", wrapper.PostDashboardV1ApiKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/api-keys/{id}", wrapper.DeleteDashboardV1ApiKeysId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/auth/keys", wrapper.GetDashboardV1AuthKeys)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/keys/rotate", wrapper.PostDashboardV1AuthKeysRotate)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/dashboard/v1/auth/login", wrapper.PostDashboardV1AuthLogin)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/login/mfa", wrapper.PostDashboardV1AuthLoginMfa)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/login/mfa/enroll", wrapper.PostDashboardV1AuthLoginMfaEnroll)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/logout", wrapper.PostDashboardV1AuthLogout)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/mfa/activate", wrapper.PostDashboardV1AuthMfaActivate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/mfa/disable", wrapper.PostDashboardV1AuthMfaDisable)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/mfa/enroll", wrapper.PostDashboardV1AuthMfaEnroll)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/mfa/recovery-codes", wrapper.PostDashboardV1AuthMfaRecoveryCodes)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/password", wrapper.PostDashboardV1AuthPassword)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/password/forgot", wrapper.PostDashboardV1AuthPasswordForgot)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/password/reset", wrapper.PostDashboardV1AuthPasswordReset)
+	})
 	r.Group(func(r chi.Router) {
 		r.Post(options.BaseURL+"/dashboard/v1/auth/refresh", wrapper.PostDashboardV1AuthRefresh)
 	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/auth/security-events", wrapper.GetDashboardV1AuthSecurityEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/auth/sessions", wrapper.DeleteDashboardV1AuthSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/auth/sessions", wrapper.GetDashboardV1AuthSessions)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/auth/sessions/{sessionId}", wrapper.DeleteDashboardV1AuthSessionsSessionId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/sso/authorize", wrapper.PostDashboardV1AuthSsoAuthorize)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/auth/sso/callback", wrapper.PostDashboardV1AuthSsoCallback)
+	})
 	r.Group(func(r chi.Router) {
 		r.Get(options.BaseURL+"/dashboard/v1/payments", wrapper.GetDashboardV1Payments)
 	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/payments", wrapper.PostDashboardV1Payments)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/payments/summary", wrapper.GetDashboardV1PaymentsSummary)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/payments/{id}", wrapper.GetDashboardV1PaymentsId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/payments/{id}/events", wrapper.GetDashboardV1PaymentsIdEvents)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/payments/{id}/refunds", wrapper.GetDashboardV1PaymentsIdRefunds)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/payments/{id}/refunds", wrapper.PostDashboardV1PaymentsIdRefunds)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/payments/{id}/refunds/{refundId}/approve", wrapper.PostDashboardV1PaymentsIdRefundsRefundIdApprove)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/payments/{id}/refunds/{refundId}/reject", wrapper.PostDashboardV1PaymentsIdRefundsRefundIdReject)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/dashboard/v1/payments/{id}/status", wrapper.PatchDashboardV1PaymentsIdStatus)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/role-policies", wrapper.GetDashboardV1RolePolicies)
+	})
+	r.Group(func(r chi.Router) {
+		r.Put(options.BaseURL+"/dashboard/v1/role-policies/{role}", wrapper.PutDashboardV1RolePoliciesRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/users", wrapper.GetDashboardV1Users)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/users", wrapper.PostDashboardV1Users)
+	})
+	r.Group(func(r chi.Router) {
+		r.Get(options.BaseURL+"/dashboard/v1/users/profile", wrapper.GetDashboardV1UsersProfile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/dashboard/v1/users/profile", wrapper.PatchDashboardV1UsersProfile)
+	})
+	r.Group(func(r chi.Router) {
+		r.Delete(options.BaseURL+"/dashboard/v1/users/{id}", wrapper.DeleteDashboardV1UsersId)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/users/{id}/deactivate", wrapper.PostDashboardV1UsersIdDeactivate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/users/{id}/impersonate", wrapper.PostDashboardV1UsersIdImpersonate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/users/{id}/reactivate", wrapper.PostDashboardV1UsersIdReactivate)
+	})
+	r.Group(func(r chi.Router) {
+		r.Patch(options.BaseURL+"/dashboard/v1/users/{id}/role", wrapper.PatchDashboardV1UsersIdRole)
+	})
+	r.Group(func(r chi.Router) {
+		r.Post(options.BaseURL+"/dashboard/v1/users/{id}/unlock", wrapper.PostDashboardV1UsersIdUnlock)
+	})
 
 	return r
 }
@@ -346,23 +2907,181 @@ func HandlerWithOptions(si ServerInterface, options ChiServerOptions) http.Handl
 // Base64 encoded, gzipped, json marshaled Swagger object
 var swaggerSpec = []string{
 
-	"H4sIAAAAAAAC/7RW32/bNhD+V4jbHhpMsey1D4WAAct+FR1SIOia7SEzZkY62+wkkj1SSb3A//twJG1L",
-	"tgxnTfZkizze3Xff3Uc+QGkaazRq76B4ACtJNuiRwpcz5Pm3QleSsl4ZDQX8aJpGnjtkW4+VYCsxV1hX",
-	"biR402hhpfdI2hVidl4Sst1f0s/EC0s4V5/F7HwmvhPs90zMZGNazZvaiN6+dOXZnxoyUBz3U4u0ggy0",
-	"bBCKmFwGrlxiIzlL/CwbW/NWJyRk4Fc22HtSegHr9ToDQmeNdhhQXpqF0u/TCi+URnvUAbm0tlalZOT5",
-	"R8fwHzoRvyacQwFf5bsi5nHX5dcOKQbrV4/Qt6SFN3+jFlJXonVIQum5oSbEgXUGV3LVoPaXyvkvSsyS",
-	"sUheYSI1eAv/lcfGnco9hedMUvEkkVzBerdgbj9i6YcApsOCk2cP73FO6JYfGPAzgKGOO/7eYzcDf2Tn",
-	"MbkncjTeC1mW6Fzkid1ea9n6pSH1D1Y/Exl6BIbUkSHvNpxH7dkIqwCxbRpJKyjgnXJO6YUw3Al3slZV",
-	"CpzBnazbVK8KoXg1nmTQoHNywVCu+14L0RzzFNA+rnUjvIHyXOxiKaPFXKoaKw61iVoSVmwgawe7eAH/",
-	"tmZ9OiOqzvAGgIkopT0ueIw6kLtz/nj02UA3EH5qFTEVNzGNXZTpQa9sZ/IQQpSvfmayViV+n75HpWkO",
-	"M8igo1LFA0QBgAIq6fHcqwaHzqiqH2gyZNQglUu5n9O7tCouhs44L33r+ie4N2pkjc+EJcMDwdXNuL6R",
-	"/MHCHpQuaOFB3bCRqh4c4ZMzTqbGpw5/Bg7LlpRf/cZdGnO6RUlI3Oe7r182xPz6x4fNhcOe4u6uAkvv",
-	"bZwaVvOQhPKx8qs35lLqxYW14uLqLU81koszNRmNR2NO3VjU0ioo4OVoPHoJGVjplyGrvJJueWskVfnd",
-	"JOeez2u+tEJNjQs8c2XDWL6tWISN8z9tDv0+YUDhmoPY9uj8D6ZaPUGGj5NnpXP3hqphFrpDF310TkwH",
-	"JXp3xFOL+7f3t+PxMT3b2uX9K36dwavx5PSpQ8kPXbNV7eBV3Cu/FAGK+EZsobDlAG2ps/8TcekCfTbq",
-	"TkzXHkk96/+NoMFHwnPxlJz3rnTRBilL6LZ35D5p3afTAgf4eoNduq425lnvJX0zjGBnkofH7Drbf2pH",
-	"URZmLlIi4sVpTT479mCOCt99BRxQv5/AJqyqjjgNG8cdTr+kGYZev0/shaT0gYquxt9MOcXOSCvnO+V2",
-	"6dmEdLchsqU6aX2R57UpZb00zhevx6/HsJ6u/w0AAP//XUX9+lENAAA=",
+	"H4sIAAAAAAAC/+x9jXPbuPHov4LR+725ZCrJsmMnF3s6v+fm4+q75OJnO73e7y7PgklIQk0BPAC0o8vk",
+	"f3+zC4AERVCmZDltrp122lgk8bHYXez3fuolcp5LwYTRvcNPvZwqOmeGKffXlMH/p0wniueGS9E77O0O",
+	"rqhmKYGnRBTzK6Z6/R6HR78VTC16/Z6gc9Y7tN/3ezqZsTm1A01okZne4W6/N+eCz4s5/tsscnifC8Om",
+	"TPU+f+7jt+f8d5y/bexLzX9vmWBv1O/N6Uc3w2jUYb7FnAlzksIw7COd55mdZnE5Gu36DebUzKo18LTX",
+	"7yn2W8EVS3uHRhUsXIybRRvFxRQnUWxSiHR5DjW5fDJ5TneTETu4epbu0f3kKft2MrraTePzlsOsN7uW",
+	"yjSP84Wcz+lAMzh6w1ICb5EJZ1mqhwQeSkFyagxTQh+S8SBRDN67pGZMHuWKTfhHMh6MyZ8JjPuYjOlc",
+	"FgIeCklqz2ntMcyjydWCjJNCKSaSxZhMuNKmT7Qk9i1NuCApn0yYYsIQ9yJnmlDFiGA3TBFAYKpYOiQX",
+	"/sGVktdM4Ng8HQ9/FS34iQAJYVadSbDNXj8Cy0IzZc/x/niBiKFzKTRDsjvO+Q9s8cIu4Mw9gQeJFIYJ",
+	"PEOa5xlPKJzhzj80HOSnYIZcyZwpw+14NOeX12wB//wvxSa9w97/2qnIfsd+pnfstD1AFJYoZuoASfNr",
+	"xNJRsssu/2f+/Obn+evFz/PXT5O//21/OBxGoVTB4JdyFeXwH8ov5NU/WGIsJOrIeTGDY74l12xBqEgJ",
+	"N5rYz/vkdsaTGeGaCGmInslbQeiUcgE7sHt5w7XZHvzw39ywue4OSbdBqhRd9D5XP7Tv+Pj0BHar+7Bv",
+	"po2lCRjrlVJSbbSdVWvFUWMLOWO/FTB/IossRRhfMSS2jBmWwoJeS3XF05QJO8bdK3LYhPCb+I/hD13M",
+	"51QtYFaZMZyMZpm8ZUBINzQr3H5T1jvcHz3p9+ZMa7ycego+4Dr8hhhJcqYmUs2JmXFN4DBxJXaf9wQM",
+	"4GRCs4ypbzS5c3oWzt7vncxzprQU+MMWcJN9zLliGtiUBeoc/tVLqWEDw+esSZb9ngHu2LwIjpOEaU3w",
+	"KZlIhYsHPkduuZkRKsiYJmZMkozyORF0zsUU39FFzhS8OCQXM6YcPIhiE8X0zA5omXBjKfDVXQfxXjN1",
+	"quSEZ6zBVOxW+iEY3KBduMuxIDTcNE0M7Inqcuuwxu9/+uGcbYOPrMVDvv/phygDCXePA3bZ6Pfn734k",
+	"P7Er8gNbkHOG7OSNnHKxdXbyHoHWXIFiplDCw1mkFrG4sBjriOPt6+NXQsksmzNhtr602uhRdCAX7y5O",
+	"3f0CVExTJGYqCC3MjAkDE0tFaJ7Dck+tyPiSGcqzrS+3Nnp8uU5mtfQJV+OMayOVvSqtkKh7/d6M0dRJ",
+	"9K8u6LRJ+cDR3FjfaHLDlOZS9GHrmomUXNHkGohifDIZvKUmmY17K8WZCjSvbpgwW7qF2Y1XUjrRT7iC",
+	"OwnJjd2JZwSQcuDuE5mltavaTb6lnc+ovpxLFVHFjCoYuZ0hSUkzY8oqZRMJN5GuGO6VlBmjSGOCfTSX",
+	"SaG0VM3xTqnWeNL2hTGgwBRoAcWwjwaHPyL0SgPaSYEPMqrtgxiDd8Ba+9w2E57cxwQAH5zDQ9HmSvz4",
+	"5xDeGUvkDVOLFzJlegu4p8LxaofYFCvWPq53wgopxM9CQMTTR4BWC9TkrFgvRbYgUiSshztEseICbpKt",
+	"bLAaLr6tliddNuhuPdBgQkHDbaMQ6ZY4hOf1XYnMTn4nW/TDrssX3XdNvmjn3To5+u3EVqXKrYJqcSoz",
+	"nmxLL8xhMM7WAHu5gs3oBdiFZkmhuFkQnH1B5IQwJB0lM6SP8/N3x4WZScV/31zJWLWL5QliK/0J9QDP",
+	"x+CWuFLyVjOFv/GpAMMOii3w7CQ9xZW7rf0TpYbaEpqH1C9NossWxH5gkIw+NtLQLPaoy8H7ZRG7naZt",
+	"4JxpuDq2BDRtR1sHbPjBZljtPtaAyoAN1spnSv3rnE8FF9Pt2XPW0sOq2Tfcnf3e2XRAw7xh8EdwduUM",
+	"2yfVYPEx5vj9TxdEVwskj/LiKuMJmTNDU2oo3ryPYZEXUr6lYuGMQrqrxee+phYpyZyKBZlQnrGUUGPY",
+	"PDf6iChmQM+ZGGbtFFN+w4TzRgAiaZbIhvZzBh8NjuGjpix2br8ABnVLOVi7JlIxYtQCbQJoWYxIXxUd",
+	"w/LfC+pYI0s3sYoVItA0WVq3jb3lWsNaJCjONzTjKfH2j4aNbDe0kb2vj3pI5m0jbcM+dlzNxaXwRydV",
+	"OWuiWAov0EwDaoHJYFs38ta5s7UmdWcXNWPVRvZfq8LJCUmpnl1JqqytpASVG/1BLDeVma2pWtlHcSZd",
+	"Ik3gv2ieTuBS6Wyr3MS+ydOoIJ/RK5a1PNHmstBrLk3eCqYu2Zzy+Kj2ectirGMsooFbBpxTZTyor9kC",
+	"VULDsgz+0ITC417fc44l70xsrYrdyOs196cTmbN1nR7n8FEXzO/3wg+ADQpwzv5SWgwOFaNpr7IgHN4q",
+	"bljvQ2SlJaddwjfkhYETC9lik8JLRhm8ugbPvMvxhcuoZvkQAQUYexvLp9k0ijqJumnizbsfToEmb1if",
+	"0OyWLjR5le4dHOw+jxJV8/uz82PCPtozjX1y3YLH12YRHt7Z+XGvD4uJnpOIzzuXaZEVusU/EJ02Qjlu",
+	"v8SJMNbVuPpkYPF2a3amPsI8dkB123HjqKTJAVveK95cl3t2uLND3p+dACErJlKmwLBDyf89Iw4/KtSr",
+	"vjDS5DsvC8WpyOnif++NXvo74TDR/8cwbYaJnP+3tVj/eTgc/lqMRntPudYFU38uP/xT+VmUzktvb33h",
+	"f6GaPdkLbeJ9dAvNqShoRpgwKg7iBvR+ZLfeYtbEcnT0N2d/yRI+p5kLBCBTvDtA1qOC/M6U7DuvlCFz",
+	"qQ0AEwXF1H6mvRPHxxaQGbzBhVSkENzoX0UN5Aej0Wg0HI2Q3xjDFCzh//0yGjz/8KdHv/46tP96/N//",
+	"FQOgn6O5iZPzd2R/b/dZtYzGYZ+8PFua9HjwPx8+PfkcnWrOVDKjYsk3fyGvZc5STnsY9PKGiamZVWEv",
+	"5d8xkpTGnkP9u1H0DsEwjGSJUZ78+LfB3mjvYDAa7e9FFrCaBMsN9T0mBPCMUeJ9EclizZrYckS0kQoU",
+	"EA3eIHd3gGtc4DXdCbEijHx9eWgLuNYYc/LxUlETuRTegd01V0yXTqaxYrlU5tLPMT60u/ayin1cLSFn",
+	"FizwPIjzAVkLeYkfzwUFLQFv9+newWh4EFvzjE9nGZ/OrG2HpimHNdPstIYTjc9W7W/GBNGMqmQG17zd",
+	"7m/jIXlLc00YTWY2KgpYkCFzMMezlDziaZ94JO6Tkkb6BCnrMfB7gM9fL96+GTCd0JylxIArpbR+4VA4",
+	"pVSpJreK5vASF2QM7PxJMqfqGv/FxsTQqR7WwfQp4Aq95S/OZzJnzP66U/1M3tIs68V4NV8KTtvtzobe",
+	"ul/JcewbwfwxNxGtDArLmNZkbG22QBn25xpS7K+gqJKb1Yd/rRgbYDwGvnBE5nQB0SxsnptF7y5WVx/L",
+	"b/IbTcA1Ub5ZRkw4ebUxSTvHjE0f7j9iqyjmnuJonit5w9LK6M5FQGp1eloBuhohbsgKcD2WzSZS3DBl",
+	"0GaDvytqGCxtIlXifJYBtMgt1cTxwz5RErdPZjSbEHpLF2Si5ByvfVSEmozmm5BdD8nxVUXSQtq5YQY/",
+	"/7DJaPa//fbZ8GAVaNpZbwfgdOHD2lBT6DpNlQFXpE9yJRNmlZA+qCHWshIbyXkSmyv9TjJNihxCI6Vg",
+	"RArnPACUBs+uqTkkx+C1HBOubRiVs65xQX4rpGFLV91uv7rCuDBP93v9DrbuKoqCLi7ACBMh32MXCyon",
+	"4fpKfIFdwGZSurDIH4H9smLogBq/I6J34cXMzfDzzz//PHj7dvDyJU5mfq+f7d5o7+lgdzTY3Y9etfbI",
+	"4hYBf7rRx6WFKsDavXbpYknMwj35QfoBAGrzlutbIXi5eBTUT7N3k97hL139+e2umVVO8XiMRX8bQSD9",
+	"0G+6ag1xf2Z/q75WB43+Cqfrhw0jgJbicSKic2JkYMlqggJf+EYTfMVzdPTce3Mg8o8Yxtuxedoc+D2G",
+	"Fs7Awp+yYJQje2Wiw98KWzZWTJOUIdIOyWupCC8jKfHys36Jfj0gkVyxGbcOyHlLBOImQniLLUSw28vS",
+	"EL90YSNrD/wVdqvkUSk3w1GhDOH+afHhcY23hGTbmF1m6R2ze4/GGtP7s0BZ3YFqjMjlhLRLB3mWjuPw",
+	"dRjaZgdVjDordYsUFEWdi3LN/eaio1erLFRMmvurvLVSC2DpMiL2+qVdKw0MKFUgOw+DeaMWL/tDZR9z",
+	"IOz5y/7SzpSWdF/Bs/rJi3jhS8ARWBqZc8UVa9Hgfe4vuOUYEn8Sy678hSUqi0Rck2rNFWqeM2Myhrwo",
+	"kWLC1Zyl5Mp+SRNkc6puHDjoYJ2oJKJ4WoB9PiQogFEyru6ycckaEyrIXIJh1EgyLkloDBLU2N53YxB8",
+	"pGbE3EoMO5pwQTMnJHqzdHhNhoRYXZmdCHWJ7bsdloSw4uY9947ATx3EmcC8ndKFjsmszJrwUEdP6cLe",
+	"IZXc7sWse928lVQXuX0bwlCwaBD4I4ocLAEXa72i4GbtJpGNng92o4aEqNgVLCQi/N8pyBvZXPobuuHK",
+	"W2XJUiKsT/Rj6QP3J9iLOT0KUR51LA7UfmlxwmtQXAAFLUvXpVLVh3hploJ6ASjlXwii+bjV+RIrzl8V",
+	"BtMmYOeAo/YC6eZlrRaP6NUhkq1+ig7B8Ki6i8WOlurAi1Gsk/lW2Ci7KeX3tRDeialgAE3XnMB/c7Vo",
+	"MbH5xyt8o3ytFMitChXuenXLbxFJy9cIN3fKo3GbQTXPCji0XXA+bhFmGVciFimE4RnhBn/3coG9yrxM",
+	"MPaZcdVFRs7K7YDotmwyIjOZpTWXs4Nv/QYMJZOaSLKWLOJDQOFvZ6Jok0MC7DVsjgYcMByvK0Y0WEHr",
+	"RevXhvvc2MPAxZIZpeKEwE5lYQg3fWt4MTObREXxRDM2MdZBCOtYtlHdx0UVA+qLQhs5Z4rYKGU0pFiF",
+	"7lYSy4YfDtIyq8vFMFeVKNfvlSpcVKwOAmkbpzSf0MtqFZ8iKRDKTX5XqG5zQ/Bjvz5DbHeNCNkmKoWP",
+	"36vINX6SnmJo3oALGxEUD6ZtsyPGLNeFdn6LUp5nPvwWh9Y24aC0sKZcscSQ92dv7k7uXd6PX0UUPLUg",
+	"2/WtESVulBaJ0pyMGiByCsUSqdI4c263SZz7ofEWoImxPj/45ZI7ZbOm9a3QOTe5vNPSutbV6MDz6M8u",
+	"hLfthlzWS10WxCVGtFwqZqMhaju91IYqy/2r31mpsEbpFOFGp+6Y449XHHTXMz6yv9nk/2uWm+rlpau6",
+	"PQs1DqnPUfTVOkrUm0trpiW1y/uU3Gk6ukR4IwDQYAGXSjTVayXGhBfLEP6zG3VFbRYaVz/3wEMof+dZ",
+	"RncOhiPy6C1NuDBSz47IiTAsI29pQt6dk7+T3f3Lg8fdQkuCCOcYI+E3LH4HuOCqKm5p7+Bpr997lb48",
+	"P46i8iaHe90qnxquyrGWJD/ruvInXwWMHxH3GcEYQMyJumGKTxY2Dk0TDPtJQzOfksZfqF1WHANwQ9Xq",
+	"LBYFTkqviX7j63qs9FLujVoccYmfqKnOxrWe8+9e3h2eV+mEiQtAcTuK3V3vXcJ6HQTtAv58QsOU5kos",
+	"aeV2WFHALVCTvdfHqCiXvAwiU4QUjCyYOcI6BGQHbt+dDPK5d+YTusNwwiDAYEIxvc2KECB9go6O5pyQ",
+	"HwbUMZ/Q9rUGSacuzJ5MrP2UayIYQ4ZcJXqHCXbIoA1+atGcOoEGFw/fT7jgGgIrbjhd3lj7Yi/iFQ3O",
+	"Z1KZQcZvWLpU1sCt206rDcvjQvNS7mOn7MW+Jc1SqC6vIjtZ5dClguBJuWTRromV/btTFr2Ae49cxlqs",
+	"+SrWWofJhGaaWU2ZBm6YlOEH1DDtuJonteZxplznGV1c2lo6ITUfLwryhmlDFY8d113WhsbPmUxoFtnE",
+	"X16ckv1nJKNiWqDkTac1BsXTwcnL2ApAMWCCXmWtZONsCAEtV9ZyakMta6UGohDyx1utKIkKoICcv0sR",
+	"2eHJ8Y/H1n8HzwmCOtzhseZ053t6TZWhXa4JG0WKQv05KFBl3Rx3L3OY1CbiVFWS/j44Pj0Z/BDG6dKy",
+	"cM4Vo4op0KLge/vXa3+Fff/ThU/FQdDg02qUmTG5TWHgYiKjmT4F3KOlvqkxVifj2oWqyIzpsJoLclnw",
+	"IXrdfvxxgC+NCftomEDZ7NE40eM+GZejwh8lCYwfD8kLLBqjfTmVBbEp+8jwp8yQ8f7oCSnr4oyHJLJQ",
+	"b0GoVgAsVeaWu9tgjlrgOmLa8FfxrhrDBbBB0BkjScZRdAYI4D5ppkHzSVhuoTG2hzImFt7IsktQ2RwB",
+	"QjF7TDCWYozN+OPAPhgPybEgrqQRyDIYaYnxgreCKaz0BcPgX/7um4OeiqvIqdbVTuubgFg2lsJTIQc1",
+	"ReWQAKmN0YqiWS3xupKRFmS8A5DRO594+nmnGoCNXQTP0mHYy4cbK8cuvpNvqJge5znsrhdE3PR2QZpG",
+	"h2zOBM1577D3ZDgaPrF2mxlSxs7wlmXZ4FrIW7Hzj9trPfS5OtNYJPZy+Rby6Oz1C/LsYPfZYwhP9Bo9",
+	"bhHCdq55OiaW3GzimjstFBxnTLEheYUmTXs3WcgAHoHBgaUWAPDBX0EsdsHfFgIlKkLxs953zPzEsuwH",
+	"2Mf3t9f6e42yZq2a2d5o1GZsKd/bWaqxgwylzHs7LSP6He46sdedbCBkuL3g9zulz3bnZneH5nzg8z4d",
+	"jBtbKaPk/7Zrc1P0RnuJ1B773O/tj3bv/rSZPohfPrn7y6VaXCFHxhCdkJ/+8uHzhxC+sFRS1R3jIsmK",
+	"FOySLmsIIWwTsdBGCGBxRIkuzMpUB6Z5qU1cvo2yAPLIlOW0SCEw/tMnToE8iHmdj3EFYCDlc24sT66S",
+	"Pi2rwdpTDlXh1boQBkh/ZO1nvLTQlheQo5UYhp9K3YYXqIb/RaaLL1y+q8xjWzPUf2U+2v2SveZcnNjP",
+	"du9wxNnFl/PFK0vUSyd+bhDhblciXK6hiNTUgYTrpfa+PPXCZ/trL3MdmreQAR3E386gGtElsaCd0FsZ",
+	"LN6nlgFkzJqh6xT1En9v0tSJTfar6s/+so3amh8ayLPf5E7AAhyv+0Me9hnuLTjsdc4VVPB1bs7CzDa+",
+	"OltKPXwF1yfcJ0vVFGLlHjaA+w4a8awO3nq35ordcFlonIrr0lQIpiNtIB3U3XkgaOFtes1Yrp0kBQt2",
+	"UqB1MFtzhZgybewDkvEJQ4URdFaQyyEM80RoQ0XCNNEzCgQHILj84dXP55cvT85IzpNrsDSZoIirt4Y4",
+	"gBDNGAoD1zztcv065DqzILkfiv2T+fvzByX5c0OVKbHRHa5VPP1ZrIuMaL9qR8PX9RIdqE+glYelGA/k",
+	"YoeF/cveL+TkdEiwHgehZMJuMaWhUMznWxUKNWQ3pvPj0AVLy1xlTrNs0cdhS9sKSomWCigmaQUmJ/JI",
+	"KnJy+hgeZzK5dnloFGImMjYkf3E/ltsAxXx/77mHXVBCZA2psTAzLLe5Pbmx1dYFtAmJZG0FyCtRzI4R",
+	"fLGZMNaB5uqlRu9Hbnsd6CZarKauU+KS7KFaxPwTKeGwCv/BDt1OA+NEpgwTZhhHzA1LZTwdpHzKDVqJ",
+	"bUITPG1UFiVSlYEYYCgUmCxZtzEPyckksCn7I6rsyTYOHmdyBkZdsuGlGZ0F3kPPWl4SqhR3xtrlqX9S",
+	"UkztHy4Dlmpf5cWuxxPPOqTxdkK3Rh2+8EPMQHvRbgKv5SX7N/t2tH8n2niNPhhCSVYRiTNOS1VHhztp",
+	"xTmjQpLpjA/WcbY1rNjg8B/s1OOVju8piGwmUZTH/h0z/pidGSUtUKjziIC2t9IzWfmu2nFAFqadWVqt",
+	"RJPKAPSNrhvykDmlTCzA3mxfdFmWLPXWP/tiGRfpDCpD8pOrWV6P1rFvo20Id2P/5rqcBX2XSzFGkOfr",
+	"IzBQmO7O1wAAXTTQN3I6ZSmB1++BBGtpLbg4ZzxF9TC8q9rtqHCyQNfeobcWZb+d0GP/3QMz+1gRngcj",
+	"53jV3X+Kten5JvPd7+LobHOykkhM7AEhpFACS0rfyoGLJ6C1SnKoryw7e9yhteNpyjU4ZNvZ0I/otaIl",
+	"6kfvuX4pJ3kp0RurQ90CQrIgLpoDq0C9Q8liOiOQRZBZ78pAWq7mwzi8OCVFmzSlySPNWMmClTQm42L6",
+	"eEh+DHpOlFEG9c4UYRxJR671dkJfOphtm0SXGg+FAIdXCGJCVOKNJwF01XTuQfwRXn3RhqDE4Vr6NdmZ",
+	"vwzpXyBxTybbpu6mdLksYwBb0c7oEcg2Q4yssqYxWJcVIOidyhkPIzaqGKXwRkSBmQuyOyJzLgrDdHfK",
+	"C0Ter1ikXNNIxQy2XSnybWOHZyGDxEdwrSOp1C71L80Le/2H42lflUDzZfjTGcszmoDxI1uyd6yPeeG9",
+	"1MaV3K0citz+syE5zrLS+mM1EF+QO3TOh99KARKKYt6LVQbWlGFmRoIEAV4ADAjpyJFO/Va2hv12waft",
+	"VzeWUQifx5JYmOtJUgpjthj+ITgwbZtHkqEnvI+/fARwJjOqaGLQ9ky1ZtrKdNi4i2AkDrnyBuPyLBBM",
+	"gV/9227RxCYAXLidrckgfsQyF/+PS5ovcIeNosPf6PKUNifRnYlUU7nCRnFsa6lSoW+Z0mRvtOcpi4k0",
+	"l9zWF3DN8VBsNRLEQOQgLg8T7cuazOhNqSm4aJWMi+vS1EqdmjAotAvlIrbKLPgnnlSyhE/mtB4WHxGz",
+	"ysOyHq2/tiB5aE9F1BmxGX3sxTisZsYBBAPN+aSmqbGPXBu9TaPpKwv8iiUpXAIcce8uLMRX25HwhRS6",
+	"mLvrIgyst/Yz2npdDNc5dwTZ1o7962LiKyPjY/0GH5apW3zYkKXXsPKcGaf7VHYLbW26Fj992f8ogjpj",
+	"7CpBJgy7dCGkaOsoNASZghPXDeJi8bwqVrfzOvTVdF7luj2qsH1C5zxbPK7iUrEX2ZQJh9quMt2QnFrr",
+	"sN2g/RbNvGCYoZliNF3YnChWkQ+MdzuTWTWzJa4Eq3FSEkmNrJrwYN5hHzadMDA/zxkVIZ3eYoR/zlnX",
+	"6AYH0a3R4R25Is2GT9XbD6hcRDp43dPsHUjyFrHq7gFtw1kDnGvDeX+0g6o8Xceop1pas24Gs8X2Vb2y",
+	"g70rPvc7vYeN0T9/2AT47U2WvoIoK5/wS/TKhkhrBraE7Y66Ry3iibsPu3D3dxgQ4OfaRrzhBiGANkHE",
+	"Mzr2sczyCDTJVeJ0vzsxtIGmE442u1l9KbcY13WALIlUvTtwaOeT+9fJulGwAdDO/RCdImJ18PaWA2Pd",
+	"QrYVHLv/8B2j/IqFhBxq2xVwfTIJ4m/a8GBtnVNruVNC5W6LtS/M8f7sjY1QE+RdzsTJS/JCCsESQ2o1",
+	"N5zhOpO3Vko6/eHFK6tk4gOM8+RMeYu39RVppm5c1+6l+B/NDGqkQQLtX43Jsc7eWGsJxSAgVSqR8poz",
+	"LCAEN+zY/uwrEHl/FJYVSWYsudalZWqiEA9Sm+7lKptgzJ4tb+D9beX3uK2gdRQowrDkVJP90b4r3153",
+	"tLm+7GiwnxaqsxB2ruVxeVIbMbC2Hoz3CPZesprT5c0GwToINznPIccQeyu2Y6QHbztCvvpobUy6CikL",
+	"isaYWLkYRFirE/QJ+0gTk0F6JYSgV3oIrtfini/SgQTrqkZyhVNwPG6zINLZN7Dyta/8jIdOCyMHpdsV",
+	"0BDTfyqXLYwzVbLINbHFycoszyF5w8W16+qDg8K/qSjtBDalkQo7dzkaJB9iHpOjq3RIIF3aRdiCZ8ml",
+	"AeNqb7G2ZN0fy00fQ0upIG9fH4Nem4Gyy+JgCp3NJ+kp5PjRufKt+IGjUi40Gc8nFIuBcQNvJGpsQVEx",
+	"s5IScFYsF6uHnmzJvF4hKELompmyqqenEE+y3YnrhUe6xvW2zMzbZzuqcweuXb5nGhTZ6vXtfWkXH9yY",
+	"fl+9O2/IBw1HLAs1dWog1VZQ6eECEf/QmVLNcMcYQ43wzrCleTRd95ROmTWGJAUiMKRxMOyRzssUZSta",
+	"aKnw8rSkraUyjig9xbrm6jhjmc6Il2iOs8B9DmStuTa25QHPghL5sAisytmSvRvQ5mlVLXQ9xRVWjYpr",
+	"HQquXnBVh5Q8uruZwWNPsr8VTC0CivVlctvJtbEAPy1PWwbl6XoDYthgo/sANbB8X9mba1tXATO0nzx5",
+	"8vzxckXXg8HoyWC0ezEaHeJ//zR6djgatSzRVx1y1UqrxXYr57PODsqyQV238Oxi78nhwfPDg+edtmDk",
+	"1jeAAVFck0bro6MqJN4m89uKPpdzLmyxTP83/bjcc8gWSo1upCoUtDHSyAlAO2MUtUyug5qRVTWkpdZm",
+	"aMEMGp1UTW59+6ojsj8aVaUhyoFivajajqmCUG1/65aa7AaAufxy+1+1Wfpxy5tFYbfsB+ULq8SWEDQ+",
+	"WwObXhdZNsD2UbZTFUE/Y0sPKu8aARHvFRp+UKKcN+pA2sKv1D7mopIYpWa26ZULqAbwUrymYBpdZMBF",
+	"5JzZg1IsYzdUOCvckPjISqtmkquCZ0Hbq7H+LeOGXU6MPsC+Vke2Hsot16xW6G6C7lP8bn80Wm7do2cy",
+	"bwHxbzXYBtnyex0qmS5DvnamxLZv7ZOEajbgQjOhOVZEWl4au+v8L+1Q9aWut7SK/aFyJn0Rd18OxpOY",
+	"kX3fu4M5pW6pLZMUNvEuqF1blr0bkrIq+DVjudPRwOPmJgBs86RnPTRONaxqymPA61LXEiGXFmFDY5db",
+	"w6HMU41kf9VEwB7RO+im5kwPO7P0ZlnwODNob8kYEzumjGhrOYjNiR772kwpm9AiM1BhFwvuguuzKung",
+	"/oo1U1qeGoB+6aRKX3nPJwbDsgD2/jFw5VsJamxJkSh+custc3VyJlIdkQnPDFMarEFFllqRs3TXGSmH",
+	"rXzWTtZbzw7ZQetwmPiv7b7oVxWwolbmeoX+wGOxuhiyr63U7JW8uvyKJ7opMxVTHjgnKksJt3Ve8DrQ",
+	"BC/SoJ0GFKMS8AYZn6RsnksDBDPAwlBGkjm9ZkQxg5Esmk7YoUurxZZZ1LXrrzANMKey5ZFHvgiFVL4m",
+	"n7WzPu7jz7CwK5ku7OLDvnFehoXfbEa5lV2t2Fcu1Qwwwm/BUlcd6jHhQhtGU3vzuXqAbtTKdIQLxbx1",
+	"mwSX8gleriZYzv7ouTehwhRAJrc6WgLc+CYqMLyrGNXBatKumS3FiWDEzyCBaxtMRvy3gpWlPVDaKvfH",
+	"htMhoeT9+5OXNVa5f/Vt+ix5wgZ7k1062GdPrwbPk1E6eEYP2JPJ3tVu8rzUppbruS3hRevle3Bw1+V7",
+	"D8vLKqdB0Ia4e5mZrcxcm7aVNNGLmS1KnJbKoRQWXbbgxqVBW767epY59OqXdcPRTEY1GZ9MBlgzbLxa",
+	"9OxFiCeS8YzUVKWflG4E7pOHkSaAyARhVGWcKS/ZrZz/89dkgdp6mPyKu+MMfeCE+rOu3x3rXRuupX67",
+	"jWtHV02HorauF1YMi/ZlxDSKMfzvuEyFGhs5BpNYwAhpmmpXJoSrqkCws0eUvfdcm6Ih8e0gbYEJLxC2",
+	"NKdp7z4aCrr19qNULwufhOujan9xsTWsdwFlV8pmOAV2ex8HPWPGrhqLk5/9akE8c/AmGECqSx34ydOn",
+	"sHntC6ElNsTNZq1UgEeZvrupz3eUuuNeaegXNE2DXq94eHxzkbu7z7p/Rx1T62hBONkQLIO9vRWz7h84",
+	"mMUReX/xAjDQS90r6p7G1m9+X1NjL1tWASP2vZ/6vt/TEYT24oodvnsCiS2v1skqtrjVZsIu2ssbunq1",
+	"RsLDVevz/aqiwJNrra5FN9jmlexJIHIzuzc8SX41LpHNrxa/Y9egC7PvKc8WjpK2paW03za+dlz0qgmV",
+	"mLomMbZRYLXGlHrs2uoYXXaHqHqiluH7GmvZlgEL9JpVw7o6wKAU2RKTnRlrLHLnzrA+/PQk7d1LJbbt",
+	"ef9opQtX4KytWhGVhR4KQ3caoaFLERNodoOKackiyXzDknorhHpXxUNEUxuA4AKQUTxxUpK9151ZvOyY",
+	"CyEEM3mLb9/OFoF9rgyTDFpB+MbAIOlgixS0AIGuGnQ8dR1FMJx5HWzfOOR1Kzj/rxDK+qXRHrZLaLNP",
+	"9heigKBxdoeA0ApRztx3XxpT7Lz/7jiiSug/qLWxSlAjkwKSkxQ2FwRDn12BtbmFvXh9w8GKh3ERlMh3",
+	"fBBr8Y2eW/OcliSVzGVoFCJ1mR5ooi7vcbW68WFOtVmy1FmdZg2r3PaQevsWr3pDw23VVvaj/luECj18",
+	"FYQzH/Hq0LI7eXbk0juf7D9O0s87jgRWZLzaFyxdeeLxvdbLsF7hdQQjb6lKl83dTgYHv6E1oARxnsvt",
+	"VX0CrVsZ/PJof/Tk8SYkeOb26TZxH4q8OxHHA/VeV9J/6KFBD+7wWuhhe6RgG9euisSH53FC0BIlduf5",
+	"TqiA/G+P9K7EwsbYayf+osj7cFeP7/O7xeTB/1w+27x8/oG5JNuktaqpdQ5+pkj+t7tgQgdzadoBM34g",
+	"GUK0no0NjTW2rpxZNqwpoUrZ4IQxOMnGS1o3mPYxohYk2CMoCuDCHVwZDav59lvDj3b3fEzVzOUZuA9t",
+	"WsutdK2apNL+WgM7PrpZrEaP8uw3NhGh1f0LG4oyinMfBnsfzrDKa2ihFoRPPtrf+zaIqK+Hgv7a2/21",
+	"1+oOdifzUDH2XYy7CK73OZqXt8WA3Nj/4UCrv9rd2+SrvW8ftuuJDXoLdeIysjzK9yK8Dt4aYKkKzroa",
+	"QMp+5ZxtlhFbDvBVNsbwX7gKH8CUbWiia6jeNVu7BvqdT/AnugvyIiK+YQHgsFU70cz0UQcpsytghJK7",
+	"QdoWXiJYVNAXP7ZuWgxnxMgka3xwzB1rlsJ3cjIZkldQVAa+KZO8fT/qKJcv2jDkzELl7sxfB752B2qH",
+	"Dvcftlhee2Xf/WaN7VVN9Dsz6+0IqyV5tYXoFHiJ+Bo1fxRfYI1c7T3ZRrB0XWKF37vyR0ye/FetmAGL",
+	"+9q4bnkSlt91adi3Ulf1B7QdVrHc4ne5kd0arX3zr6nIlG/c24Et39GqxY31YO3zgubPX6Wg+/yLNM9b",
+	"IjPf2JdwwdHbUStQuAbX3Mmrvttduac7rd6mHC562g9fb+U7Fi234gGAFO5sCKuV5AYQNmNUNE05PKLZ",
+	"acCysK05knf4627/vnyt6kC+snn33f23t6Parqb6hy3cciJstUt38jYN+kthYSD6xBERE75sVl2sBFDV",
+	"SXqtmj+IsxvECsF0cb9DpIbPe9uAH6b+Y3a3tGBtcuN6axOu6rV71mHHaNVM2dotT9z5vqy+3N5Jj/74",
+	"l/hDo40/FUIfBGGCnvLtTqY3LhvLj0k0s3yoQmaqq1LemmHtGOtOdZ05qTZVoPjJ29NXZ+fvfjy+OHn3",
+	"4+XFxRsnutp2ngqCJESjwZHbZz+oZexqJWI4vA059+VVrhZEdmnDPx6SE60LtJPb5FBn83F2dYw0Q4kJ",
+	"HitWRa1RvVzfD5Jufc3zcuFDcu7/6WJVyxN1ak+wn+AwulSBcpR7Un11P9LdTilP6t5YMnTNFhWCcF3b",
+	"apnppYs8l8oQw5NrZqoc8V4/lFIOOiRGLxUMxTU9WFGakxCj/sPEWqJfwzJttbqnNLEFrYA+bCQV8q32",
+	"2nUbMTq18c149q92M/7hGn8Hd9wye9zsVvM2jBbXbljOTfOpsE3tKot/2UdPYhloVzi6ctvCj1iZ7U6v",
+	"qEehqLH8i/PmTS079zDj/EcC3JY30IlBmxi4LVEUAtpGr8v93tuvHlT7s32OYR4gwyQDcPwx1cAXsLfq",
+	"MKMNiVFMdMBYcdI4rbrx51GorHfYmxmTH+7soMlmJrU5/Hb07aj3+cPn/z8AhX3jOETZAAA=",
 }
 
 // GetSwagger returns the content of the embedded swagger specification file
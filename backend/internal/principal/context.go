@@ -0,0 +1,20 @@
+package principal
+
+import (
+	"context"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+type contextKey struct{}
+
+// NewContext returns a copy of ctx carrying the authenticated principal.
+func NewContext(ctx context.Context, p *entity.Principal) context.Context {
+	return context.WithValue(ctx, contextKey{}, p)
+}
+
+// FromContext returns the principal attached by the authentication step, if any.
+func FromContext(ctx context.Context) (*entity.Principal, bool) {
+	p, ok := ctx.Value(contextKey{}).(*entity.Principal)
+	return p, ok && p != nil
+}
@@ -0,0 +1,290 @@
+package seeder
+
+import (
+	"database/sql"
+	"fmt"
+	"log"
+	"strings"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+// migration is a schema change applied once, in order, on top of createTables.
+type migration struct {
+	version int
+	name    string
+	up      func(tx *sql.Tx) error
+}
+
+// migrations must only ever be appended to; applied versions are recorded in schema_migrations.
+var migrations = []migration{
+	{1, "add user profile columns", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`ALTER TABLE users ADD COLUMN display_name TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE users ADD COLUMN timezone TEXT NOT NULL DEFAULT 'UTC'`,
+			`ALTER TABLE users ADD COLUMN locale TEXT NOT NULL DEFAULT 'en-US'`,
+		)
+	}},
+	{2, "add users.active", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`ALTER TABLE users ADD COLUMN active INTEGER NOT NULL DEFAULT 1`,
+		)
+	}},
+	{3, "add two-factor authentication", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`ALTER TABLE users ADD COLUMN totp_secret TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE users ADD COLUMN mfa_enabled INTEGER NOT NULL DEFAULT 0`,
+			`CREATE TABLE recovery_codes (
+			  id INTEGER PRIMARY KEY AUTOINCREMENT,
+			  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			  code_hash TEXT NOT NULL,
+			  used_at DATETIME,
+			  UNIQUE (user_id, code_hash)
+			)`,
+			`CREATE TABLE role_policies (
+			  role TEXT PRIMARY KEY,
+			  mfa_required INTEGER NOT NULL DEFAULT 0
+			)`,
+		)
+	}},
+	{4, "add api_keys", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`CREATE TABLE api_keys (
+			  id INTEGER PRIMARY KEY AUTOINCREMENT,
+			  prefix TEXT NOT NULL UNIQUE,
+			  key_hash TEXT NOT NULL,
+			  label TEXT NOT NULL,
+			  owner_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			  scopes TEXT NOT NULL,
+			  created_at DATETIME NOT NULL,
+			  expires_at DATETIME,
+			  last_used_at DATETIME,
+			  revoked_at DATETIME
+			)`,
+		)
+	}},
+	{5, "add security_events", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`CREATE TABLE security_events (
+			  id INTEGER PRIMARY KEY AUTOINCREMENT,
+			  type TEXT NOT NULL,
+			  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			  session_id TEXT NOT NULL DEFAULT '',
+			  ip TEXT NOT NULL DEFAULT '',
+			  user_agent TEXT NOT NULL DEFAULT '',
+			  detail TEXT NOT NULL DEFAULT '',
+			  created_at DATETIME NOT NULL
+			)`,
+			`CREATE INDEX idx_security_events_created_at ON security_events(created_at)`,
+		)
+	}},
+	{6, "add user_identities", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`CREATE TABLE user_identities (
+			  id INTEGER PRIMARY KEY AUTOINCREMENT,
+			  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			  issuer TEXT NOT NULL,
+			  subject TEXT NOT NULL,
+			  created_at DATETIME NOT NULL,
+			  UNIQUE (issuer, subject)
+			)`,
+		)
+	}},
+	// security_events is an audit trail: its rows must outlive the users they
+	// name, so the foreign keys go and the emails are kept next to the ids
+	{7, "add security_events.actor_id and user emails", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`CREATE TABLE security_events_new (
+			  id INTEGER PRIMARY KEY AUTOINCREMENT,
+			  type TEXT NOT NULL,
+			  user_id INTEGER NOT NULL,
+			  user_email TEXT NOT NULL DEFAULT '',
+			  actor_id INTEGER,
+			  actor_email TEXT NOT NULL DEFAULT '',
+			  session_id TEXT NOT NULL DEFAULT '',
+			  ip TEXT NOT NULL DEFAULT '',
+			  user_agent TEXT NOT NULL DEFAULT '',
+			  detail TEXT NOT NULL DEFAULT '',
+			  created_at DATETIME NOT NULL
+			)`,
+			`INSERT INTO security_events_new(id, type, user_id, user_email, session_id, ip, user_agent, detail, created_at)
+			 SELECT e.id, e.type, e.user_id, COALESCE(u.email, ''), e.session_id, e.ip, e.user_agent, e.detail, e.created_at
+			 FROM security_events e
+			 LEFT JOIN users u ON u.id = e.user_id`,
+			`DROP TABLE security_events`,
+			`ALTER TABLE security_events_new RENAME TO security_events`,
+			`CREATE INDEX idx_security_events_created_at ON security_events(created_at)`,
+		)
+	}},
+	{8, "add payments.reference and payments.notes", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`ALTER TABLE payments ADD COLUMN reference TEXT NOT NULL DEFAULT ''`,
+			`ALTER TABLE payments ADD COLUMN notes TEXT NOT NULL DEFAULT ''`,
+		)
+	}},
+	{9, "store payments.amount as integer minor units", func(tx *sql.Tx) error {
+		if err := execAll(tx,
+			`ALTER TABLE payments ADD COLUMN amount_minor INTEGER NOT NULL DEFAULT 0`,
+			`ALTER TABLE payments ADD COLUMN currency TEXT NOT NULL DEFAULT '`+entity.DefaultCurrency+`'`,
+		); err != nil {
+			return err
+		}
+		if err := convertAmounts(tx); err != nil {
+			return err
+		}
+		return execAll(tx,
+			`ALTER TABLE payments DROP COLUMN amount`,
+			`ALTER TABLE payments RENAME COLUMN amount_minor TO amount`,
+		)
+	}},
+	{10, "add fx_rates", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`CREATE TABLE fx_rates (
+			  id INTEGER PRIMARY KEY AUTOINCREMENT,
+			  base TEXT NOT NULL,
+			  quote TEXT NOT NULL,
+			  rate TEXT NOT NULL,
+			  effective_from DATETIME NOT NULL,
+			  UNIQUE (base, quote, effective_from)
+			)`,
+		)
+	}},
+	{11, "add idempotency_keys", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`CREATE TABLE idempotency_keys (
+			  user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+			  credential TEXT NOT NULL,
+			  idempotency_key TEXT NOT NULL,
+			  request_hash TEXT NOT NULL,
+			  payment_id TEXT NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+			  created_at DATETIME NOT NULL,
+			  expires_at DATETIME NOT NULL,
+			  PRIMARY KEY (user_id, credential, idempotency_key)
+			)`,
+			`CREATE INDEX idx_idempotency_keys_expires_at ON idempotency_keys(expires_at)`,
+		)
+	}},
+	{12, "add payments.version", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`ALTER TABLE payments ADD COLUMN version INTEGER NOT NULL DEFAULT 1`,
+		)
+	}},
+	{13, "add payment_events", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`CREATE TABLE payment_events (
+			  id INTEGER PRIMARY KEY AUTOINCREMENT,
+			  payment_id TEXT NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+			  type TEXT NOT NULL,
+			  actor_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			  actor_email TEXT NOT NULL DEFAULT '',
+			  source TEXT NOT NULL,
+			  old_value TEXT NOT NULL DEFAULT '',
+			  new_value TEXT NOT NULL DEFAULT '',
+			  reason TEXT NOT NULL DEFAULT '',
+			  created_at DATETIME NOT NULL
+			)`,
+			`CREATE INDEX idx_payment_events_payment_id ON payment_events(payment_id, id)`,
+		)
+	}},
+	{14, "add refunds", func(tx *sql.Tx) error {
+		return execAll(tx,
+			`CREATE TABLE refunds (
+			  id TEXT PRIMARY KEY,
+			  payment_id TEXT NOT NULL REFERENCES payments(id) ON DELETE CASCADE,
+			  amount INTEGER NOT NULL,
+			  currency TEXT NOT NULL,
+			  status TEXT NOT NULL,
+			  reason TEXT NOT NULL,
+			  requested_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			  requested_by_email TEXT NOT NULL DEFAULT '',
+			  decided_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+			  decided_by_email TEXT NOT NULL DEFAULT '',
+			  created_at DATETIME NOT NULL,
+			  decided_at DATETIME
+			)`,
+			`CREATE INDEX idx_refunds_payment_id ON refunds(payment_id, status)`,
+			`ALTER TABLE payment_events ADD COLUMN refund_id TEXT NOT NULL DEFAULT ''`,
+		)
+	}},
+}
+
+// Apply pending migrations, each in its own transaction.
+func migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+	  version INTEGER PRIMARY KEY,
+	  name TEXT NOT NULL,
+	  applied_at DATETIME NOT NULL DEFAULT CURRENT_TIMESTAMP
+	);`); err != nil {
+		return err
+	}
+
+	var current int
+	if err := db.QueryRow("SELECT COALESCE(MAX(version), 0) FROM schema_migrations").Scan(&current); err != nil {
+		return err
+	}
+
+	for _, m := range migrations {
+		if m.version <= current {
+			continue
+		}
+
+		tx, err := db.Begin()
+		if err != nil {
+			return err
+		}
+		if err := m.up(tx); err != nil {
+			_ = tx.Rollback()
+			return fmt.Errorf("migration %d (%s): %w", m.version, m.name, err)
+		}
+		if _, err := tx.Exec("INSERT INTO schema_migrations(version, name) VALUES (?, ?)", m.version, m.name); err != nil {
+			_ = tx.Rollback()
+			return err
+		}
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		log.Printf("applied migration %d: %s", m.version, m.name)
+	}
+	return nil
+}
+
+// convertAmounts fills amount_minor from the decimal text amounts, which were all in the default currency.
+func convertAmounts(tx *sql.Tx) error {
+	rows, err := tx.Query(`SELECT id, amount FROM payments`)
+	if err != nil {
+		return err
+	}
+	amounts := map[string]string{}
+	for rows.Next() {
+		var id, amount string
+		if err := rows.Scan(&id, &amount); err != nil {
+			_ = rows.Close()
+			return err
+		}
+		amounts[id] = amount
+	}
+	_ = rows.Close()
+	if err := rows.Err(); err != nil {
+		return err
+	}
+
+	for id, amount := range amounts {
+		m, err := entity.ParseMoney(strings.TrimSpace(amount), entity.DefaultCurrency)
+		if err != nil {
+			return fmt.Errorf("payment %s: %w", id, err)
+		}
+		if _, err := tx.Exec(`UPDATE payments SET amount_minor = ? WHERE id = ?`, m.Amount, id); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func execAll(tx *sql.Tx, stmts ...string) error {
+	for _, s := range stmts {
+		if _, err := tx.Exec(s); err != nil {
+			return err
+		}
+	}
+	return nil
+}
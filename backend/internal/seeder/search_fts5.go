@@ -0,0 +1,78 @@
+//go:build sqlite_fts5
+
+package seeder
+
+import (
+	"database/sql"
+	"log"
+)
+
+// payments_fts indexes the searchable payment text. It is an external content
+// table, so it stores only the index and reads the text back from payments.
+var searchIndexTriggers = []string{
+	`CREATE TRIGGER payments_fts_ai AFTER INSERT ON payments BEGIN
+	  INSERT INTO payments_fts(rowid, id, merchant, reference, notes)
+	  VALUES (new.rowid, new.id, new.merchant, new.reference, new.notes);
+	END`,
+	`CREATE TRIGGER payments_fts_ad AFTER DELETE ON payments BEGIN
+	  INSERT INTO payments_fts(payments_fts, rowid, id, merchant, reference, notes)
+	  VALUES ('delete', old.rowid, old.id, old.merchant, old.reference, old.notes);
+	END`,
+	`CREATE TRIGGER payments_fts_au AFTER UPDATE OF id, merchant, reference, notes ON payments BEGIN
+	  INSERT INTO payments_fts(payments_fts, rowid, id, merchant, reference, notes)
+	  VALUES ('delete', old.rowid, old.id, old.merchant, old.reference, old.notes);
+	  INSERT INTO payments_fts(rowid, id, merchant, reference, notes)
+	  VALUES (new.rowid, new.id, new.merchant, new.reference, new.notes);
+	END`,
+}
+
+// prepareSearchIndex has nothing to do with FTS5: the triggers keep working
+// through migrations, and syncSearchIndex checks them afterwards.
+func prepareSearchIndex(db *sql.DB) error {
+	return nil
+}
+
+// syncSearchIndex creates the payment search index and the triggers that keep it
+// in step with payments. Missing triggers mean the index is new or was left
+// behind by a build without FTS5, so it is rebuilt from payments.
+func syncSearchIndex(db *sql.DB) error {
+	tx, err := db.Begin()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = tx.Rollback() }()
+
+	if _, err := tx.Exec(`CREATE VIRTUAL TABLE IF NOT EXISTS payments_fts USING fts5(
+	  id, merchant, reference, notes,
+	  content='payments', content_rowid='rowid',
+	  tokenize='unicode61 remove_diacritics 2', prefix='2 3'
+	)`); err != nil {
+		return err
+	}
+
+	var triggers int
+	if err := tx.QueryRow(
+		`SELECT COUNT(1) FROM sqlite_master WHERE type = 'trigger' AND name IN ('payments_fts_ai', 'payments_fts_ad', 'payments_fts_au')`,
+	).Scan(&triggers); err != nil {
+		return err
+	}
+	if triggers == len(searchIndexTriggers) {
+		return nil
+	}
+
+	stmts := []string{
+		`DROP TRIGGER IF EXISTS payments_fts_ai`,
+		`DROP TRIGGER IF EXISTS payments_fts_ad`,
+		`DROP TRIGGER IF EXISTS payments_fts_au`,
+	}
+	stmts = append(stmts, searchIndexTriggers...)
+	stmts = append(stmts, `INSERT INTO payments_fts(payments_fts) VALUES ('rebuild')`)
+	if err := execAll(tx, stmts...); err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+	log.Println("rebuilt payment search index")
+	return nil
+}
@@ -0,0 +1,22 @@
+//go:build !sqlite_fts5
+
+package seeder
+
+import "database/sql"
+
+// prepareSearchIndex drops the search index triggers a build with FTS5 may have
+// left, since without FTS5 they would make migrations and every write to payments
+// fail. The next build with FTS5 recreates them and rebuilds the index.
+func prepareSearchIndex(db *sql.DB) error {
+	for _, name := range []string{"payments_fts_ai", "payments_fts_ad", "payments_fts_au"} {
+		if _, err := db.Exec(`DROP TRIGGER IF EXISTS ` + name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// syncSearchIndex has nothing to do without FTS5.
+func syncSearchIndex(db *sql.DB) error {
+	return nil
+}
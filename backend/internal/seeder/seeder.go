@@ -6,21 +6,35 @@ import (
 	"math/rand"
 	"time"
 
-	"golang.org/x/crypto/bcrypt"
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	passwordsvc "github.com/durianpay/fullstack-boilerplate/internal/service/password"
 )
 
 // Seed initializes the database schema and seeds default data.
 // It is safe to call on every startup — it only inserts when tables are empty.
-func Seed(db *sql.DB) error {
+// Default passwords are hashed with the configured hasher and are not held to the password policy.
+func Seed(db *sql.DB, passwords passwordsvc.Hasher) error {
 	if err := createTables(db); err != nil {
 		return err
 	}
-	if err := seedUsers(db); err != nil {
+	if err := prepareSearchIndex(db); err != nil {
+		return err
+	}
+	if err := migrate(db); err != nil {
+		return err
+	}
+	if err := syncSearchIndex(db); err != nil {
+		return err
+	}
+	if err := seedUsers(db, passwords); err != nil {
 		return err
 	}
 	if err := seedPayments(db); err != nil {
 		return err
 	}
+	if err := seedFXRates(db); err != nil {
+		return err
+	}
 
 	const dbLifetime = time.Minute * 5
 	db.SetConnMaxLifetime(dbLifetime)
@@ -53,7 +67,7 @@ func createTables(db *sql.DB) error {
 }
 
 // Inserts default user accounts when the users table is empty.
-func seedUsers(db *sql.DB) error {
+func seedUsers(db *sql.DB, passwords passwordsvc.Hasher) error {
 	var cnt int
 	if err := db.QueryRow("SELECT COUNT(1) FROM users").Scan(&cnt); err != nil {
 		return err
@@ -62,14 +76,14 @@ func seedUsers(db *sql.DB) error {
 		return nil
 	}
 
-	defaultHash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.DefaultCost)
+	defaultHash, err := passwords.Hash("password")
 	if err != nil {
 		return err
 	}
 
 	defaultUsers := []struct {
 		email string
-		hash  []byte
+		hash  string
 		role  string
 	}{
 		{"cs@test.com", defaultHash, "cs"},
@@ -79,20 +93,20 @@ func seedUsers(db *sql.DB) error {
 	for _, u := range defaultUsers {
 		if _, err := db.Exec(
 			"INSERT INTO users(email, password_hash, role) VALUES (?, ?, ?)",
-			u.email, string(u.hash), u.role,
+			u.email, u.hash, u.role,
 		); err != nil {
 			return err
 		}
 	}
 
 	// Superuser account with a stronger password
-	superuserHash, err := bcrypt.GenerateFromPassword([]byte("Password@123"), bcrypt.DefaultCost)
+	superuserHash, err := passwords.Hash("Password@123")
 	if err != nil {
 		return err
 	}
 	if _, err := db.Exec(
 		"INSERT INTO users(email, password_hash, role) VALUES (?, ?, ?)",
-		"superuser@test.com", string(superuserHash), "superuser",
+		"superuser@test.com", superuserHash, "superuser",
 	); err != nil {
 		return err
 	}
@@ -116,47 +130,48 @@ func seedPayments(db *sql.DB) error {
 		merchant string
 		status   string
 		amount   string
+		currency string
 	}{
-		{"pay_001", "Tokopedia", "completed", "50000.00"},
-		{"pay_002", "Shopee", "processing", "75000.00"},
-		{"pay_003", "Bukalapak", "failed", "25000.00"},
-		{"pay_004", "Lazada", "completed", "100000.00"},
-		{"pay_005", "Blibli", "completed", "150000.00"},
-		{"pay_006", "Gojek", "processing", "45000.00"},
-		{"pay_007", "Grab", "failed", "82000.00"},
-		{"pay_008", "Traveloka", "completed", "200000.00"},
-		{"pay_009", "Dana", "completed", "35000.00"},
-		{"pay_010", "OVO", "processing", "62000.00"},
-		{"pay_011", "LinkAja", "completed", "88000.00"},
-		{"pay_012", "Tiket.com", "failed", "120000.00"},
-		{"pay_013", "Akulaku", "completed", "47000.00"},
-		{"pay_014", "Kredivo", "processing", "93000.00"},
-		{"pay_015", "JD.ID", "completed", "155000.00"},
-		{"pay_016", "Alfamart", "completed", "12000.00"},
-		{"pay_017", "Indomaret", "processing", "18500.00"},
-		{"pay_018", "Bank BCA", "completed", "500000.00"},
-		{"pay_019", "Bank Mandiri", "failed", "250000.00"},
-		{"pay_020", "Telkomsel", "completed", "50000.00"},
-		{"pay_021", "XL Axiata", "processing", "75000.00"},
-		{"pay_022", "Indosat", "completed", "30000.00"},
-		{"pay_023", "Pertamina", "completed", "350000.00"},
-		{"pay_024", "PLN", "failed", "175000.00"},
-		{"pay_025", "BPJS Kesehatan", "completed", "42000.00"},
-		{"pay_026", "Garuda Indonesia", "processing", "2500000.00"},
-		{"pay_027", "Lion Air", "completed", "850000.00"},
-		{"pay_028", "Pos Indonesia", "failed", "15000.00"},
-		{"pay_029", "JNE Express", "completed", "28000.00"},
-		{"pay_030", "SiCepat", "processing", "22000.00"},
-		{"pay_031", "Anteraja", "completed", "19500.00"},
-		{"pay_032", "Tokopedia Official", "completed", "1250000.00"},
-		{"pay_033", "Shopee Mall", "failed", "975000.00"},
-		{"pay_034", "Blibli Official", "processing", "3200000.00"},
-		{"pay_035", "Apple Store ID", "completed", "1599000.00"},
-		{"pay_036", "Google Play ID", "completed", "49000.00"},
-		{"pay_037", "Netflix ID", "processing", "186000.00"},
-		{"pay_038", "Spotify ID", "completed", "54990.00"},
-		{"pay_039", "Disney+ Hotstar", "failed", "39000.00"},
-		{"pay_040", "Vidio Premium", "completed", "59000.00"},
+		{"pay_001", "Tokopedia", "completed", "50000.00", "IDR"},
+		{"pay_002", "Shopee", "processing", "75000.00", "IDR"},
+		{"pay_003", "Bukalapak", "failed", "25000.00", "IDR"},
+		{"pay_004", "Lazada", "completed", "100000.00", "IDR"},
+		{"pay_005", "Blibli", "completed", "150000.00", "IDR"},
+		{"pay_006", "Gojek", "processing", "45000.00", "IDR"},
+		{"pay_007", "Grab", "failed", "82000.00", "IDR"},
+		{"pay_008", "Traveloka", "completed", "200000.00", "IDR"},
+		{"pay_009", "Dana", "completed", "35000.00", "IDR"},
+		{"pay_010", "OVO", "processing", "62000.00", "IDR"},
+		{"pay_011", "LinkAja", "completed", "88000.00", "IDR"},
+		{"pay_012", "Tiket.com", "failed", "120000.00", "IDR"},
+		{"pay_013", "Akulaku", "completed", "47000.00", "IDR"},
+		{"pay_014", "Kredivo", "processing", "93000.00", "IDR"},
+		{"pay_015", "JD.ID", "completed", "155000.00", "IDR"},
+		{"pay_016", "Alfamart", "completed", "12000.00", "IDR"},
+		{"pay_017", "Indomaret", "processing", "18500.00", "IDR"},
+		{"pay_018", "Bank BCA", "completed", "500000.00", "IDR"},
+		{"pay_019", "Bank Mandiri", "failed", "250000.00", "IDR"},
+		{"pay_020", "Telkomsel", "completed", "50000.00", "IDR"},
+		{"pay_021", "XL Axiata", "processing", "75000.00", "IDR"},
+		{"pay_022", "Indosat", "completed", "30000.00", "IDR"},
+		{"pay_023", "Pertamina", "completed", "350000.00", "IDR"},
+		{"pay_024", "PLN", "failed", "175000.00", "IDR"},
+		{"pay_025", "BPJS Kesehatan", "completed", "42000.00", "IDR"},
+		{"pay_026", "Garuda Indonesia", "processing", "2500000.00", "IDR"},
+		{"pay_027", "Lion Air", "completed", "850000.00", "IDR"},
+		{"pay_028", "Pos Indonesia", "failed", "15000.00", "IDR"},
+		{"pay_029", "JNE Express", "completed", "28000.00", "IDR"},
+		{"pay_030", "SiCepat", "processing", "22000.00", "IDR"},
+		{"pay_031", "Anteraja", "completed", "19500.00", "IDR"},
+		{"pay_032", "Tokopedia Official", "completed", "1250000.00", "IDR"},
+		{"pay_033", "Shopee Mall", "failed", "975000.00", "IDR"},
+		{"pay_034", "Blibli Official", "processing", "3200000.00", "IDR"},
+		{"pay_035", "Apple Store ID", "completed", "99.99", "USD"},
+		{"pay_036", "Google Play ID", "completed", "49000.00", "IDR"},
+		{"pay_037", "Netflix ID", "processing", "11.99", "USD"},
+		{"pay_038", "Spotify ID", "completed", "54990.00", "IDR"},
+		{"pay_039", "Disney+ Hotstar", "failed", "39000.00", "IDR"},
+		{"pay_040", "Vidio Premium", "completed", "59000.00", "IDR"},
 	}
 
 	now := time.Now()
@@ -166,9 +181,13 @@ func seedPayments(db *sql.DB) error {
 		hoursAgo := rng.Intn(24)
 		ts := now.AddDate(0, 0, -daysAgo).Add(-time.Duration(hoursAgo) * time.Hour)
 
+		amount, err := entity.ParseMoney(p.amount, p.currency)
+		if err != nil {
+			return err
+		}
 		if _, err := db.Exec(
-			"INSERT INTO payments(id, merchant, status, amount, created_at) VALUES (?, ?, ?, ?, ?)",
-			p.id, p.merchant, p.status, p.amount, ts.Format(time.RFC3339),
+			"INSERT INTO payments(id, merchant, status, amount, currency, created_at) VALUES (?, ?, ?, ?, ?, ?)",
+			p.id, p.merchant, p.status, amount.Amount, amount.Currency, ts.Format(time.RFC3339),
 		); err != nil {
 			return err
 		}
@@ -177,3 +196,34 @@ func seedPayments(db *sql.DB) error {
 	log.Println("seeded sample payments")
 	return nil
 }
+
+// Inserts sample USD/IDR rates covering the sample payments when fx_rates is empty.
+func seedFXRates(db *sql.DB) error {
+	var cnt int
+	if err := db.QueryRow("SELECT COUNT(1) FROM fx_rates").Scan(&cnt); err != nil {
+		return err
+	}
+	if cnt > 0 {
+		return nil
+	}
+
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+	rates := []struct {
+		daysAgo int
+		rate    string
+	}{
+		{60, "16250.00"},
+		{14, "16385.50"},
+	}
+	for _, r := range rates {
+		if _, err := db.Exec(
+			"INSERT INTO fx_rates(base, quote, rate, effective_from) VALUES (?, ?, ?, ?)",
+			"USD", "IDR", r.rate, today.AddDate(0, 0, -r.daysAgo),
+		); err != nil {
+			return err
+		}
+	}
+
+	log.Println("seeded sample fx rates")
+	return nil
+}
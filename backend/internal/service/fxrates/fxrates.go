@@ -0,0 +1,120 @@
+// Package fxrates reads exchange rates from a CSV or JSON file.
+//
+// A CSV file has a header row naming the columns base, quote, rate and
+// effective_from, in any order. A JSON file holds an array of objects with the
+// same keys. effective_from is an RFC 3339 time or a date (midnight UTC), and
+// rate is the price of one unit of base in quote as a decimal:
+//
+//	base,quote,rate,effective_from
+//	USD,IDR,16250.50,2025-03-01
+package fxrates
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+)
+
+type record struct {
+	Base          string `json:"base"`
+	Quote         string `json:"quote"`
+	Rate          string `json:"rate"`
+	EffectiveFrom string `json:"effective_from"`
+}
+
+// LoadFile reads the rates in path, telling CSV from JSON by the file extension.
+func LoadFile(path string) ([]*entity.FXRate, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("fxrates: %w", err)
+	}
+	defer f.Close()
+
+	var records []record
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".csv":
+		records, err = readCSV(f)
+	case ".json":
+		err = json.NewDecoder(f).Decode(&records)
+	default:
+		return nil, fmt.Errorf("fxrates: %s: file must be .csv or .json", path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("fxrates: read %s: %w", path, err)
+	}
+
+	rates := make([]*entity.FXRate, 0, len(records))
+	for i, rec := range records {
+		rate, err := parseRecord(rec)
+		if err != nil {
+			return nil, fmt.Errorf("fxrates: %s: rate %d: %w", path, i+1, err)
+		}
+		rates = append(rates, rate)
+	}
+	return rates, nil
+}
+
+func readCSV(r io.Reader) ([]record, error) {
+	rows, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, err
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+
+	column := map[string]int{}
+	for i, name := range rows[0] {
+		column[strings.ToLower(strings.TrimSpace(name))] = i
+	}
+	for _, name := range []string{"base", "quote", "rate", "effective_from"} {
+		if _, ok := column[name]; !ok {
+			return nil, fmt.Errorf("header has no %s column", name)
+		}
+	}
+
+	records := make([]record, 0, len(rows)-1)
+	for _, row := range rows[1:] {
+		records = append(records, record{
+			Base:          row[column["base"]],
+			Quote:         row[column["quote"]],
+			Rate:          row[column["rate"]],
+			EffectiveFrom: row[column["effective_from"]],
+		})
+	}
+	return records, nil
+}
+
+func parseRecord(rec record) (*entity.FXRate, error) {
+	base := strings.ToUpper(strings.TrimSpace(rec.Base))
+	quote := strings.ToUpper(strings.TrimSpace(rec.Quote))
+	for _, c := range []string{base, quote} {
+		if _, ok := entity.CurrencyExponent(c); !ok {
+			return nil, fmt.Errorf("unsupported currency %q", c)
+		}
+	}
+	if base == quote {
+		return nil, fmt.Errorf("base and quote are both %s", base)
+	}
+
+	rate := strings.TrimSpace(rec.Rate)
+	if _, err := entity.ParseRate(rate); err != nil {
+		return nil, err
+	}
+
+	effective := strings.TrimSpace(rec.EffectiveFrom)
+	at, err := time.Parse(time.RFC3339, effective)
+	if err != nil {
+		if at, err = time.Parse(time.DateOnly, effective); err != nil {
+			return nil, fmt.Errorf("effective_from %q is neither an RFC 3339 time nor a date", effective)
+		}
+	}
+	return &entity.FXRate{Base: base, Quote: quote, Rate: rate, EffectiveFrom: at}, nil
+}
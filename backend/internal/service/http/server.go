@@ -2,17 +2,23 @@ package http
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
 	"os/signal"
+	"slices"
 	"strings"
 	"syscall"
 	"time"
 
 	"github.com/durianpay/fullstack-boilerplate/internal/docs"
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
 	"github.com/durianpay/fullstack-boilerplate/internal/openapigen"
+	"github.com/durianpay/fullstack-boilerplate/internal/principal"
+	"github.com/durianpay/fullstack-boilerplate/internal/transport"
+	"github.com/getkin/kin-openapi/openapi3"
 	"github.com/getkin/kin-openapi/openapi3filter"
 	"github.com/go-chi/chi/v5"
 	chimw "github.com/go-chi/chi/v5/middleware"
@@ -31,48 +37,248 @@ const (
 	idleTimeout  = 60
 )
 
-func jwtAuthFunc(secret []byte) openapi3filter.AuthenticationFunc {
+// AccessTokenDenylist reports whether an access token was revoked before it expired.
+type AccessTokenDenylist interface {
+	IsAccessTokenRevoked(ctx context.Context, jti string, sessionID string) (bool, error)
+}
+
+// TokenKeys resolves the keys access tokens are verified with.
+type TokenKeys interface {
+	Keyfunc(t *jwt.Token) (any, error)
+	ValidMethods() []string
+}
+
+// APIKeyAuthenticator resolves an X-API-Key header to the principal the key acts as.
+type APIKeyAuthenticator interface {
+	AuthenticateAPIKey(ctx context.Context, key string) (*entity.Principal, error)
+}
+
+// ImpersonationAuditor records each request made with an impersonation token.
+type ImpersonationAuditor interface {
+	RecordImpersonatedRequest(ctx context.Context, p *entity.Principal, client entity.ClientInfo, request string) error
+}
+
+// authFunc accepts either a bearer access token or an API key, depending on
+// which security scheme the validator is trying for the operation.
+func authFunc(keys TokenKeys, denylist AccessTokenDenylist, apiKeys APIKeyAuthenticator, audit ImpersonationAuditor) openapi3filter.AuthenticationFunc {
 	return func(ctx context.Context, input *openapi3filter.AuthenticationInput) error {
-		if input.SecuritySchemeName != "bearerAuth" {
+		req := input.RequestValidationInput.Request
+
+		var (
+			p   *entity.Principal
+			err error
+		)
+		switch input.SecuritySchemeName {
+		case "bearerAuth":
+			p, err = bearerPrincipal(ctx, req, keys, denylist)
+		case "apiKey":
+			p, err = apiKeyPrincipal(ctx, req, apiKeys)
+		default:
 			return fmt.Errorf("unsupported security scheme: %s", input.SecuritySchemeName)
 		}
+		if err != nil {
+			return err
+		}
 
-		req := input.RequestValidationInput.Request
-		header := req.Header.Get("Authorization")
-		if header == "" {
-			return fmt.Errorf("missing authorization header")
+		// Impersonated requests are recorded before any check, so refused
+		// attempts are attributed to the superuser too.
+		if p.ActorID != "" {
+			client := entity.ClientInfo{UserAgent: req.UserAgent(), IP: transport.ClientIP(req)}
+			if err := audit.RecordImpersonatedRequest(ctx, p, client, req.Method+" "+req.URL.Path); err != nil {
+				return entity.ErrorInternal("failed to record impersonated request")
+			}
 		}
 
-		parts := strings.SplitN(header, " ", 2)
-		if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
-			return fmt.Errorf("invalid authorization header format")
+		op := input.RequestValidationInput.Route.Operation
+		if !roleAllowed(op, p.Role) {
+			return entity.ErrorForbidden("role is not allowed to perform this operation")
+		}
+		if !scopesAllowed(op, p) {
+			return entity.ErrorForbidden("api key is missing a scope required by this operation")
+		}
+		if !impersonationAllowed(op, p) {
+			return entity.ErrorForbidden("this operation is not allowed while impersonating")
 		}
 
-		tokenStr := parts[1]
-		token, err := jwt.Parse(tokenStr, func(t *jwt.Token) (interface{}, error) {
-			if _, ok := t.Method.(*jwt.SigningMethodHMAC); !ok {
-				return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
-			}
-			return secret, nil
-		})
-		if err != nil || !token.Valid {
-			return fmt.Errorf("invalid or expired token")
+		// The validator hands this same request to the handler, so attaching
+		// the principal here makes it visible downstream.
+		*req = *req.WithContext(principal.NewContext(req.Context(), p))
+
+		return nil
+	}
+}
+
+func bearerPrincipal(ctx context.Context, req *http.Request, keys TokenKeys, denylist AccessTokenDenylist) (*entity.Principal, error) {
+	header := req.Header.Get("Authorization")
+	if header == "" {
+		return nil, fmt.Errorf("missing authorization header")
+	}
+
+	parts := strings.SplitN(header, " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return nil, fmt.Errorf("invalid authorization header format")
+	}
+
+	tokenStr := parts[1]
+	token, err := jwt.Parse(tokenStr, keys.Keyfunc, jwt.WithValidMethods(keys.ValidMethods()))
+	if err != nil || !token.Valid {
+		return nil, fmt.Errorf("invalid or expired token")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+
+	if tokenType, _ := claims["type"].(string); tokenType != "access" {
+		return nil, fmt.Errorf("invalid token type")
+	}
+
+	p, err := principalFromClaims(claims)
+	if err != nil {
+		return nil, err
+	}
+
+	revoked, err := denylist.IsAccessTokenRevoked(ctx, p.TokenID, p.SessionID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check token revocation")
+	}
+	if revoked {
+		return nil, fmt.Errorf("token has been revoked")
+	}
+
+	return p, nil
+}
+
+func apiKeyPrincipal(ctx context.Context, req *http.Request, apiKeys APIKeyAuthenticator) (*entity.Principal, error) {
+	key := req.Header.Get("X-API-Key")
+	if key == "" {
+		return nil, fmt.Errorf("missing api key header")
+	}
+
+	p, err := apiKeys.AuthenticateAPIKey(ctx, key)
+	if err != nil {
+		var appErr *entity.AppError
+		if errors.As(err, &appErr) && appErr.Code == entity.ErrorCodeUnauthorized {
+			return nil, errors.New(appErr.Message)
+		}
+		return nil, fmt.Errorf("failed to check api key")
+	}
+	return p, nil
+}
+
+// principalFromClaims builds the request principal from verified access token claims.
+func principalFromClaims(claims jwt.MapClaims) (*entity.Principal, error) {
+	p := &entity.Principal{}
+	p.UserID, _ = claims["sub"].(string)
+	p.Email, _ = claims["email"].(string)
+	p.Role, _ = claims["role"].(string)
+	p.SessionID, _ = claims["sid"].(string)
+	p.TokenID, _ = claims["jti"].(string)
+	if p.UserID == "" || p.SessionID == "" || p.TokenID == "" {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+
+	exp, err := claims.GetExpirationTime()
+	if err != nil || exp == nil {
+		return nil, fmt.Errorf("invalid token claims")
+	}
+	p.ExpiresAt = exp.Time
+
+	if raw, ok := claims["act"]; ok {
+		act, _ := raw.(map[string]any)
+		p.ActorID, _ = act["sub"].(string)
+		p.ActorEmail, _ = act["email"].(string)
+		if p.ActorID == "" {
+			return nil, fmt.Errorf("invalid token claims")
 		}
+	}
+
+	return p, nil
+}
 
-		claims, ok := token.Claims.(jwt.MapClaims)
-		if !ok {
-			return fmt.Errorf("invalid token claims")
+// roleAllowed checks the role against the operation's x-roles extension.
+// Operations without x-roles are open to every authenticated user.
+func roleAllowed(op *openapi3.Operation, role string) bool {
+	if op == nil {
+		return true
+	}
+	raw, ok := op.Extensions["x-roles"]
+	if !ok {
+		return true
+	}
+	roles, ok := raw.([]any)
+	if !ok {
+		return false
+	}
+	for _, r := range roles {
+		if r == role {
+			return true
 		}
+	}
+	return false
+}
 
-		if tokenType, _ := claims["type"].(string); tokenType != "access" {
-			return fmt.Errorf("invalid token type")
+// scopesAllowed checks an API key's scopes against the operation's x-scopes
+// extension; every listed scope is required. Tokens are not scoped, and an
+// operation without x-scopes cannot be called with an API key at all.
+func scopesAllowed(op *openapi3.Operation, p *entity.Principal) bool {
+	if p.APIKeyID == "" {
+		return true
+	}
+	if op == nil {
+		return false
+	}
+	raw, ok := op.Extensions["x-scopes"]
+	if !ok {
+		return false
+	}
+	scopes, ok := raw.([]any)
+	if !ok {
+		return false
+	}
+	for _, s := range scopes {
+		name, _ := s.(string)
+		if !slices.Contains(p.Scopes, name) {
+			return false
 		}
+	}
+	return true
+}
 
-		return nil
+// impersonationAllowed refuses impersonation tokens on operations marked
+// with the x-no-impersonation extension.
+func impersonationAllowed(op *openapi3.Operation, p *entity.Principal) bool {
+	if p.ActorID == "" || op == nil {
+		return true
+	}
+	blocked, _ := op.Extensions["x-no-impersonation"].(bool)
+	return !blocked
+}
+
+// validationErrorHandler renders validator failures in the same JSON shape as handler errors.
+func validationErrorHandler(_ context.Context, err error, w http.ResponseWriter, _ *http.Request, opts oapinethttpmw.ErrorHandlerOpts) {
+	var appErr *entity.AppError
+	if errors.As(err, &appErr) {
+		transport.WriteAppError(w, appErr)
+		return
+	}
+
+	switch opts.StatusCode {
+	case http.StatusBadRequest:
+		// openapi errors are multi-line with a decent message on the first
+		msg, _, _ := strings.Cut(err.Error(), "\n")
+		transport.WriteAppError(w, entity.ErrorBadRequest(msg))
+	case http.StatusUnauthorized:
+		transport.WriteAppError(w, entity.ErrorUnauthorized("Unauthenticated: "+err.Error()))
+	case http.StatusNotFound, http.StatusMethodNotAllowed:
+		transport.WriteAppError(w, entity.ErrorNotFound("no matching operation was found"))
+	default:
+		transport.WriteError(w, err)
 	}
 }
 
-func NewServer(apiHandler openapigen.ServerInterface, openapiYamlPath string, jwtSecret []byte) *Server {
+func NewServer(apiHandler openapigen.ServerInterface, openapiYamlPath string, keys TokenKeys, denylist AccessTokenDenylist, apiKeys APIKeyAuthenticator, audit ImpersonationAuditor) *Server {
 	swagger, err := openapigen.GetSwagger()
 	if err != nil {
 		log.Fatalf("failed to load swagger: %v", err)
@@ -85,9 +291,9 @@ func NewServer(apiHandler openapigen.ServerInterface, openapiYamlPath string, jw
 	r.Use(chimw.Recoverer)
 	r.Use(cors.Handler(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
-		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type"},
-		ExposedHeaders:   []string{"Link"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders:   []string{"Accept", "Authorization", "Content-Type", "X-API-Key", "Idempotency-Key", "If-Match"},
+		ExposedHeaders:   []string{"Link", "ETag", "Idempotent-Replayed"},
 		AllowCredentials: true,
 		MaxAge:           300,
 	}))
@@ -97,15 +303,16 @@ func NewServer(apiHandler openapigen.ServerInterface, openapiYamlPath string, jw
 	r.Get("/docs/openapi.yaml", docs.OpenAPISpecHandler(openapiYamlPath))
 
 	// All API routes — the OpenAPI validator handles request validation
-	// and JWT authentication (via AuthenticationFunc) for secured endpoints.
+	// and authentication (JWT or API key, via AuthenticationFunc) for secured endpoints.
 	r.Route("/", func(api chi.Router) {
 		api.Use(oapinethttpmw.OapiRequestValidatorWithOptions(
 			swagger,
 			&oapinethttpmw.Options{
 				DoNotValidateServers:  true,
 				SilenceServersWarning: true,
+				ErrorHandlerWithOpts:  validationErrorHandler,
 				Options: openapi3filter.Options{
-					AuthenticationFunc: jwtAuthFunc(jwtSecret),
+					AuthenticationFunc: authFunc(keys, denylist, apiKeys, audit),
 				},
 			},
 		))
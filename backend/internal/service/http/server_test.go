@@ -0,0 +1,39 @@
+package http
+
+import (
+	"testing"
+
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+func TestScopesAllowed(t *testing.T) {
+	scoped := func(scopes ...any) *openapi3.Operation {
+		return &openapi3.Operation{Extensions: map[string]any{"x-scopes": scopes}}
+	}
+	key := func(scopes ...string) *entity.Principal {
+		return &entity.Principal{UserID: "1", APIKeyID: "7", Scopes: scopes}
+	}
+
+	tests := []struct {
+		name string
+		op   *openapi3.Operation
+		p    *entity.Principal
+		want bool
+	}{
+		{"token on an unscoped operation", &openapi3.Operation{}, &entity.Principal{UserID: "1"}, true},
+		{"key with the scope", scoped("payments:read"), key("payments:read"), true},
+		{"key with more scopes", scoped("payments:read"), key("payments:write", "payments:read"), true},
+		{"key without the scope", scoped("payments:write"), key("payments:read"), false},
+		{"key missing one of two scopes", scoped("payments:read", "payments:write"), key("payments:read"), false},
+		{"key on an operation without x-scopes", &openapi3.Operation{}, key("payments:read"), false},
+		{"key on an unknown operation", nil, key("payments:read"), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := scopesAllowed(tt.op, tt.p); got != tt.want {
+				t.Errorf("scopesAllowed = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,452 @@
+// Package jwtkeys keeps the keys used to sign and verify JWTs.
+//
+// With RS256 or EdDSA the ring holds one signing key and any number of
+// retired keys that are still accepted for verification, so tokens issued
+// before a rotation keep working until they expire. Keys live in a directory
+// as PKCS#8 PEM files plus a keys.json manifest, which lets several instances
+// share them; a Locker keeps their rotations from overwriting each other.
+// Every token carries the kid of the key that signed it.
+//
+// With HS256 the ring falls back to the single shared secret and has no kid.
+package jwtkeys
+
+import (
+	"context"
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"math/big"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	AlgHS256 = "HS256"
+	AlgRS256 = "RS256"
+	AlgEdDSA = "EdDSA"
+
+	manifestFile = "keys.json"
+	rsaKeyBits   = 2048
+	// the ring rereads the directory at most once per interval, when signing or
+	// when a token has an unknown kid
+	reloadInterval = 10 * time.Second
+
+	rotateLockKey = "jwtkeys:rotate"
+	// rotateLockTTL frees the lock should an instance die while rotating
+	rotateLockTTL  = 30 * time.Second
+	rotateLockWait = 10 * time.Second
+)
+
+// ErrRotationUnsupported is returned by Rotate when the ring uses a shared HS256 secret.
+var ErrRotationUnsupported = errors.New("jwtkeys: key rotation requires RS256 or EdDSA")
+
+// Key is one signing key pair. RetiredAt is zero for the active key.
+type Key struct {
+	ID        string    `json:"kid"`
+	Alg       string    `json:"alg"`
+	CreatedAt time.Time `json:"created_at"`
+	RetiredAt time.Time `json:"retired_at,omitzero"`
+
+	private crypto.Signer
+}
+
+func (k *Key) Public() crypto.PublicKey {
+	return k.private.Public()
+}
+
+type manifest struct {
+	Keys []*Key `json:"keys"`
+}
+
+// Locker serialises key rotations between the instances sharing a key directory.
+type Locker interface {
+	// Lock waits until it holds key or ctx is done, and returns the func that
+	// releases it. The lock expires after ttl should the holder die.
+	Lock(ctx context.Context, key string, ttl time.Duration) (func(), error)
+}
+
+type KeyRing struct {
+	mu         sync.RWMutex
+	dir        string
+	alg        string
+	retention  time.Duration
+	secret     []byte
+	locker     Locker
+	keys       []*Key // active key first
+	lastReload time.Time
+	now        func() time.Time
+}
+
+// NewKeyRing loads the keys in dir, creating a first key when there is none.
+// Retired keys are kept for retention, which must cover the longest token lifetime.
+// When acceptSecret is set, tokens without a kid that were signed with the HS256
+// secret are still accepted, which eases the switch from HS256. The locker may be
+// nil when a single instance uses dir.
+func NewKeyRing(dir string, alg string, retention time.Duration, secret []byte, acceptSecret bool, locker Locker) (*KeyRing, error) {
+	r := &KeyRing{dir: dir, alg: alg, retention: retention, locker: locker, now: time.Now}
+	if alg == AlgHS256 || acceptSecret {
+		r.secret = secret
+	}
+
+	switch alg {
+	case AlgHS256:
+		return r, nil
+	case AlgRS256, AlgEdDSA:
+	default:
+		return nil, fmt.Errorf("jwtkeys: unsupported signing algorithm %q", alg)
+	}
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("jwtkeys: %w", err)
+	}
+	if err := r.reload(); err != nil {
+		return nil, err
+	}
+	if r.active() == nil {
+		if _, err := r.rotate(false); err != nil {
+			return nil, err
+		}
+	}
+	return r, nil
+}
+
+// Sign signs the claims with the active key. The directory is reread when it
+// is due, so after another instance rotated the keys this one follows within
+// the reload interval; until then it signs with the key just retired, which
+// every instance still accepts.
+func (r *KeyRing) Sign(claims jwt.Claims) (string, error) {
+	if r.alg == AlgHS256 {
+		return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(r.secret)
+	}
+
+	if r.reloadDue() {
+		if err := r.reload(); err != nil {
+			return "", err
+		}
+	}
+	key := r.active()
+	if key == nil {
+		return "", fmt.Errorf("jwtkeys: no active %s key in %s", r.alg, r.dir)
+	}
+
+	token := jwt.NewWithClaims(jwt.GetSigningMethod(key.Alg), claims)
+	token.Header["kid"] = key.ID
+	return token.SignedString(key.private)
+}
+
+// Keyfunc resolves the verification key for a token; it is meant for jwt.Parse.
+func (r *KeyRing) Keyfunc(t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+	if kid == "" {
+		if _, ok := t.Method.(*jwt.SigningMethodHMAC); ok && r.secret != nil {
+			return r.secret, nil
+		}
+		return nil, fmt.Errorf("token has no kid")
+	}
+
+	key := r.find(kid)
+	if key == nil && r.alg != AlgHS256 && r.reloadDue() {
+		// another instance may have rotated the keys
+		if err := r.reload(); err != nil {
+			return nil, err
+		}
+		key = r.find(kid)
+	}
+	if key == nil {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if t.Method.Alg() != key.Alg {
+		return nil, fmt.Errorf("unexpected signing method: %v", t.Header["alg"])
+	}
+	return key.Public(), nil
+}
+
+// ValidMethods lists the algorithms tokens may use; pass it to jwt.WithValidMethods.
+func (r *KeyRing) ValidMethods() []string {
+	methods := []string{}
+	if r.alg != AlgHS256 {
+		methods = append(methods, AlgRS256, AlgEdDSA)
+	}
+	if r.secret != nil {
+		methods = append(methods, AlgHS256)
+	}
+	return methods
+}
+
+// Rotate makes a new key the signing key. The previous key is retired and
+// only used for verification until the retention period is over.
+func (r *KeyRing) Rotate() (*Key, error) {
+	if r.alg == AlgHS256 {
+		return nil, ErrRotationUnsupported
+	}
+	return r.rotate(true)
+}
+
+// rotate creates a new signing key while it holds the rotation lock, and drops
+// the keys whose retention is over. Unless force is set it keeps an
+// active key another instance created in the meantime.
+func (r *KeyRing) rotate(force bool) (*Key, error) {
+	if r.locker != nil {
+		ctx, cancel := context.WithTimeout(context.Background(), rotateLockWait)
+		defer cancel()
+		release, err := r.locker.Lock(ctx, rotateLockKey, rotateLockTTL)
+		if err != nil {
+			return nil, fmt.Errorf("jwtkeys: lock rotation: %w", err)
+		}
+		defer release()
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	// start from what is on disk so keys rotated by another instance are kept
+	if err := r.loadLocked(); err != nil {
+		return nil, err
+	}
+	if active := r.activeLocked(); active != nil && !force {
+		return active, nil
+	}
+
+	key, err := generateKey(r.alg, r.now())
+	if err != nil {
+		return nil, err
+	}
+	now := key.CreatedAt
+	kept := []*Key{key}
+	for _, k := range r.keys {
+		if k.RetiredAt.IsZero() {
+			k.RetiredAt = now
+		}
+		if !r.expired(k, now) {
+			kept = append(kept, k)
+		}
+	}
+
+	if err := writeKeyFile(filepath.Join(r.dir, key.ID+".pem"), key.private); err != nil {
+		return nil, err
+	}
+	if err := r.writeManifest(kept); err != nil {
+		return nil, err
+	}
+	for _, k := range r.keys {
+		if r.expired(k, now) {
+			_ = os.Remove(filepath.Join(r.dir, k.ID+".pem"))
+		}
+	}
+
+	r.keys = kept
+	return key, nil
+}
+
+// Keys returns the active key followed by the retired keys still accepted.
+func (r *KeyRing) Keys() []*Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	now := r.now()
+	keys := []*Key{}
+	for _, k := range r.keys {
+		if !r.expired(k, now) {
+			keys = append(keys, k)
+		}
+	}
+	return keys
+}
+
+// JWK is a public key in JSON Web Key format (RFC 7517).
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	Alg string `json:"alg"`
+	// RSA
+	N string `json:"n,omitempty"`
+	E string `json:"e,omitempty"`
+	// OKP (Ed25519)
+	Crv string `json:"crv,omitempty"`
+	X   string `json:"x,omitempty"`
+}
+
+// JWKS returns the public keys that verify tokens issued by this ring.
+func (r *KeyRing) JWKS() []JWK {
+	set := []JWK{}
+	for _, k := range r.Keys() {
+		jwk := JWK{Kid: k.ID, Use: "sig", Alg: k.Alg}
+		switch pub := k.Public().(type) {
+		case *rsa.PublicKey:
+			jwk.Kty = "RSA"
+			jwk.N = base64.RawURLEncoding.EncodeToString(pub.N.Bytes())
+			jwk.E = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes())
+		case ed25519.PublicKey:
+			jwk.Kty = "OKP"
+			jwk.Crv = "Ed25519"
+			jwk.X = base64.RawURLEncoding.EncodeToString(pub)
+		default:
+			continue
+		}
+		set = append(set, jwk)
+	}
+	return set
+}
+
+// find returns the key with the kid unless its retention is over.
+func (r *KeyRing) find(kid string) *Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, k := range r.keys {
+		if k.ID == kid && !r.expired(k, r.now()) {
+			return k
+		}
+	}
+	return nil
+}
+
+// active returns the signing key, or nil when the ring has none for its algorithm.
+func (r *KeyRing) active() *Key {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.activeLocked()
+}
+
+func (r *KeyRing) activeLocked() *Key {
+	if len(r.keys) == 0 || r.keys[0].Alg != r.alg || !r.keys[0].RetiredAt.IsZero() {
+		return nil
+	}
+	return r.keys[0]
+}
+
+// expired reports whether k was retired longer than the retention ago.
+func (r *KeyRing) expired(k *Key, now time.Time) bool {
+	return !k.RetiredAt.IsZero() && now.Sub(k.RetiredAt) >= r.retention
+}
+
+func (r *KeyRing) reloadDue() bool {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.now().Sub(r.lastReload) >= reloadInterval
+}
+
+func (r *KeyRing) reload() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.loadLocked()
+}
+
+// loadLocked reads the manifest and the key files it lists. Without a manifest
+// the keys in memory are kept; on first start that means there are none yet.
+// Keys whose retention is over stay listed until the next rotation deletes
+// them, but are no longer used.
+func (r *KeyRing) loadLocked() error {
+	r.lastReload = r.now()
+
+	data, err := os.ReadFile(filepath.Join(r.dir, manifestFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("jwtkeys: %w", err)
+	}
+
+	var m manifest
+	if err := json.Unmarshal(data, &m); err != nil {
+		return fmt.Errorf("jwtkeys: invalid %s: %w", manifestFile, err)
+	}
+	if len(m.Keys) == 0 {
+		return nil
+	}
+	for _, k := range m.Keys {
+		k.private, err = readKeyFile(filepath.Join(r.dir, k.ID+".pem"))
+		if err != nil {
+			return err
+		}
+	}
+	r.keys = m.Keys
+	return nil
+}
+
+// writeManifest replaces the manifest atomically so readers never see a partial file.
+func (r *KeyRing) writeManifest(keys []*Key) error {
+	data, err := json.MarshalIndent(manifest{Keys: keys}, "", "  ")
+	if err != nil {
+		return fmt.Errorf("jwtkeys: %w", err)
+	}
+	tmp := filepath.Join(r.dir, manifestFile+".tmp")
+	if err := os.WriteFile(tmp, data, 0o600); err != nil {
+		return fmt.Errorf("jwtkeys: %w", err)
+	}
+	if err := os.Rename(tmp, filepath.Join(r.dir, manifestFile)); err != nil {
+		return fmt.Errorf("jwtkeys: %w", err)
+	}
+	return nil
+}
+
+func generateKey(alg string, now time.Time) (*Key, error) {
+	var (
+		signer crypto.Signer
+		err    error
+	)
+	switch alg {
+	case AlgRS256:
+		signer, err = rsa.GenerateKey(rand.Reader, rsaKeyBits)
+	case AlgEdDSA:
+		_, signer, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, fmt.Errorf("jwtkeys: unsupported signing algorithm %q", alg)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("jwtkeys: generate key: %w", err)
+	}
+
+	id := make([]byte, 12)
+	if _, err := rand.Read(id); err != nil {
+		return nil, fmt.Errorf("jwtkeys: generate kid: %w", err)
+	}
+	return &Key{
+		ID:        base64.RawURLEncoding.EncodeToString(id),
+		Alg:       alg,
+		CreatedAt: now.UTC(),
+		private:   signer,
+	}, nil
+}
+
+func writeKeyFile(path string, key crypto.Signer) error {
+	der, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return fmt.Errorf("jwtkeys: %w", err)
+	}
+	data := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: der})
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("jwtkeys: %w", err)
+	}
+	return nil
+}
+
+func readKeyFile(path string) (crypto.Signer, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("jwtkeys: %w", err)
+	}
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("jwtkeys: %s is not PEM encoded", path)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("jwtkeys: %s: %w", path, err)
+	}
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("jwtkeys: %s holds an unsupported key type", path)
+	}
+	return signer, nil
+}
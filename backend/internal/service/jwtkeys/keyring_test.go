@@ -0,0 +1,220 @@
+package jwtkeys
+
+import (
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	redissvc "github.com/durianpay/fullstack-boilerplate/internal/service/redis"
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const testRetention = time.Hour
+
+// clock is a time source the tests move by hand.
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Add(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+func newTestRing(t *testing.T, dir string, alg string, c *clock) *KeyRing {
+	t.Helper()
+	r, err := NewKeyRing(dir, alg, testRetention, nil, false, nil)
+	if err != nil {
+		t.Fatalf("NewKeyRing: %v", err)
+	}
+	r.now = c.Now
+	// the keys were just loaded on the wall clock
+	r.lastReload = c.Now()
+	return r
+}
+
+func sign(t *testing.T, r *KeyRing) (string, string) {
+	t.Helper()
+	signed, err := r.Sign(jwt.RegisteredClaims{Subject: "1"})
+	if err != nil {
+		t.Fatalf("Sign: %v", err)
+	}
+	token, _, err := jwt.NewParser().ParseUnverified(signed, jwt.MapClaims{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	kid, _ := token.Header["kid"].(string)
+	return signed, kid
+}
+
+func verify(r *KeyRing, signed string) error {
+	_, err := jwt.Parse(signed, r.Keyfunc, jwt.WithValidMethods(r.ValidMethods()))
+	return err
+}
+
+func TestRotate(t *testing.T) {
+	for _, alg := range []string{AlgEdDSA, AlgRS256} {
+		t.Run(alg, func(t *testing.T) {
+			c := &clock{now: time.Now()}
+			r := newTestRing(t, t.TempDir(), alg, c)
+			old, oldKid := sign(t, r)
+
+			c.Add(time.Minute)
+			key, err := r.Rotate()
+			if err != nil {
+				t.Fatalf("Rotate: %v", err)
+			}
+			fresh, kid := sign(t, r)
+			if kid != key.ID || kid == oldKid {
+				t.Fatalf("signed with kid %q, want the new key %q", kid, key.ID)
+			}
+			if got := len(r.JWKS()); got != 2 {
+				t.Errorf("JWKS has %d keys during the grace period, want 2", got)
+			}
+
+			tests := []struct {
+				name    string
+				elapsed time.Duration
+				oldOK   bool
+			}{
+				{"right after the rotation", 0, true},
+				{"just inside the retention", testRetention - time.Second, true},
+				{"once the retention is over", time.Second, false},
+			}
+			for _, tt := range tests {
+				c.Add(tt.elapsed)
+				if err := verify(r, old); (err == nil) != tt.oldOK {
+					t.Errorf("%s: old token err = %v, want ok %v", tt.name, err, tt.oldOK)
+				}
+				if err := verify(r, fresh); err != nil {
+					t.Errorf("%s: new token: %v", tt.name, err)
+				}
+			}
+			if got := len(r.JWKS()); got != 1 {
+				t.Errorf("JWKS has %d keys after the retention, want 1", got)
+			}
+		})
+	}
+}
+
+func TestRotatePrunesExpiredKeys(t *testing.T) {
+	c := &clock{now: time.Now()}
+	dir := t.TempDir()
+	r := newTestRing(t, dir, AlgEdDSA, c)
+	first := r.Keys()[0]
+
+	if _, err := r.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+	c.Add(testRetention)
+	if _, err := r.Rotate(); err != nil {
+		t.Fatal(err)
+	}
+
+	// a fresh ring only sees what is left on disk
+	reopened := newTestRing(t, dir, AlgEdDSA, c)
+	for _, k := range reopened.Keys() {
+		if k.ID == first.ID {
+			t.Fatalf("key %s is still listed after its retention", first.ID)
+		}
+	}
+	if got := len(reopened.Keys()); got != 2 {
+		t.Errorf("got %d keys, want the active key and the one just retired", got)
+	}
+}
+
+func TestSignFollowsRotationOnAnotherInstance(t *testing.T) {
+	c := &clock{now: time.Now()}
+	dir := t.TempDir()
+	a := newTestRing(t, dir, AlgEdDSA, c)
+	b := newTestRing(t, dir, AlgEdDSA, c)
+	_, before := sign(t, a)
+
+	key, err := b.Rotate()
+	if err != nil {
+		t.Fatalf("Rotate: %v", err)
+	}
+	signed, kid := sign(t, a)
+	if kid != before {
+		t.Fatalf("signed with %q before the reload interval, want the previous key %q", kid, before)
+	}
+	// the key just retired is still accepted everywhere
+	if err := verify(b, signed); err != nil {
+		t.Fatalf("verify on the rotating instance: %v", err)
+	}
+
+	c.Add(reloadInterval)
+	if _, kid := sign(t, a); kid != key.ID {
+		t.Errorf("signed with %q after the reload interval, want the new key %q", kid, key.ID)
+	}
+}
+
+func TestConcurrentRotationsKeepEveryKey(t *testing.T) {
+	mr := miniredis.RunT(t)
+	dir := t.TempDir()
+	const instances, rotations = 3, 4
+
+	rings := make([]*KeyRing, instances)
+	for i := range rings {
+		r, err := NewKeyRing(dir, AlgEdDSA, testRetention, nil, false, redissvc.NewClient(mr.Addr()))
+		if err != nil {
+			t.Fatalf("NewKeyRing: %v", err)
+		}
+		rings[i] = r
+	}
+
+	var wg sync.WaitGroup
+	for _, r := range rings {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for range rotations {
+				if _, err := r.Rotate(); err != nil {
+					t.Errorf("Rotate: %v", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	reopened, err := NewKeyRing(dir, AlgEdDSA, testRetention, nil, false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	keys := reopened.Keys()
+	// the instances started with one shared key
+	if want := 1 + instances*rotations; len(keys) != want {
+		t.Fatalf("got %d keys, want %d", len(keys), want)
+	}
+	for i, k := range keys {
+		if k.RetiredAt.IsZero() != (i == 0) {
+			t.Errorf("key %d retired at %v", i, k.RetiredAt)
+		}
+	}
+}
+
+func TestHS256CannotRotate(t *testing.T) {
+	r, err := NewKeyRing(t.TempDir(), AlgHS256, testRetention, []byte("secret"), false, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := r.Rotate(); err != ErrRotationUnsupported {
+		t.Fatalf("Rotate err = %v, want ErrRotationUnsupported", err)
+	}
+	signed, err := r.Sign(jwt.RegisteredClaims{Subject: "1"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := verify(r, signed); err != nil {
+		t.Errorf("verify: %v", err)
+	}
+}
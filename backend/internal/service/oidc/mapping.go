@@ -0,0 +1,47 @@
+package oidc
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GroupRole grants Role to members of Group.
+type GroupRole struct {
+	Group string
+	Role  string
+}
+
+// RoleMapping maps IdP groups to dashboard roles. Earlier entries take
+// precedence, so list the most privileged role first.
+type RoleMapping []GroupRole
+
+// ParseRoleMapping reads "group=role" pairs separated by commas, such as
+// "dashboard-admins=superuser,payments-ops=operation,support=cs".
+func ParseRoleMapping(s string) (RoleMapping, error) {
+	var m RoleMapping
+	for _, pair := range strings.Split(s, ",") {
+		pair = strings.TrimSpace(pair)
+		if pair == "" {
+			continue
+		}
+		group, role, ok := strings.Cut(pair, "=")
+		group, role = strings.TrimSpace(group), strings.TrimSpace(role)
+		if !ok || group == "" || role == "" {
+			return nil, fmt.Errorf("oidc: invalid role mapping %q, want group=role", pair)
+		}
+		m = append(m, GroupRole{Group: group, Role: role})
+	}
+	return m, nil
+}
+
+// Role returns the role of the first entry whose group the user is in.
+func (m RoleMapping) Role(groups []string) (string, bool) {
+	for _, gr := range m {
+		for _, g := range groups {
+			if g == gr.Group {
+				return gr.Role, true
+			}
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,449 @@
+// Package oidc is a minimal OpenID Connect relying party for the
+// authorization code flow with PKCE (RFC 7636).
+//
+// The provider is configured by its issuer URL only; endpoints and signing
+// keys are read from its discovery document and JWKS, fetched on first use so
+// the server can start while the IdP is unreachable. ID tokens are verified
+// for signature, issuer, audience, expiry and nonce.
+package oidc
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/big"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	// an unknown kid triggers at most one JWKS refetch per interval
+	jwksRefreshInterval = 10 * time.Second
+	// tolerated clock difference with the IdP
+	clockSkew       = time.Minute
+	maxResponseSize = 1 << 20
+)
+
+// Config describes the client registration at the IdP.
+type Config struct {
+	Issuer       string
+	ClientID     string
+	ClientSecret string // empty for a public client, which then relies on PKCE alone
+	RedirectURL  string
+	Scopes       []string
+	// GroupsClaim names the ID token claim that lists the user's groups.
+	GroupsClaim string
+	HTTPClient  *http.Client
+}
+
+// Claims are the ID token claims the dashboard uses.
+type Claims struct {
+	Subject string
+	Email   string
+	// EmailVerified is nil when the IdP does not send email_verified.
+	EmailVerified *bool
+	Name          string
+	Groups        []string
+	// AMR and ACR say how the IdP authenticated the user (RFC 8176 methods and
+	// the authentication context class); both are empty when not sent.
+	AMR []string
+	ACR string
+}
+
+type discovery struct {
+	Issuer                string   `json:"issuer"`
+	AuthorizationEndpoint string   `json:"authorization_endpoint"`
+	TokenEndpoint         string   `json:"token_endpoint"`
+	JWKSURI               string   `json:"jwks_uri"`
+	SigningAlgs           []string `json:"id_token_signing_alg_values_supported"`
+	TokenAuthMethods      []string `json:"token_endpoint_auth_methods_supported"`
+}
+
+type Provider struct {
+	cfg Config
+
+	mu         sync.Mutex
+	meta       *discovery
+	keys       map[string]any
+	lastJWKSAt time.Time
+}
+
+func NewProvider(cfg Config) *Provider {
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 10 * time.Second}
+	}
+	if len(cfg.Scopes) == 0 {
+		cfg.Scopes = []string{"openid", "email", "profile"}
+	}
+	if cfg.GroupsClaim == "" {
+		cfg.GroupsClaim = "groups"
+	}
+	return &Provider{cfg: cfg}
+}
+
+func (p *Provider) Issuer() string {
+	return p.cfg.Issuer
+}
+
+// NewPKCEVerifier returns a random code verifier.
+func NewPKCEVerifier() (string, error) {
+	return randomString()
+}
+
+// PKCEChallenge derives the S256 code challenge sent with the authorization request.
+func PKCEChallenge(verifier string) string {
+	sum := sha256.Sum256([]byte(verifier))
+	return base64.RawURLEncoding.EncodeToString(sum[:])
+}
+
+// NewNonce returns a random value for the state or nonce parameter.
+func NewNonce() (string, error) {
+	return randomString()
+}
+
+func randomString() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+// AuthCodeURL builds the URL the browser is sent to for signing in at the IdP.
+func (p *Provider) AuthCodeURL(ctx context.Context, state string, nonce string, codeChallenge string) (string, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	q := url.Values{}
+	q.Set("response_type", "code")
+	q.Set("client_id", p.cfg.ClientID)
+	q.Set("redirect_uri", p.cfg.RedirectURL)
+	q.Set("scope", strings.Join(p.cfg.Scopes, " "))
+	q.Set("state", state)
+	q.Set("nonce", nonce)
+	q.Set("code_challenge", codeChallenge)
+	q.Set("code_challenge_method", "S256")
+
+	sep := "?"
+	if strings.Contains(meta.AuthorizationEndpoint, "?") {
+		sep = "&"
+	}
+	return meta.AuthorizationEndpoint + sep + q.Encode(), nil
+}
+
+// Exchange redeems an authorization code and returns the verified ID token claims.
+func (p *Provider) Exchange(ctx context.Context, code string, codeVerifier string, nonce string) (*Claims, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.cfg.RedirectURL)
+	form.Set("code_verifier", codeVerifier)
+
+	useBasic := p.cfg.ClientSecret != "" &&
+		(len(meta.TokenAuthMethods) == 0 || slices.Contains(meta.TokenAuthMethods, "client_secret_basic"))
+	if !useBasic {
+		form.Set("client_id", p.cfg.ClientID)
+		if p.cfg.ClientSecret != "" {
+			form.Set("client_secret", p.cfg.ClientSecret)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if useBasic {
+		req.SetBasicAuth(url.QueryEscape(p.cfg.ClientID), url.QueryEscape(p.cfg.ClientSecret))
+	}
+
+	var tok struct {
+		IDToken          string `json:"id_token"`
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	status, err := p.doJSON(req, &tok)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		if tok.Error != "" {
+			return nil, fmt.Errorf("oidc: token endpoint: %s: %s", tok.Error, tok.ErrorDescription)
+		}
+		return nil, fmt.Errorf("oidc: token endpoint returned status %d", status)
+	}
+	if tok.IDToken == "" {
+		return nil, errors.New("oidc: token response has no id_token")
+	}
+
+	return p.VerifyIDToken(ctx, tok.IDToken, nonce)
+}
+
+// VerifyIDToken checks the token's signature and standard claims and returns the claims.
+func (p *Provider) VerifyIDToken(ctx context.Context, rawIDToken string, nonce string) (*Claims, error) {
+	meta, err := p.discover(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := jwt.Parse(rawIDToken,
+		func(t *jwt.Token) (any, error) { return p.key(ctx, t) },
+		jwt.WithValidMethods(p.signingAlgs(meta)),
+		jwt.WithIssuer(meta.Issuer),
+		jwt.WithAudience(p.cfg.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: invalid id token: %w", err)
+	}
+	mc, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return nil, errors.New("oidc: invalid id token claims")
+	}
+
+	if got, _ := mc["nonce"].(string); got == "" || got != nonce {
+		return nil, errors.New("oidc: id token nonce does not match")
+	}
+	// with several audiences the token must have been issued to us
+	if aud, _ := mc.GetAudience(); len(aud) > 1 {
+		if azp, _ := mc["azp"].(string); azp != p.cfg.ClientID {
+			return nil, errors.New("oidc: id token was issued to another client")
+		}
+	}
+
+	claims := &Claims{}
+	claims.Subject, _ = mc["sub"].(string)
+	if claims.Subject == "" {
+		return nil, errors.New("oidc: id token has no subject")
+	}
+	claims.Email, _ = mc["email"].(string)
+	claims.Name, _ = mc["name"].(string)
+	if v, ok := mc["email_verified"].(bool); ok {
+		claims.EmailVerified = &v
+	}
+	claims.ACR, _ = mc["acr"].(string)
+	if amr, ok := mc["amr"].([]any); ok {
+		for _, m := range amr {
+			if s, ok := m.(string); ok {
+				claims.AMR = append(claims.AMR, s)
+			}
+		}
+	}
+	switch groups := mc[p.cfg.GroupsClaim].(type) {
+	case string:
+		claims.Groups = []string{groups}
+	case []any:
+		for _, g := range groups {
+			if s, ok := g.(string); ok {
+				claims.Groups = append(claims.Groups, s)
+			}
+		}
+	}
+	return claims, nil
+}
+
+// signingAlgs is what the IdP advertises, RS256 being the one every IdP must support.
+func (p *Provider) signingAlgs(meta *discovery) []string {
+	if len(meta.SigningAlgs) == 0 {
+		return []string{"RS256"}
+	}
+	// "none" is never acceptable, whatever the IdP claims
+	return slices.DeleteFunc(slices.Clone(meta.SigningAlgs), func(alg string) bool { return alg == "none" })
+}
+
+// discover fetches the discovery document once and caches it.
+func (p *Provider) discover(ctx context.Context) (*discovery, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.meta != nil {
+		return p.meta, nil
+	}
+
+	wellKnown := strings.TrimSuffix(p.cfg.Issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, wellKnown, nil)
+	if err != nil {
+		return nil, fmt.Errorf("oidc: %w", err)
+	}
+	var meta discovery
+	status, err := p.doJSON(req, &meta)
+	if err != nil {
+		return nil, err
+	}
+	if status != http.StatusOK {
+		return nil, fmt.Errorf("oidc: discovery returned status %d", status)
+	}
+	if meta.Issuer != p.cfg.Issuer {
+		return nil, fmt.Errorf("oidc: discovery issuer %q does not match %q", meta.Issuer, p.cfg.Issuer)
+	}
+	if meta.AuthorizationEndpoint == "" || meta.TokenEndpoint == "" || meta.JWKSURI == "" {
+		return nil, errors.New("oidc: discovery document is missing endpoints")
+	}
+
+	p.meta = &meta
+	return p.meta, nil
+}
+
+// key returns the IdP key for the token's kid, refetching the JWKS when the kid is new.
+func (p *Provider) key(ctx context.Context, t *jwt.Token) (any, error) {
+	kid, _ := t.Header["kid"].(string)
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	if k := p.findKey(kid); k != nil {
+		return k, nil
+	}
+	if time.Since(p.lastJWKSAt) < jwksRefreshInterval {
+		return nil, fmt.Errorf("unknown kid %q", kid)
+	}
+	if err := p.fetchJWKS(ctx); err != nil {
+		return nil, err
+	}
+	if k := p.findKey(kid); k != nil {
+		return k, nil
+	}
+	return nil, fmt.Errorf("unknown kid %q", kid)
+}
+
+// findKey matches by kid; a token without kid is accepted when the IdP has a single key.
+func (p *Provider) findKey(kid string) any {
+	if kid == "" && len(p.keys) == 1 {
+		for _, k := range p.keys {
+			return k
+		}
+	}
+	return p.keys[kid]
+}
+
+type jsonWebKey struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid"`
+	Use string `json:"use"`
+	N   string `json:"n"`
+	E   string `json:"e"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y"`
+}
+
+// fetchJWKS replaces the cached keys; the caller holds p.mu.
+func (p *Provider) fetchJWKS(ctx context.Context) error {
+	p.lastJWKSAt = time.Now()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, p.meta.JWKSURI, nil)
+	if err != nil {
+		return fmt.Errorf("oidc: %w", err)
+	}
+	var set struct {
+		Keys []jsonWebKey `json:"keys"`
+	}
+	status, err := p.doJSON(req, &set)
+	if err != nil {
+		return err
+	}
+	if status != http.StatusOK {
+		return fmt.Errorf("oidc: jwks returned status %d", status)
+	}
+
+	keys := map[string]any{}
+	for _, jwk := range set.Keys {
+		if jwk.Use != "" && jwk.Use != "sig" {
+			continue
+		}
+		pub, err := parseJWK(jwk)
+		if err != nil {
+			continue // unsupported key types are skipped, not fatal
+		}
+		keys[jwk.Kid] = pub
+	}
+	p.keys = keys
+	return nil
+}
+
+func parseJWK(k jsonWebKey) (any, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, err
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil {
+			return nil, err
+		}
+		return &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		default:
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil {
+			return nil, err
+		}
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, err
+		}
+		return &ecdsa.PublicKey{Curve: curve, X: new(big.Int).SetBytes(x), Y: new(big.Int).SetBytes(y)}, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("unsupported curve %q", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("invalid Ed25519 key")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("unsupported key type %q", k.Kty)
+	}
+}
+
+// doJSON sends req and decodes a JSON body of any status into v.
+func (p *Provider) doJSON(req *http.Request, v any) (int, error) {
+	resp, err := p.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("oidc: %w", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+	if err != nil {
+		return 0, fmt.Errorf("oidc: %w", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil && resp.StatusCode == http.StatusOK {
+		return 0, fmt.Errorf("oidc: invalid response from %s: %w", req.URL.Path, err)
+	}
+	return resp.StatusCode, nil
+}
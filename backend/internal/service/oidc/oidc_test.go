@@ -0,0 +1,354 @@
+package oidc
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	testClientID     = "dashboard"
+	testClientSecret = "s3cret"
+	testRedirectURL  = "http://localhost:5173/auth/callback"
+)
+
+// fakeIdP is a stand-in OpenID provider: it signs users in without asking
+// anything and issues ID tokens with the claims set on it.
+type fakeIdP struct {
+	t      *testing.T
+	server *httptest.Server
+	key    *rsa.PrivateKey
+
+	mu     sync.Mutex
+	claims jwt.MapClaims
+	grants map[string]grant // by authorization code
+}
+
+type grant struct {
+	challenge string
+	nonce     string
+}
+
+func newFakeIdP(t *testing.T) *fakeIdP {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	idp := &fakeIdP{
+		t:      t,
+		key:    key,
+		grants: map[string]grant{},
+		claims: jwt.MapClaims{
+			"sub":            "user-123",
+			"email":          "jane@example.com",
+			"email_verified": true,
+			"name":           "Jane Doe",
+			"groups":         []string{"staff", "payments-ops"},
+		},
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /.well-known/openid-configuration", idp.discovery)
+	mux.HandleFunc("GET /jwks", idp.jwks)
+	mux.HandleFunc("GET /authorize", idp.authorize)
+	mux.HandleFunc("POST /token", idp.token)
+	idp.server = httptest.NewServer(mux)
+	t.Cleanup(idp.server.Close)
+	return idp
+}
+
+func (idp *fakeIdP) issuer() string {
+	return idp.server.URL
+}
+
+func (idp *fakeIdP) provider() *Provider {
+	return NewProvider(Config{
+		Issuer:       idp.issuer(),
+		ClientID:     testClientID,
+		ClientSecret: testClientSecret,
+		RedirectURL:  testRedirectURL,
+		Scopes:       []string{"openid", "email", "profile", "groups"},
+	})
+}
+
+func (idp *fakeIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"issuer":                                idp.issuer(),
+		"authorization_endpoint":                idp.issuer() + "/authorize",
+		"token_endpoint":                        idp.issuer() + "/token",
+		"jwks_uri":                              idp.issuer() + "/jwks",
+		"id_token_signing_alg_values_supported": []string{"RS256"},
+		"token_endpoint_auth_methods_supported": []string{"client_secret_basic"},
+	})
+}
+
+func (idp *fakeIdP) jwks(w http.ResponseWriter, r *http.Request) {
+	pub := idp.key.PublicKey
+	writeJSON(w, http.StatusOK, map[string]any{"keys": []map[string]string{{
+		"kty": "RSA",
+		"kid": "test-key",
+		"use": "sig",
+		"alg": "RS256",
+		"n":   base64.RawURLEncoding.EncodeToString(pub.N.Bytes()),
+		"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(pub.E)).Bytes()),
+	}}})
+}
+
+// authorize signs the user in right away and redirects back with a code.
+func (idp *fakeIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	q := r.URL.Query()
+	if q.Get("client_id") != testClientID || q.Get("redirect_uri") != testRedirectURL ||
+		q.Get("response_type") != "code" || q.Get("code_challenge_method") != "S256" {
+		http.Error(w, "bad authorization request", http.StatusBadRequest)
+		return
+	}
+
+	code := rand.Text()
+	idp.mu.Lock()
+	idp.grants[code] = grant{challenge: q.Get("code_challenge"), nonce: q.Get("nonce")}
+	idp.mu.Unlock()
+
+	back := url.Values{"code": {code}, "state": {q.Get("state")}}
+	http.Redirect(w, r, testRedirectURL+"?"+back.Encode(), http.StatusFound)
+}
+
+func (idp *fakeIdP) token(w http.ResponseWriter, r *http.Request) {
+	id, secret, ok := r.BasicAuth()
+	if !ok || id != testClientID || secret != testClientSecret {
+		writeJSON(w, http.StatusUnauthorized, map[string]string{"error": "invalid_client"})
+		return
+	}
+	if err := r.ParseForm(); err != nil || r.PostForm.Get("grant_type") != "authorization_code" {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_request"})
+		return
+	}
+
+	idp.mu.Lock()
+	g, found := idp.grants[r.PostForm.Get("code")]
+	delete(idp.grants, r.PostForm.Get("code"))
+	idp.mu.Unlock()
+
+	sum := sha256.Sum256([]byte(r.PostForm.Get("code_verifier")))
+	if !found || base64.RawURLEncoding.EncodeToString(sum[:]) != g.challenge {
+		writeJSON(w, http.StatusBadRequest, map[string]string{"error": "invalid_grant"})
+		return
+	}
+
+	writeJSON(w, http.StatusOK, map[string]string{
+		"access_token": "opaque",
+		"token_type":   "Bearer",
+		"id_token":     idp.idToken(jwt.MapClaims{"nonce": g.nonce}),
+	})
+}
+
+// idToken signs the IdP's claims plus the standard ones, with overrides applied last.
+func (idp *fakeIdP) idToken(overrides jwt.MapClaims) string {
+	idp.mu.Lock()
+	defer idp.mu.Unlock()
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"iss": idp.issuer(),
+		"aud": testClientID,
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+	}
+	for k, v := range idp.claims {
+		claims[k] = v
+	}
+	for k, v := range overrides {
+		claims[k] = v
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = "test-key"
+	signed, err := token.SignedString(idp.key)
+	if err != nil {
+		idp.t.Fatal(err)
+	}
+	return signed
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+// signIn runs the browser part of the flow and returns the code and state sent to the redirect URL.
+func signIn(t *testing.T, p *Provider, state, nonce, verifier string) (string, string) {
+	t.Helper()
+	authURL, err := p.AuthCodeURL(context.Background(), state, nonce, PKCEChallenge(verifier))
+	if err != nil {
+		t.Fatalf("AuthCodeURL: %v", err)
+	}
+
+	browser := &http.Client{CheckRedirect: func(*http.Request, []*http.Request) error {
+		return http.ErrUseLastResponse
+	}}
+	resp, err := browser.Get(authURL)
+	if err != nil {
+		t.Fatalf("authorize: %v", err)
+	}
+	resp.Body.Close()
+	if resp.StatusCode != http.StatusFound {
+		t.Fatalf("authorize: status %d", resp.StatusCode)
+	}
+
+	loc, err := url.Parse(resp.Header.Get("Location"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(loc.String(), testRedirectURL) {
+		t.Fatalf("redirected to %s", loc)
+	}
+	return loc.Query().Get("code"), loc.Query().Get("state")
+}
+
+func TestAuthorizationCodeFlow(t *testing.T) {
+	idp := newFakeIdP(t)
+	idp.claims["amr"] = []string{"pwd", "mfa"}
+	idp.claims["acr"] = "urn:example:loa:2"
+	p := idp.provider()
+
+	verifier, _ := NewPKCEVerifier()
+	code, state := signIn(t, p, "state-1", "nonce-1", verifier)
+	if state != "state-1" {
+		t.Fatalf("state = %q, want state-1", state)
+	}
+
+	claims, err := p.Exchange(context.Background(), code, verifier, "nonce-1")
+	if err != nil {
+		t.Fatalf("Exchange: %v", err)
+	}
+	if claims.Subject != "user-123" || claims.Email != "jane@example.com" || claims.Name != "Jane Doe" {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+	if claims.EmailVerified == nil || !*claims.EmailVerified {
+		t.Errorf("email_verified not read: %+v", claims)
+	}
+	if len(claims.Groups) != 2 || claims.Groups[1] != "payments-ops" {
+		t.Errorf("groups = %v", claims.Groups)
+	}
+	if len(claims.AMR) != 2 || claims.AMR[1] != "mfa" || claims.ACR != "urn:example:loa:2" {
+		t.Errorf("amr = %v, acr = %q", claims.AMR, claims.ACR)
+	}
+
+	// codes are single use
+	if _, err := p.Exchange(context.Background(), code, verifier, "nonce-1"); err == nil {
+		t.Error("second exchange of the same code succeeded")
+	}
+}
+
+func TestExchangeRejectsWrongVerifier(t *testing.T) {
+	idp := newFakeIdP(t)
+	p := idp.provider()
+
+	verifier, _ := NewPKCEVerifier()
+	code, _ := signIn(t, p, "state", "nonce", verifier)
+
+	other, _ := NewPKCEVerifier()
+	if _, err := p.Exchange(context.Background(), code, other, "nonce"); err == nil || !strings.Contains(err.Error(), "invalid_grant") {
+		t.Fatalf("Exchange with another verifier: err = %v, want invalid_grant", err)
+	}
+}
+
+func TestVerifyIDTokenRejectsBadTokens(t *testing.T) {
+	idp := newFakeIdP(t)
+	p := idp.provider()
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	forged := jwt.NewWithClaims(jwt.SigningMethodRS256, jwt.MapClaims{
+		"iss": idp.issuer(), "aud": testClientID, "sub": "user-123", "nonce": "n",
+		"iat": time.Now().Unix(), "exp": time.Now().Add(time.Minute).Unix(),
+	})
+	forged.Header["kid"] = "test-key"
+	forgedToken, _ := forged.SignedString(otherKey)
+
+	unsigned := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{
+		"iss": idp.issuer(), "aud": testClientID, "sub": "user-123", "nonce": "n",
+		"iat": time.Now().Unix(), "exp": time.Now().Add(time.Minute).Unix(),
+	})
+	unsignedToken, _ := unsigned.SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+	tests := []struct {
+		name  string
+		token string
+	}{
+		{"wrong nonce", idp.idToken(jwt.MapClaims{"nonce": "other"})},
+		{"missing nonce", idp.idToken(nil)},
+		{"wrong audience", idp.idToken(jwt.MapClaims{"nonce": "n", "aud": "someone-else"})},
+		{"foreign azp", idp.idToken(jwt.MapClaims{"nonce": "n", "aud": []string{testClientID, "api"}, "azp": "api"})},
+		{"wrong issuer", idp.idToken(jwt.MapClaims{"nonce": "n", "iss": "https://evil.example.com"})},
+		{"expired", idp.idToken(jwt.MapClaims{"nonce": "n", "exp": time.Now().Add(-time.Hour).Unix()})},
+		{"no subject", idp.idToken(jwt.MapClaims{"nonce": "n", "sub": ""})},
+		{"bad signature", forgedToken},
+		{"alg none", unsignedToken},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if claims, err := p.VerifyIDToken(context.Background(), tt.token, "n"); err == nil {
+				t.Fatalf("token accepted: %+v", claims)
+			}
+		})
+	}
+
+	if _, err := p.VerifyIDToken(context.Background(), idp.idToken(jwt.MapClaims{"nonce": "n"}), "n"); err != nil {
+		t.Fatalf("valid token rejected: %v", err)
+	}
+}
+
+func TestDiscoveryRejectsIssuerMismatch(t *testing.T) {
+	idp := newFakeIdP(t)
+	p := NewProvider(Config{Issuer: idp.issuer() + "/", ClientID: testClientID, RedirectURL: testRedirectURL})
+
+	if _, err := p.AuthCodeURL(context.Background(), "s", "n", "c"); err == nil {
+		t.Fatal("discovery accepted a document for another issuer")
+	}
+}
+
+func TestRoleMapping(t *testing.T) {
+	m, err := ParseRoleMapping("admins=superuser, payments-ops=operation,staff=cs")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		groups []string
+		role   string
+		ok     bool
+	}{
+		{[]string{"staff", "payments-ops"}, "operation", true},
+		{[]string{"staff", "admins", "payments-ops"}, "superuser", true},
+		{[]string{"staff"}, "cs", true},
+		{[]string{"contractors"}, "", false},
+		{nil, "", false},
+	}
+	for _, tt := range tests {
+		role, ok := m.Role(tt.groups)
+		if role != tt.role || ok != tt.ok {
+			t.Errorf("Role(%v) = %q, %v; want %q, %v", tt.groups, role, ok, tt.role, tt.ok)
+		}
+	}
+
+	if _, err := ParseRoleMapping("admins"); err == nil {
+		t.Error("ParseRoleMapping accepted a pair without a role")
+	}
+}
@@ -0,0 +1,166 @@
+// Package password hashes and verifies passwords and checks new ones against
+// the password policy.
+//
+// Hashes are self-describing: bcrypt hashes carry their cost, and argon2id
+// hashes use the PHC string format with their parameters. Every Hasher can
+// therefore verify hashes made by any other one, and NeedsRehash tells when a
+// stored hash is weaker than what the Hasher would produce today, so it can be
+// upgraded the next time the password is known.
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	AlgBcrypt   = "bcrypt"
+	AlgArgon2id = "argon2id"
+
+	argon2SaltLength = 16
+	argon2KeyLength  = 32
+)
+
+// ErrUnknownHash is returned by Verify for hashes in an unsupported format.
+var ErrUnknownHash = errors.New("password: unknown hash format")
+
+// Hasher creates password hashes with one algorithm and verifies hashes of any supported one.
+type Hasher interface {
+	Hash(password string) (string, error)
+	Verify(hash string, password string) (bool, error)
+	// NeedsRehash reports whether hash was made with another algorithm or weaker parameters.
+	NeedsRehash(hash string) bool
+}
+
+// NewHasher returns the hasher for alg, bcrypt or argon2id.
+func NewHasher(alg string, bcryptCost int) (Hasher, error) {
+	switch alg {
+	case AlgBcrypt:
+		if bcryptCost < bcrypt.MinCost || bcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("password: bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+		return &Bcrypt{Cost: bcryptCost}, nil
+	case AlgArgon2id:
+		return &Argon2id{Params: DefaultArgon2Params}, nil
+	default:
+		return nil, fmt.Errorf("password: unsupported hash algorithm %q", alg)
+	}
+}
+
+// Verify checks password against a bcrypt or argon2id hash. An empty hash,
+// as kept for accounts that only sign in through SSO, matches no password.
+func Verify(hash string, password string) (bool, error) {
+	switch {
+	case hash == "":
+		return false, nil
+	case strings.HasPrefix(hash, "$2"):
+		err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		return err == nil, err
+	case strings.HasPrefix(hash, "$argon2id$"):
+		p, salt, key, err := decodeArgon2id(hash)
+		if err != nil {
+			return false, err
+		}
+		other := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, uint32(len(key)))
+		return subtle.ConstantTimeCompare(key, other) == 1, nil
+	default:
+		return false, ErrUnknownHash
+	}
+}
+
+// Bcrypt hashes with bcrypt at the given cost.
+type Bcrypt struct {
+	Cost int
+}
+
+func (b *Bcrypt) Hash(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), b.Cost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
+}
+
+func (b *Bcrypt) Verify(hash string, password string) (bool, error) {
+	return Verify(hash, password)
+}
+
+func (b *Bcrypt) NeedsRehash(hash string) bool {
+	cost, err := bcrypt.Cost([]byte(hash))
+	return err != nil || cost < b.Cost
+}
+
+// Argon2Params are the argon2id cost parameters; Memory is in KiB.
+type Argon2Params struct {
+	Memory      uint32
+	Iterations  uint32
+	Parallelism uint8
+}
+
+// DefaultArgon2Params follow the second recommended option of RFC 9106.
+var DefaultArgon2Params = Argon2Params{Memory: 64 * 1024, Iterations: 3, Parallelism: 4}
+
+// Argon2id hashes with argon2id and encodes the result in PHC string format.
+type Argon2id struct {
+	Params Argon2Params
+}
+
+func (a *Argon2id) Hash(password string) (string, error) {
+	salt := make([]byte, argon2SaltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", err
+	}
+	p := a.Params
+	key := argon2.IDKey([]byte(password), salt, p.Iterations, p.Memory, p.Parallelism, argon2KeyLength)
+	return fmt.Sprintf("$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version, p.Memory, p.Iterations, p.Parallelism,
+		base64.RawStdEncoding.EncodeToString(salt), base64.RawStdEncoding.EncodeToString(key)), nil
+}
+
+func (a *Argon2id) Verify(hash string, password string) (bool, error) {
+	return Verify(hash, password)
+}
+
+func (a *Argon2id) NeedsRehash(hash string) bool {
+	p, _, _, err := decodeArgon2id(hash)
+	if err != nil {
+		return true
+	}
+	return p.Memory < a.Params.Memory || p.Iterations < a.Params.Iterations || p.Parallelism < a.Params.Parallelism
+}
+
+// decodeArgon2id parses $argon2id$v=19$m=65536,t=3,p=4$<salt>$<key>.
+func decodeArgon2id(hash string) (Argon2Params, []byte, []byte, error) {
+	var p Argon2Params
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != AlgArgon2id {
+		return p, nil, nil, ErrUnknownHash
+	}
+
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return p, nil, nil, fmt.Errorf("password: unsupported argon2 version %q", parts[2])
+	}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.Memory, &p.Iterations, &p.Parallelism); err != nil {
+		return p, nil, nil, fmt.Errorf("password: invalid argon2 parameters: %w", err)
+	}
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return p, nil, nil, fmt.Errorf("password: invalid argon2 salt: %w", err)
+	}
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return p, nil, nil, fmt.Errorf("password: invalid argon2 hash")
+	}
+	return p, salt, key, nil
+}
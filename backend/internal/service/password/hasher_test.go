@@ -0,0 +1,107 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+// testArgon2Params keep the tests fast; only their order matters here.
+var testArgon2Params = Argon2Params{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func mustHash(t *testing.T, h Hasher, password string) string {
+	t.Helper()
+	hash, err := h.Hash(password)
+	if err != nil {
+		t.Fatalf("Hash: %v", err)
+	}
+	return hash
+}
+
+func TestVerifyAcrossAlgorithms(t *testing.T) {
+	hashers := map[string]Hasher{
+		AlgBcrypt:   &Bcrypt{Cost: bcrypt.MinCost},
+		AlgArgon2id: &Argon2id{Params: testArgon2Params},
+	}
+	for alg, h := range hashers {
+		hash := mustHash(t, h, "s3cret-Pass")
+		for name, verifier := range hashers {
+			tests := []struct {
+				password string
+				ok       bool
+			}{
+				{"s3cret-Pass", true},
+				{"s3cret-pass", false},
+				{"", false},
+			}
+			for _, tt := range tests {
+				ok, err := verifier.Verify(hash, tt.password)
+				if err != nil || ok != tt.ok {
+					t.Errorf("%s hash, %s verifier, %q: ok %v err %v, want %v", alg, name, tt.password, ok, err, tt.ok)
+				}
+			}
+		}
+	}
+}
+
+func TestVerifyUnusableHashes(t *testing.T) {
+	tests := []struct {
+		name    string
+		hash    string
+		wantErr bool
+	}{
+		{"empty hash of an SSO-only account", "", false},
+		{"unknown format", "plain-text", true},
+		{"argon2id with other version", "$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$a2V5", true},
+		{"argon2id without a key", "$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ok, err := Verify(tt.hash, "")
+			if ok || (err != nil) != tt.wantErr {
+				t.Errorf("Verify = %v, %v; want no match and error %v", ok, err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestNeedsRehash(t *testing.T) {
+	bcryptMin := mustHash(t, &Bcrypt{Cost: bcrypt.MinCost}, "pw")
+	bcryptHigher := mustHash(t, &Bcrypt{Cost: bcrypt.MinCost + 1}, "pw")
+	argon := mustHash(t, &Argon2id{Params: testArgon2Params}, "pw")
+	stronger := testArgon2Params
+	stronger.Iterations++
+
+	tests := []struct {
+		name   string
+		hasher Hasher
+		hash   string
+		want   bool
+	}{
+		{"bcrypt at the same cost", &Bcrypt{Cost: bcrypt.MinCost}, bcryptMin, false},
+		{"bcrypt at a higher cost", &Bcrypt{Cost: bcrypt.MinCost}, bcryptHigher, false},
+		{"bcrypt at a lower cost", &Bcrypt{Cost: bcrypt.MinCost + 1}, bcryptMin, true},
+		{"argon2id hash for bcrypt", &Bcrypt{Cost: bcrypt.MinCost}, argon, true},
+		{"bcrypt hash for argon2id", &Argon2id{Params: testArgon2Params}, bcryptMin, true},
+		{"argon2id with the same parameters", &Argon2id{Params: testArgon2Params}, argon, false},
+		{"argon2id with weaker parameters", &Argon2id{Params: stronger}, argon, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.hasher.NeedsRehash(tt.hash); got != tt.want {
+				t.Errorf("NeedsRehash = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestArgon2idHashFormat(t *testing.T) {
+	hash := mustHash(t, &Argon2id{Params: testArgon2Params}, "pw")
+	if !strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Errorf("hash = %q, want the PHC string format with its parameters", hash)
+	}
+	if other := mustHash(t, &Argon2id{Params: testArgon2Params}, "pw"); other == hash {
+		t.Error("two hashes of one password are equal; the salt is not random")
+	}
+}
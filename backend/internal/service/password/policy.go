@@ -0,0 +1,87 @@
+package password
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// maxLength caps passwords well above anything a person types; bcrypt
+// ignores everything past 72 bytes anyway.
+const maxLength = 72
+
+// Policy decides whether a new password is acceptable.
+type Policy struct {
+	MinLength int
+	// MinClasses is how many of lowercase, uppercase, digits and symbols must appear.
+	MinClasses int
+	breached   map[string]struct{}
+}
+
+// LoadBreachedList reads a list of known breached passwords, one per line.
+// Blank lines and lines starting with # are skipped; matching ignores case.
+func (p *Policy) LoadBreachedList(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("password: %w", err)
+	}
+	defer f.Close()
+
+	breached := map[string]struct{}{}
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		breached[strings.ToLower(line)] = struct{}{}
+	}
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("password: read %s: %w", path, err)
+	}
+	p.breached = breached
+	return nil
+}
+
+// Check returns an error when password breaks the policy. Its message is meant for the user.
+func (p *Policy) Check(password string) error {
+	n := utf8.RuneCountInString(password)
+	if n < p.MinLength {
+		return fmt.Errorf("password must be at least %d characters", p.MinLength)
+	}
+	if len(password) > maxLength {
+		return fmt.Errorf("password must be at most %d bytes", maxLength)
+	}
+
+	var lower, upper, digit, symbol bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			symbol = true
+		}
+	}
+	classes := 0
+	for _, ok := range []bool{lower, upper, digit, symbol} {
+		if ok {
+			classes++
+		}
+	}
+	if classes < p.MinClasses {
+		return fmt.Errorf("password must contain at least %d of: lowercase letters, uppercase letters, digits, symbols", p.MinClasses)
+	}
+
+	if _, ok := p.breached[strings.ToLower(password)]; ok {
+		return errors.New("password appears in a list of breached passwords, choose another one")
+	}
+	return nil
+}
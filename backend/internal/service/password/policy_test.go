@@ -0,0 +1,42 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestPolicyCheck(t *testing.T) {
+	list := filepath.Join(t.TempDir(), "breached.txt")
+	if err := os.WriteFile(list, []byte("# known passwords\n\nPassword@123\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	p := &Policy{MinLength: 8, MinClasses: 3}
+	if err := p.LoadBreachedList(list); err != nil {
+		t.Fatalf("LoadBreachedList: %v", err)
+	}
+
+	tests := []struct {
+		name     string
+		password string
+		ok       bool
+	}{
+		{"three classes", "Correct-horse", true},
+		{"four classes", "Correct-h0rse", true},
+		{"too short", "Ab1-", false},
+		{"length counts characters, not bytes", "Äöü-ßéñ1", true},
+		{"at the byte limit", "Aa1-" + strings.Repeat("x", maxLength-4), true},
+		{"over the byte limit", "Aa1-" + strings.Repeat("x", maxLength-3), false},
+		{"two classes", "correcthorse1", false},
+		{"breached", "Password@123", false},
+		{"breached in another case", "PASSWORD@123", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := p.Check(tt.password); (err == nil) != tt.ok {
+				t.Errorf("Check(%q) = %v, want ok %v", tt.password, err, tt.ok)
+			}
+		})
+	}
+}
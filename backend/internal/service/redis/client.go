@@ -2,6 +2,8 @@ package redis
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"log"
 	"time"
 
@@ -30,14 +32,117 @@ func (c *Client) Set(ctx context.Context, key string, value string, ttl time.Dur
 	return c.rdb.Set(ctx, key, value, ttl).Err()
 }
 
+// SetNX sets key only if it does not exist yet and reports whether it did.
+func (c *Client) SetNX(ctx context.Context, key string, value string, ttl time.Duration) (bool, error) {
+	return c.rdb.SetNX(ctx, key, value, ttl).Result()
+}
+
+// setIfGreater stores ARGV[1] in KEYS[1] unless the key holds a number that is
+// at least as large, and returns 1 when it stored it.
+var setIfGreater = redis.NewScript(`
+local current = tonumber(redis.call("GET", KEYS[1]))
+if current and current >= tonumber(ARGV[1]) then
+	return 0
+end
+redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+return 1
+`)
+
+// SetIfGreater atomically sets key to value unless it already holds a value
+// that is not smaller, and reports whether it did.
+func (c *Client) SetIfGreater(ctx context.Context, key string, value uint64, ttl time.Duration) (bool, error) {
+	n, err := setIfGreater.Run(ctx, c.rdb, []string{key}, value, ttl.Milliseconds()).Int()
+	if err != nil {
+		return false, err
+	}
+	return n == 1, nil
+}
+
+// lockRetryInterval is how often Lock retries a lock held by someone else.
+const lockRetryInterval = 50 * time.Millisecond
+
+// releaseLock deletes KEYS[1] only while it still holds the token ARGV[1], so a
+// holder whose lock expired cannot free the next holder's.
+var releaseLock = redis.NewScript(`
+if redis.call("GET", KEYS[1]) == ARGV[1] then
+	return redis.call("DEL", KEYS[1])
+end
+return 0
+`)
+
+// Lock takes the lock key, waiting until it is free or ctx is done, and returns
+// the func that releases it. The lock expires after ttl should the holder die.
+func (c *Client) Lock(ctx context.Context, key string, ttl time.Duration) (func(), error) {
+	raw := make([]byte, 16)
+	if _, err := rand.Read(raw); err != nil {
+		return nil, err
+	}
+	token := hex.EncodeToString(raw)
+	for {
+		ok, err := c.rdb.SetNX(ctx, key, token, ttl).Result()
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			return func() {
+				_ = releaseLock.Run(context.Background(), c.rdb, []string{key}, token).Err()
+			}, nil
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(lockRetryInterval):
+		}
+	}
+}
+
 func (c *Client) Get(ctx context.Context, key string) (string, error) {
 	return c.rdb.Get(ctx, key).Result()
 }
 
+// GetDel returns the value of key and deletes it atomically.
+func (c *Client) GetDel(ctx context.Context, key string) (string, error) {
+	return c.rdb.GetDel(ctx, key).Result()
+}
+
 func (c *Client) Del(ctx context.Context, keys ...string) error {
 	return c.rdb.Del(ctx, keys...).Err()
 }
 
+func (c *Client) Exists(ctx context.Context, key string) (bool, error) {
+	n, err := c.rdb.Exists(ctx, key).Result()
+	if err != nil {
+		return false, err
+	}
+	return n > 0, nil
+}
+
+func (c *Client) Incr(ctx context.Context, key string) (int64, error) {
+	return c.rdb.Incr(ctx, key).Result()
+}
+
+// PTTL returns the remaining time to live of key; it is negative when the key
+// does not exist or has no expiry.
+func (c *Client) PTTL(ctx context.Context, key string) (time.Duration, error) {
+	return c.rdb.PTTL(ctx, key).Result()
+}
+
+func (c *Client) Expire(ctx context.Context, key string, ttl time.Duration) error {
+	return c.rdb.Expire(ctx, key, ttl).Err()
+}
+
+func (c *Client) SAdd(ctx context.Context, key string, members ...string) error {
+	return c.rdb.SAdd(ctx, key, members).Err()
+}
+
+func (c *Client) SRem(ctx context.Context, key string, members ...string) error {
+	return c.rdb.SRem(ctx, key, members).Err()
+}
+
+func (c *Client) SMembers(ctx context.Context, key string) ([]string, error) {
+	return c.rdb.SMembers(ctx, key).Result()
+}
+
 func (c *Client) Close() error {
 	return c.rdb.Close()
 }
@@ -4,7 +4,10 @@ import (
 	"encoding/json"
 	"errors"
 	"io"
+	"net"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/durianpay/fullstack-boilerplate/internal/entity"
 )
@@ -21,8 +24,18 @@ func CodeToStatus(code entity.Code) int {
 		return http.StatusBadRequest
 	case entity.ErrorCodeUnauthorized:
 		return http.StatusUnauthorized
+	case entity.ErrorCodeForbidden:
+		return http.StatusForbidden
 	case entity.ErrorCodeNotFound:
 		return http.StatusNotFound
+	case entity.ErrorCodeConflict:
+		return http.StatusConflict
+	case entity.ErrorCodeTooMany:
+		return http.StatusTooManyRequests
+	case entity.ErrorCodePreconditionFailed:
+		return http.StatusPreconditionFailed
+	case entity.ErrorCodePreconditionRequired:
+		return http.StatusPreconditionRequired
 	default:
 		return http.StatusInternalServerError
 	}
@@ -30,6 +43,11 @@ func CodeToStatus(code entity.Code) int {
 
 func WriteAppError(w http.ResponseWriter, appErr *entity.AppError) {
 	status := CodeToStatus(appErr.Code)
+	if appErr.RetryAfter > 0 {
+		// whole seconds, rounded up so clients never retry too early
+		secs := int64((appErr.RetryAfter + time.Second - 1) / time.Second)
+		w.Header().Set("Retry-After", strconv.FormatInt(secs, 10))
+	}
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(status)
 	resp := ErrorResponse{
@@ -93,3 +111,12 @@ func WriteJSON(w http.ResponseWriter, status int, data any) {
 		http.Error(w, "internal server error", http.StatusInternalServerError)
 	}
 }
+
+// ClientIP returns the host part of the request's remote address.
+func ClientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
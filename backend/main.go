@@ -2,19 +2,32 @@ package main
 
 import (
 	"database/sql"
+	"fmt"
 	"log"
+	"strings"
 	"time"
 
 	"github.com/durianpay/fullstack-boilerplate/internal/api"
 	"github.com/durianpay/fullstack-boilerplate/internal/config"
+	"github.com/durianpay/fullstack-boilerplate/internal/entity"
+	kh "github.com/durianpay/fullstack-boilerplate/internal/module/apikey/handler"
+	kr "github.com/durianpay/fullstack-boilerplate/internal/module/apikey/repository"
+	ku "github.com/durianpay/fullstack-boilerplate/internal/module/apikey/usecase"
 	ah "github.com/durianpay/fullstack-boilerplate/internal/module/auth/handler"
 	ar "github.com/durianpay/fullstack-boilerplate/internal/module/auth/repository"
 	au "github.com/durianpay/fullstack-boilerplate/internal/module/auth/usecase"
 	ph "github.com/durianpay/fullstack-boilerplate/internal/module/payment/handler"
 	pr "github.com/durianpay/fullstack-boilerplate/internal/module/payment/repository"
 	pu "github.com/durianpay/fullstack-boilerplate/internal/module/payment/usecase"
+	uh "github.com/durianpay/fullstack-boilerplate/internal/module/user/handler"
+	uu "github.com/durianpay/fullstack-boilerplate/internal/module/user/usecase"
 	"github.com/durianpay/fullstack-boilerplate/internal/seeder"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/fxrates"
 	srv "github.com/durianpay/fullstack-boilerplate/internal/service/http"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/jwtkeys"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/mailer"
+	"github.com/durianpay/fullstack-boilerplate/internal/service/oidc"
+	passwordsvc "github.com/durianpay/fullstack-boilerplate/internal/service/password"
 	redissvc "github.com/durianpay/fullstack-boilerplate/internal/service/redis"
 	"github.com/joho/godotenv"
 	_ "github.com/mattn/go-sqlite3"
@@ -29,7 +42,19 @@ func main() {
 	}
 	defer db.Close()
 
-	if err := seeder.Seed(db); err != nil {
+	// Passwords: new hashes use the configured algorithm, older ones are upgraded on login
+	passwords, err := passwordsvc.NewHasher(config.PasswordHashAlg, config.PasswordBcryptCost)
+	if err != nil {
+		log.Fatal(err)
+	}
+	passwordPolicy := &passwordsvc.Policy{MinLength: config.PasswordMinLength, MinClasses: config.PasswordMinClasses}
+	if config.PasswordBreachedListFile != "" {
+		if err := passwordPolicy.LoadBreachedList(config.PasswordBreachedListFile); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	if err := seeder.Seed(db, passwords); err != nil {
 		log.Fatal(err)
 	}
 
@@ -42,22 +67,106 @@ func main() {
 	redisClient := redissvc.NewClient(config.RedisAddr)
 	defer redisClient.Close()
 
+	// JWT keys: tokens must stay verifiable for as long as the longest-lived one
+	keyRetention := max(JwtExpiredDuration, au.RefreshTokenTTL)
+	keyRing, err := jwtkeys.NewKeyRing(config.JwtKeysDir, config.JwtSigningAlg, keyRetention, config.JwtSecret, config.JwtAcceptHS256, redisClient)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	// Mailer: write to a local outbox file when configured, otherwise to the log
+	var mail mailer.Mailer = mailer.NewLogMailer()
+	if config.MailOutboxFile != "" {
+		mail = mailer.NewFileMailer(config.MailOutboxFile)
+	}
+
 	userRepo := ar.NewUserRepo(db)
-	authUC := au.NewAuthUsecase(userRepo, redisClient, config.JwtSecret, JwtExpiredDuration)
+	lockout := au.LoginLockout{
+		BackoffAfter:    config.LoginBackoffAfter,
+		BackoffBase:     config.LoginBackoffBase,
+		MaxAttempts:     config.LoginMaxAttempts,
+		IPMaxAttempts:   config.LoginIPMaxAttempts,
+		LockoutDuration: config.LoginLockoutDuration,
+	}
+	sso, err := newSSO()
+	if err != nil {
+		log.Fatal(err)
+	}
+	authUC := au.NewAuthUsecase(userRepo, redisClient, mail, keyRing, passwords, passwordPolicy, JwtExpiredDuration, config.ImpersonationTTL, config.PasswordResetURL, lockout, sso)
 	authH := ah.NewAuthHandler(authUC)
 
+	userUC := uu.NewUserUsecase(userRepo, authUC, authUC, authUC, passwords, passwordPolicy)
+	userH := uh.NewUserHandler(userUC)
+
+	// FX rates: the file is loaded again on every start, replacing rates it repeats
+	fxRateRepo := pr.NewFXRateRepo(db)
+	if config.FXRatesFile != "" {
+		rates, err := fxrates.LoadFile(config.FXRatesFile)
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := fxRateRepo.SaveFXRates(rates); err != nil {
+			log.Fatal(err)
+		}
+		log.Printf("loaded %d fx rates from %s", len(rates), config.FXRatesFile)
+	}
+
 	paymentRepo := pr.NewPaymentRepo(db)
-	paymentUC := pu.NewPaymentUsecase(paymentRepo, redisClient)
+	paymentUC := pu.NewPaymentUsecase(paymentRepo, fxRateRepo, redisClient)
 	paymentH := ph.NewPaymentHandler(paymentUC)
 
+	apiKeyRepo := kr.NewAPIKeyRepo(db)
+	apiKeyUC := ku.NewAPIKeyUsecase(apiKeyRepo, userRepo)
+	apiKeyH := kh.NewAPIKeyHandler(apiKeyUC)
+
 	apiHandler := &api.APIHandler{
+		APIKey:  apiKeyH,
 		Auth:    authH,
 		Payment: paymentH,
+		User:    userH,
 	}
 
-	server := srv.NewServer(apiHandler, config.OpenapiYamlLocation, config.JwtSecret)
+	server := srv.NewServer(apiHandler, config.OpenapiYamlLocation, keyRing, authUC, apiKeyUC, authUC)
 
 	addr := config.HttpAddress
 	log.Printf("starting server on %s", addr)
 	server.Start(addr)
 }
+
+// newSSO builds the single sign-on settings; SSO stays off without OIDC_ISSUER.
+func newSSO() (au.SSO, error) {
+	if config.OIDCIssuer == "" {
+		return au.SSO{}, nil
+	}
+	if config.OIDCClientID == "" {
+		return au.SSO{}, fmt.Errorf("config: OIDC_CLIENT_ID is required when OIDC_ISSUER is set")
+	}
+	roles, err := oidc.ParseRoleMapping(config.OIDCRoleMapping)
+	if err != nil {
+		return au.SSO{}, err
+	}
+	for _, gr := range roles {
+		if !entity.ValidRole(gr.Role) {
+			return au.SSO{}, fmt.Errorf("config: OIDC_ROLE_MAPPING has unknown role %q", gr.Role)
+		}
+	}
+	if config.OIDCDefaultRole != "" && !entity.ValidRole(config.OIDCDefaultRole) {
+		return au.SSO{}, fmt.Errorf("config: OIDC_DEFAULT_ROLE has unknown role %q", config.OIDCDefaultRole)
+	}
+
+	provider := oidc.NewProvider(oidc.Config{
+		Issuer:       config.OIDCIssuer,
+		ClientID:     config.OIDCClientID,
+		ClientSecret: config.OIDCClientSecret,
+		RedirectURL:  config.OIDCRedirectURL,
+		Scopes:       strings.Fields(config.OIDCScopes),
+		GroupsClaim:  config.OIDCGroupsClaim,
+	})
+	return au.SSO{
+		Provider:      provider,
+		Roles:         roles,
+		DefaultRole:   config.OIDCDefaultRole,
+		AutoProvision: config.OIDCAutoProvision,
+		MFAACRValues:  strings.Fields(config.OIDCMFAACRValues),
+	}, nil
+}
@@ -1,3 +1,15 @@
 export const PAYMENT_ENDPOINTS = {
   LIST: "/dashboard/v1/payments",
+  SUMMARY: "/dashboard/v1/payments/summary",
+  DETAIL: (id: string) => `/dashboard/v1/payments/${encodeURIComponent(id)}`,
+  EVENTS: (id: string) =>
+    `/dashboard/v1/payments/${encodeURIComponent(id)}/events`,
+  REFUNDS: (id: string) =>
+    `/dashboard/v1/payments/${encodeURIComponent(id)}/refunds`,
+  REFUND_APPROVE: (id: string, refundId: string) =>
+    `/dashboard/v1/payments/${encodeURIComponent(id)}/refunds/${encodeURIComponent(refundId)}/approve`,
+  REFUND_REJECT: (id: string, refundId: string) =>
+    `/dashboard/v1/payments/${encodeURIComponent(id)}/refunds/${encodeURIComponent(refundId)}/reject`,
+  STATUS: (id: string) =>
+    `/dashboard/v1/payments/${encodeURIComponent(id)}/status`,
 } as const;
@@ -1,4 +1,4 @@
 export const USER_ENDPOINTS = {
-  PROFILE: "/api/v1/users/profile",
-  UPDATE: "/api/v1/users/update",
+  PROFILE: "/dashboard/v1/users/profile",
+  UPDATE: "/dashboard/v1/users/profile",
 } as const;
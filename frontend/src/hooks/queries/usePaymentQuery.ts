@@ -4,15 +4,56 @@
  */
 
 import { paymentService } from "@/services";
-import type { PaymentListParams, PaymentListResponse } from "@/types/payment";
-import { useQuery } from "@tanstack/react-query";
+import type {
+  PaymentDetail,
+  PaymentEventListResponse,
+  PaymentListParams,
+  PaymentListResponse,
+  PaymentSummaryParams,
+  PaymentSummaryResponse,
+} from "@/types/payment";
+import { keepPreviousData, useQuery } from "@tanstack/react-query";
 
 /**
- * Fetch payments list
+ * Fetch one page of payments; the previous page stays on screen while the next
+ * one loads
  */
 export const usePaymentListQuery = (params?: PaymentListParams) => {
   return useQuery<PaymentListResponse>({
     queryKey: ["payments", params],
     queryFn: () => paymentService.listPayments(params),
+    placeholderData: keepPreviousData,
+  });
+};
+
+/**
+ * Fetch payment counts and daily totals, added up by the server
+ */
+export const usePaymentSummaryQuery = (params: PaymentSummaryParams) => {
+  return useQuery<PaymentSummaryResponse>({
+    queryKey: ["payments", "summary", params],
+    queryFn: () => paymentService.getPaymentSummary(params),
+  });
+};
+
+/**
+ * Fetch a single payment with its history and refunds; disabled until an id is given
+ */
+export const usePaymentQuery = (id?: string) => {
+  return useQuery<PaymentDetail>({
+    queryKey: ["payment", id],
+    queryFn: () => paymentService.getPayment(id!),
+    enabled: !!id,
+  });
+};
+
+/**
+ * Fetch a payment's history; disabled until an id is given
+ */
+export const usePaymentEventsQuery = (id?: string) => {
+  return useQuery<PaymentEventListResponse>({
+    queryKey: ["payment", id, "events"],
+    queryFn: () => paymentService.listPaymentEvents(id!),
+    enabled: !!id,
   });
 };
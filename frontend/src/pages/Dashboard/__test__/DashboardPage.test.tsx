@@ -4,7 +4,10 @@
 
 import DashboardPage from "@/pages/Dashboard/index";
 import * as paymentServiceModule from "@/services/paymentService";
-import type { PaymentListResponse } from "@/types/payment";
+import type {
+  PaymentListResponse,
+  PaymentSummaryResponse,
+} from "@/types/payment";
 import { QueryClient, QueryClientProvider } from "@tanstack/react-query";
 import { render, screen, waitFor } from "@testing-library/react";
 import type { ReactNode } from "react";
@@ -14,6 +17,7 @@ import { MemoryRouter } from "react-router";
 vi.mock("@/services/paymentService", () => ({
   paymentService: {
     listPayments: vi.fn(),
+    getPaymentSummary: vi.fn(),
   },
 }));
 
@@ -60,6 +64,9 @@ vi.mock("@/stores", () => ({
 const mockListPayments = vi.mocked(
   paymentServiceModule.paymentService.listPayments,
 );
+const mockGetPaymentSummary = vi.mocked(
+  paymentServiceModule.paymentService.getPaymentSummary,
+);
 
 const mockPaymentData: PaymentListResponse = {
   payments: [
@@ -101,6 +108,26 @@ const mockPaymentData: PaymentListResponse = {
   ],
 };
 
+const mockSummaryData: PaymentSummaryResponse = {
+  report_currency: "IDR",
+  from: "2026-01-22",
+  to: "2026-02-20",
+  total: 5,
+  completed: 3,
+  processing: 1,
+  failed: 1,
+  days: [
+    {
+      date: "2026-02-20",
+      total: "50000.00",
+      completed: "50000.00",
+      processing: "0.00",
+      failed: "0.00",
+    },
+  ],
+  unconverted: [],
+};
+
 function createWrapper() {
   const queryClient = new QueryClient({
     defaultOptions: {
@@ -123,6 +150,7 @@ describe("DashboardPage", () => {
 
   it("renders page title and subtitle", () => {
     mockListPayments.mockReturnValue(new Promise(() => {}));
+    mockGetPaymentSummary.mockReturnValue(new Promise(() => {}));
     const Wrapper = createWrapper();
     render(
       <Wrapper>
@@ -138,6 +166,7 @@ describe("DashboardPage", () => {
 
   it("renders the Payment Overview heading", () => {
     mockListPayments.mockReturnValue(new Promise(() => {}));
+    mockGetPaymentSummary.mockReturnValue(new Promise(() => {}));
     const Wrapper = createWrapper();
     render(
       <Wrapper>
@@ -150,6 +179,7 @@ describe("DashboardPage", () => {
 
   it("shows loading skeleton while fetching", () => {
     mockListPayments.mockReturnValue(new Promise(() => {}));
+    mockGetPaymentSummary.mockReturnValue(new Promise(() => {}));
     const Wrapper = createWrapper();
     render(
       <Wrapper>
@@ -164,6 +194,7 @@ describe("DashboardPage", () => {
 
   it("renders summary cards after data loads", async () => {
     mockListPayments.mockResolvedValue(mockPaymentData);
+    mockGetPaymentSummary.mockResolvedValue(mockSummaryData);
     const Wrapper = createWrapper();
     render(
       <Wrapper>
@@ -187,6 +218,7 @@ describe("DashboardPage", () => {
 
   it("renders the Quick Access section with Payments link", () => {
     mockListPayments.mockReturnValue(new Promise(() => {}));
+    mockGetPaymentSummary.mockReturnValue(new Promise(() => {}));
     const Wrapper = createWrapper();
     render(
       <Wrapper>
@@ -201,6 +233,7 @@ describe("DashboardPage", () => {
 
   it("renders recent transactions table after data loads", async () => {
     mockListPayments.mockResolvedValue(mockPaymentData);
+    mockGetPaymentSummary.mockResolvedValue(mockSummaryData);
     const Wrapper = createWrapper();
     render(
       <Wrapper>
@@ -219,6 +252,7 @@ describe("DashboardPage", () => {
 
   it("shows error card when API fails", async () => {
     mockListPayments.mockRejectedValue(new Error("Network Error"));
+    mockGetPaymentSummary.mockRejectedValue(new Error("Network Error"));
     const Wrapper = createWrapper();
     render(
       <Wrapper>
@@ -237,6 +271,7 @@ describe("DashboardPage", () => {
 
   it("renders View All link in recent transactions", async () => {
     mockListPayments.mockResolvedValue(mockPaymentData);
+    mockGetPaymentSummary.mockResolvedValue(mockSummaryData);
     const Wrapper = createWrapper();
     render(
       <Wrapper>
@@ -4,7 +4,10 @@
 
 import { useDashboardPage } from "@/pages/Dashboard/useDashboardPage";
 import * as paymentServiceModule from "@/services/paymentService";
-import type { PaymentListResponse } from "@/types/payment";
+import type {
+  PaymentListResponse,
+  PaymentSummaryResponse,
+} from "@/types/payment";
 import { QueryClient, QueryClientProvider } from "@tanstack/react-query";
 import { renderHook, waitFor } from "@testing-library/react";
 import type { ReactNode } from "react";
@@ -13,12 +16,16 @@ import type { ReactNode } from "react";
 vi.mock("@/services/paymentService", () => ({
   paymentService: {
     listPayments: vi.fn(),
+    getPaymentSummary: vi.fn(),
   },
 }));
 
 const mockListPayments = vi.mocked(
   paymentServiceModule.paymentService.listPayments,
 );
+const mockGetPaymentSummary = vi.mocked(
+  paymentServiceModule.paymentService.getPaymentSummary,
+);
 
 function createWrapper() {
   const queryClient = new QueryClient({
@@ -87,13 +94,42 @@ const mockPaymentResponse: PaymentListResponse = {
   ],
 };
 
+const mockSummaryResponse: PaymentSummaryResponse = {
+  report_currency: "IDR",
+  from: "2026-01-22",
+  to: "2026-02-20",
+  total: 7,
+  completed: 3,
+  processing: 2,
+  failed: 2,
+  days: [
+    {
+      date: "2026-02-19",
+      total: "75000.00",
+      completed: "0.00",
+      processing: "75000.00",
+      failed: "0.00",
+    },
+    {
+      date: "2026-02-20",
+      total: "50000.49",
+      completed: "50000.49",
+      processing: "0.00",
+      failed: "0.00",
+    },
+  ],
+  unconverted: [],
+};
+
 describe("useDashboardPage", () => {
   beforeEach(() => {
     vi.clearAllMocks();
+    mockListPayments.mockResolvedValue(mockPaymentResponse);
+    mockGetPaymentSummary.mockResolvedValue(mockSummaryResponse);
   });
 
   it("returns null summary while loading", () => {
-    mockListPayments.mockReturnValue(new Promise(() => {})); // never resolves
+    mockGetPaymentSummary.mockReturnValue(new Promise(() => {})); // never resolves
     const { result } = renderHook(() => useDashboardPage(), {
       wrapper: createWrapper(),
     });
@@ -102,9 +138,7 @@ describe("useDashboardPage", () => {
     expect(result.current.summary).toBeNull();
   });
 
-  it("calculates correct summary totals", async () => {
-    mockListPayments.mockResolvedValue(mockPaymentResponse);
-
+  it("takes the counts from the server-side summary", async () => {
     const { result } = renderHook(() => useDashboardPage(), {
       wrapper: createWrapper(),
     });
@@ -113,13 +147,15 @@ describe("useDashboardPage", () => {
 
     expect(result.current.summary).not.toBeNull();
     expect(result.current.summary!.total).toBe(7);
-    expect(result.current.summary!.completed).toBe(3); // pay_001, pay_004, pay_005
-    expect(result.current.summary!.processing).toBe(2); // pay_002, pay_006
-    expect(result.current.summary!.failed).toBe(2); // pay_003, pay_007
+    expect(result.current.summary!.completed).toBe(3);
+    expect(result.current.summary!.processing).toBe(2);
+    expect(result.current.summary!.failed).toBe(2);
   });
 
-  it("returns top 5 recent transactions sorted by date descending", async () => {
-    mockListPayments.mockResolvedValue(mockPaymentResponse);
+  it("asks for the five latest payments as recent transactions", async () => {
+    mockListPayments.mockResolvedValue({
+      payments: mockPaymentResponse.payments.slice(0, 5),
+    });
 
     const { result } = renderHook(() => useDashboardPage(), {
       wrapper: createWrapper(),
@@ -127,18 +163,33 @@ describe("useDashboardPage", () => {
 
     await waitFor(() => expect(result.current.isLoading).toBe(false));
 
+    expect(mockListPayments).toHaveBeenCalledWith({
+      sort: "-created_at",
+      limit: 5,
+    });
     const recent = result.current.summary!.recentTransactions;
-    expect(recent).toHaveLength(5);
-    // Should be sorted by date descending, so first is most recent
-    expect(recent[0].id).toBe("pay_001");
-    expect(recent[1].id).toBe("pay_002");
-    expect(recent[2].id).toBe("pay_003");
-    expect(recent[3].id).toBe("pay_004");
-    expect(recent[4].id).toBe("pay_005");
+    expect(recent.map((p) => p.id)).toEqual([
+      "pay_001",
+      "pay_002",
+      "pay_003",
+      "pay_004",
+      "pay_005",
+    ]);
   });
 
-  it("returns null summary when payments data is empty", async () => {
+  it("returns zero counts when there are no payments", async () => {
     mockListPayments.mockResolvedValue({ payments: [] });
+    mockGetPaymentSummary.mockResolvedValue({
+      report_currency: "IDR",
+      from: "2026-01-22",
+      to: "2026-02-20",
+      total: 0,
+      completed: 0,
+      processing: 0,
+      failed: 0,
+      days: [],
+      unconverted: [],
+    });
 
     const { result } = renderHook(() => useDashboardPage(), {
       wrapper: createWrapper(),
@@ -146,16 +197,43 @@ describe("useDashboardPage", () => {
 
     await waitFor(() => expect(result.current.isLoading).toBe(false));
 
-    // When payments array is empty, total should be 0
     expect(result.current.summary).not.toBeNull();
     expect(result.current.summary!.total).toBe(0);
-    expect(result.current.summary!.completed).toBe(0);
-    expect(result.current.summary!.processing).toBe(0);
-    expect(result.current.summary!.failed).toBe(0);
+    expect(result.current.summary!.recentTransactions).toHaveLength(0);
+    expect(result.current.chartData).toHaveLength(0);
+  });
+
+  it("requests totals in IDR and charts one point per day", async () => {
+    const { result } = renderHook(() => useDashboardPage(), {
+      wrapper: createWrapper(),
+    });
+
+    await waitFor(() => expect(result.current.isLoading).toBe(false));
+
+    expect(mockGetPaymentSummary).toHaveBeenCalledWith({
+      report_currency: "IDR",
+      tz: expect.any(String),
+    });
+    expect(result.current.chartData).toEqual([
+      {
+        date: "Feb 19",
+        total: 75000,
+        completed: 0,
+        processing: 75000,
+        failed: 0,
+      },
+      {
+        date: "Feb 20",
+        total: 50000,
+        completed: 50000,
+        processing: 0,
+        failed: 0,
+      },
+    ]);
   });
 
   it("returns error when query fails", async () => {
-    mockListPayments.mockRejectedValue(new Error("API Error"));
+    mockGetPaymentSummary.mockRejectedValue(new Error("API Error"));
 
     const { result } = renderHook(() => useDashboardPage(), {
       wrapper: createWrapper(),
@@ -167,4 +245,21 @@ describe("useDashboardPage", () => {
     expect(result.current.error!.message).toBe("API Error");
     expect(result.current.summary).toBeNull();
   });
+
+  it("passes on the payments that could not be converted", async () => {
+    mockGetPaymentSummary.mockResolvedValue({
+      ...mockSummaryResponse,
+      unconverted: [{ currency: "SGD", count: 2, amount: "120.50" }],
+    });
+
+    const { result } = renderHook(() => useDashboardPage(), {
+      wrapper: createWrapper(),
+    });
+
+    await waitFor(() => expect(result.current.isLoading).toBe(false));
+
+    expect(result.current.summary!.unconverted).toEqual([
+      { currency: "SGD", count: 2, amount: "120.50" },
+    ]);
+  });
 });
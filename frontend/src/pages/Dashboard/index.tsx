@@ -68,6 +68,7 @@ function RecentTransactionsTable({
     merchant: string;
     status: "completed" | "processing" | "failed";
     amount: string;
+    currency?: string;
     created_at: string;
   }>;
 }) {
@@ -99,7 +100,7 @@ function RecentTransactionsTable({
             <tr key={tx.id} className="border-b hover:bg-gray-50">
               <td className="px-4 py-3 text-sm">{tx.merchant}</td>
               <td className="px-4 py-3 text-sm font-medium">
-                {formatCurrency(tx.amount)}
+                {formatCurrency(tx.amount, tx.currency)}
               </td>
               <td className="px-4 py-3 text-sm">
                 <span
@@ -138,9 +139,10 @@ export default function DashboardPage() {
       </div>
       {/* Payments Summary Section */}
       <div className="mb-8">
-        <h2 className="text-foreground mb-4 text-xl font-semibold">
+        <h2 className="text-foreground text-xl font-semibold">
           Payment Overview
         </h2>
+        <p className="text-foreground-muted mb-4 text-sm">Last 30 days</p>
         {isLoading && <SummarySkeleton />}
         {error && (
           <Card className="border-red-200 bg-red-50">
@@ -197,6 +199,17 @@ export default function DashboardPage() {
             <SummaryCard label="Failed" value={summary.failed} color="red" />
           </div>
         )}
+        {summary && !isLoading && summary.unconverted.length > 0 && (
+          <p className="text-foreground-muted mt-4 text-sm">
+            Not in the chart, for want of an IDR exchange rate:{" "}
+            {summary.unconverted
+              .map(
+                (u) =>
+                  `${u.count} payment${u.count === 1 ? "" : "s"} totalling ${formatCurrency(u.amount, u.currency)}`,
+              )
+              .join(", ")}
+          </p>
+        )}
       </div>
       {/* Payments Chart Section */}
       <div className="mb-8">
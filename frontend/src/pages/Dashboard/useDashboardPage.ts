@@ -3,11 +3,17 @@
  * Fetches and processes payment summary data for the dashboard
  */
 
-import { usePaymentListQuery } from "@/hooks/queries/usePaymentQuery";
+import {
+  usePaymentListQuery,
+  usePaymentSummaryQuery,
+} from "@/hooks/queries/usePaymentQuery";
 import type { PaymentChartDataPoint } from "@/pages/Payment/PaymentChart";
-import type { PaymentStatus } from "@/types/payment";
+import type { PaymentStatus, UnconvertedTotal } from "@/types/payment";
 import { useMemo } from "react";
 
+// how many of the latest payments the dashboard lists
+const RECENT_TRANSACTIONS = 5;
+
 /**
  * Payment summary statistics
  */
@@ -16,11 +22,14 @@ export interface PaymentSummary {
   completed: number;
   processing: number;
   failed: number;
+  // payments left out of the chart for want of an IDR rate
+  unconverted: UnconvertedTotal[];
   recentTransactions: Array<{
     id: string;
     merchant: string;
     status: PaymentStatus;
     amount: string;
+    currency?: string;
     created_at: string;
   }>;
 }
@@ -34,102 +43,62 @@ export function useDashboardPage(): {
   isLoading: boolean;
   error: Error | null;
 } {
-  // Fetch all payments (without filters to get full data)
-  const { data, isLoading, error } = usePaymentListQuery();
+  // Counts and daily totals of the last 30 days come from the server, with
+  // amounts in IDR so that payments in other currencies can be added up, and
+  // days in the browser's time zone
+  const summaryQuery = usePaymentSummaryQuery({
+    report_currency: "IDR",
+    tz: Intl.DateTimeFormat().resolvedOptions().timeZone,
+  });
+
+  // Only the five most recent payments are listed
+  const recentQuery = usePaymentListQuery({
+    sort: "-created_at",
+    limit: RECENT_TRANSACTIONS,
+  });
+
+  const totals = summaryQuery.data;
+  const recent = recentQuery.data?.payments;
 
   // Process data into summary
   const summary = useMemo(() => {
-    if (!data?.payments) return null;
-
-    const payments = data.payments;
-
-    // Count by status
-    const completed = payments.filter((p) => p.status === "completed").length;
-    const processing = payments.filter((p) => p.status === "processing").length;
-    const failed = payments.filter((p) => p.status === "failed").length;
-    const total = payments.length;
-
-    // Get recent transactions (sorted by created_at descending, limit 5)
-    const recentTransactions = payments
-      .sort(
-        (a, b) =>
-          new Date(b.created_at).getTime() - new Date(a.created_at).getTime(),
-      )
-      .slice(0, 5);
+    if (!totals || !recent) return null;
 
     return {
-      total,
-      completed,
-      processing,
-      failed,
-      recentTransactions,
+      total: totals.total,
+      completed: totals.completed,
+      processing: totals.processing,
+      failed: totals.failed,
+      unconverted: totals.unconverted,
+      recentTransactions: recent,
     };
-  }, [data?.payments]);
-
-  const payments = data?.payments;
+  }, [totals, recent]);
 
-  // Compute chart data: group payments by date, aggregate amounts
+  // Compute chart data: one point per day, amounts rounded to whole units
   const chartData: PaymentChartDataPoint[] = useMemo(() => {
-    if (!payments) return [];
-
-    const grouped = new Map<
-      string,
-      {
-        timestamp: number;
-        total: number;
-        completed: number;
-        processing: number;
-        failed: number;
-      }
-    >();
-
-    for (const p of payments) {
-      const d = new Date(p.created_at);
-      const dateKey = d.toLocaleDateString("en-US", {
-        month: "short",
-        day: "numeric",
-      });
-      const amount = parseFloat(p.amount) || 0;
-
-      if (!grouped.has(dateKey)) {
-        // Use midnight of that date as the sort key
-        const midnight = new Date(
-          d.getFullYear(),
-          d.getMonth(),
-          d.getDate(),
-        ).getTime();
-        grouped.set(dateKey, {
-          timestamp: midnight,
-          total: 0,
-          completed: 0,
-          processing: 0,
-          failed: 0,
-        });
-      }
-
-      const entry = grouped.get(dateKey)!;
-      entry.total += amount;
-      if (p.status === "completed") entry.completed += amount;
-      else if (p.status === "processing") entry.processing += amount;
-      else if (p.status === "failed") entry.failed += amount;
-    }
-
-    // Sort by date chronologically
-    return Array.from(grouped.entries())
-      .sort((a, b) => a[1].timestamp - b[1].timestamp)
-      .map(([date, values]) => ({
-        date,
-        total: Math.round(values.total),
-        completed: Math.round(values.completed),
-        processing: Math.round(values.processing),
-        failed: Math.round(values.failed),
-      }));
-  }, [payments]);
+    if (!totals) return [];
+
+    return totals.days.map((day) => {
+      const [year, month, date] = day.date.split("-").map(Number);
+      return {
+        date: new Date(year, month - 1, date).toLocaleDateString("en-US", {
+          month: "short",
+          day: "numeric",
+        }),
+        total: Math.round(parseFloat(day.total) || 0),
+        completed: Math.round(parseFloat(day.completed) || 0),
+        processing: Math.round(parseFloat(day.processing) || 0),
+        failed: Math.round(parseFloat(day.failed) || 0),
+      };
+    });
+  }, [totals]);
+
+  const error = summaryQuery.error ?? recentQuery.error;
 
   return {
     summary,
     chartData,
-    isLoading,
+    isLoading: summaryQuery.isLoading || recentQuery.isLoading,
     error: error instanceof Error ? error : null,
   };
 }
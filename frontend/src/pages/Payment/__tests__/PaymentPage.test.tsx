@@ -6,7 +6,7 @@ import PaymentPage from "@/pages/Payment/index";
 import * as paymentServiceModule from "@/services/paymentService";
 import type { PaymentListResponse } from "@/types/payment";
 import { QueryClient, QueryClientProvider } from "@tanstack/react-query";
-import { render, screen } from "@testing-library/react";
+import { render, screen, waitFor } from "@testing-library/react";
 import type { ReactNode } from "react";
 
 vi.mock("@/services/paymentService", () => ({
@@ -138,4 +138,24 @@ describe("PaymentPage", () => {
     // Check that the table headers eventually appear
     expect(screen.getByText("Payments")).toBeInTheDocument();
   });
+
+  it("renders previous and next page buttons", async () => {
+    mockListPayments.mockResolvedValue({
+      ...mockPaymentData,
+      has_more: true,
+      next_cursor: "c1",
+    });
+    const Wrapper = createWrapper();
+    render(
+      <Wrapper>
+        <PaymentPage />
+      </Wrapper>,
+    );
+
+    expect(await screen.findByText("Page 1")).toBeInTheDocument();
+    expect(screen.getByLabelText("Previous page")).toBeDisabled();
+    await waitFor(() =>
+      expect(screen.getByLabelText("Next page")).toBeEnabled(),
+    );
+  });
 });
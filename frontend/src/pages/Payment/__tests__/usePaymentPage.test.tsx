@@ -2,7 +2,10 @@
  * Tests for usePaymentPage hook
  */
 
-import { usePaymentPage } from "@/pages/Payment/usePaymentPage";
+import {
+  PAYMENT_PAGE_SIZE,
+  usePaymentPage,
+} from "@/pages/Payment/usePaymentPage";
 import * as paymentServiceModule from "@/services/paymentService";
 import type { PaymentListResponse } from "@/types/payment";
 import { QueryClient, QueryClientProvider } from "@tanstack/react-query";
@@ -116,6 +119,91 @@ describe("usePaymentPage", () => {
     expect(rows[0].original.Status).toBe("completed");
   });
 
+  it("fetches one page at a time and moves by cursor", async () => {
+    const [first, second, third] = mockPaymentData.payments;
+    mockListPayments.mockImplementation(async (params) =>
+      params?.cursor === "c1"
+        ? { payments: [third], has_more: false }
+        : { payments: [first, second], has_more: true, next_cursor: "c1" },
+    );
+
+    const { result } = renderHook(() => usePaymentPage(), {
+      wrapper: createWrapper(),
+    });
+
+    await waitFor(() => expect(result.current.isLoading).toBe(false));
+
+    expect(mockListPayments).toHaveBeenCalledTimes(1);
+    expect(mockListPayments).toHaveBeenLastCalledWith({
+      limit: PAYMENT_PAGE_SIZE,
+      cursor: undefined,
+    });
+    expect(result.current.tableData.map((row) => row.ID)).toEqual([
+      "pay_001",
+      "pay_002",
+    ]);
+    expect(result.current.hasPreviousPage).toBe(false);
+    expect(result.current.hasNextPage).toBe(true);
+
+    act(() => {
+      result.current.handleNextPage();
+    });
+    await waitFor(() =>
+      expect(result.current.tableData.map((row) => row.ID)).toEqual([
+        "pay_003",
+      ]),
+    );
+    expect(mockListPayments).toHaveBeenLastCalledWith({
+      limit: PAYMENT_PAGE_SIZE,
+      cursor: "c1",
+    });
+    expect(result.current.page).toBe(1);
+    expect(result.current.hasPreviousPage).toBe(true);
+    expect(result.current.hasNextPage).toBe(false);
+
+    act(() => {
+      result.current.handlePreviousPage();
+    });
+    await waitFor(() =>
+      expect(result.current.tableData.map((row) => row.ID)).toEqual([
+        "pay_001",
+        "pay_002",
+      ]),
+    );
+    expect(result.current.page).toBe(0);
+  });
+
+  it("goes back to the first page when the filters change", async () => {
+    mockListPayments.mockResolvedValue({
+      ...mockPaymentData,
+      has_more: true,
+      next_cursor: "c1",
+    });
+
+    const { result } = renderHook(() => usePaymentPage(), {
+      wrapper: createWrapper(),
+    });
+
+    await waitFor(() => expect(result.current.hasNextPage).toBe(true));
+    act(() => {
+      result.current.handleNextPage();
+    });
+    expect(result.current.page).toBe(1);
+
+    act(() => {
+      result.current.handleStatusChange("failed");
+    });
+
+    expect(result.current.page).toBe(0);
+    await waitFor(() =>
+      expect(mockListPayments).toHaveBeenLastCalledWith({
+        status: "failed",
+        limit: PAYMENT_PAGE_SIZE,
+        cursor: undefined,
+      }),
+    );
+  });
+
   it("initializes with empty filters", async () => {
     const { result } = renderHook(() => usePaymentPage(), {
       wrapper: createWrapper(),
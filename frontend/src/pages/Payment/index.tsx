@@ -8,6 +8,7 @@ import { DataTable } from "@/components/common/DataTable";
 import { Dropdown } from "@/components/inputs";
 import { PAYMENT_STATUS_OPTIONS, SORT_OPTIONS } from "@/constants";
 import { usePaymentPage } from "@/hooks";
+import { ChevronLeftIcon, ChevronRightIcon } from "@heroicons/react/24/outline";
 
 export default function PaymentPage() {
   const {
@@ -15,11 +16,17 @@ export default function PaymentPage() {
     table,
     filters,
     sortBy,
+    page,
+    hasPreviousPage,
+    hasNextPage,
     isLoading,
+    isFetching,
     error,
     handleStatusChange,
     handleSortChange,
     handleResetFilters,
+    handlePreviousPage,
+    handleNextPage,
   } = usePaymentPage();
 
   return (
@@ -118,10 +125,34 @@ export default function PaymentPage() {
             table={table}
             columns={columns}
             isLoading={isLoading}
-            showPagination={true}
+            isTableDataLoading={isFetching && !isLoading}
+            showPagination={false}
             showSearch={false}
             emptyMessage="No payments found"
           />
+
+          {/* Pagination: the API pages by cursor, so only previous and next */}
+          <div className="border-border bg-background-surface mt-4 flex items-center justify-end gap-2 rounded-lg border p-3">
+            <Button
+              variant="secondary"
+              onClick={handlePreviousPage}
+              disabled={!hasPreviousPage || isFetching}
+              className="p-2"
+              aria-label="Previous page"
+            >
+              <ChevronLeftIcon className="h-5 w-5" />
+            </Button>
+            <span className="text-foreground text-sm">Page {page + 1}</span>
+            <Button
+              variant="secondary"
+              onClick={handleNextPage}
+              disabled={!hasNextPage || isFetching}
+              className="p-2"
+              aria-label="Next page"
+            >
+              <ChevronRightIcon className="h-5 w-5" />
+            </Button>
+          </div>
         </CardContent>
       </Card>
     </div>
@@ -37,7 +37,11 @@ export interface UsePaymentPageResult {
   // State
   filters: PaymentListParams;
   sortBy: string;
+  page: number;
+  hasPreviousPage: boolean;
+  hasNextPage: boolean;
   isLoading: boolean;
+  isFetching: boolean;
   error: Error | null;
 
   // Handlers
@@ -45,8 +49,13 @@ export interface UsePaymentPageResult {
   handleIDChange: (value: string) => void;
   handleSortChange: (value: string) => void;
   handleResetFilters: () => void;
+  handlePreviousPage: () => void;
+  handleNextPage: () => void;
 }
 
+// rows per page fetched from the API
+export const PAYMENT_PAGE_SIZE = 10;
+
 /**
  * Custom hook for payment page business logic
  */
@@ -60,12 +69,18 @@ const statusColors: Record<PaymentStatus, { bg: string; text: string }> = {
 export function usePaymentPage(): UsePaymentPageResult {
   const [filters, setFilters] = useState<PaymentListParams>({});
   const [sortBy, setSortBy] = useState<string>("");
+  // cursors[i] fetches page i; the first page needs none
+  const [cursors, setCursors] = useState<(string | undefined)[]>([undefined]);
+  const page = cursors.length - 1;
 
-  // Fetch payments with current filters
-  const { data, isLoading, error } = usePaymentListQuery({
+  // Fetch the current page with current filters; the server pages by cursor
+  const { data, isLoading, isFetching, error } = usePaymentListQuery({
     ...filters,
     ...(sortBy && { sort: sortBy }),
+    limit: PAYMENT_PAGE_SIZE,
+    cursor: cursors[page],
   });
+  const nextCursor = data?.has_more ? data.next_cursor : undefined;
 
   // Prepare table data
   const tableData: PaymentTableRow[] = useMemo(() => {
@@ -75,7 +90,7 @@ export function usePaymentPage(): UsePaymentPageResult {
         ID: payment.id,
         Merchant: payment.merchant,
         Status: payment.status,
-        Amount: formatCurrency(payment.amount),
+        Amount: formatCurrency(payment.amount, payment.currency),
         "Created At": new Date(payment.created_at).toLocaleDateString("en-US", {
           year: "numeric",
           month: "short",
@@ -83,7 +98,7 @@ export function usePaymentPage(): UsePaymentPageResult {
         }),
       })) || []
     );
-  }, [data?.payments]);
+  }, [data]);
 
   // Define columns for the DataTable
   const columns: ColumnDef<PaymentTableRow, unknown>[] = useMemo(
@@ -123,15 +138,20 @@ export function usePaymentPage(): UsePaymentPageResult {
     [],
   );
 
-  // Setup DataTable hook
+  // Setup DataTable hook; the server already cut the page
   const { table } = useDataTable({
     data: tableData,
     columns,
-    initialPageSize: 10,
+    manualPagination: true,
+    initialPageSize: PAYMENT_PAGE_SIZE,
   });
 
+  // Cursors only hold for the filters and sort they were issued for
+  const resetPages = () => setCursors([undefined]);
+
   // Handle filter changes
   const handleStatusChange = (value: string) => {
+    resetPages();
     setFilters((prev) => ({
       ...prev,
       status: value || undefined,
@@ -139,6 +159,7 @@ export function usePaymentPage(): UsePaymentPageResult {
   };
 
   const handleIDChange = (value: string) => {
+    resetPages();
     setFilters((prev) => ({
       ...prev,
       id: value || undefined,
@@ -146,14 +167,26 @@ export function usePaymentPage(): UsePaymentPageResult {
   };
 
   const handleSortChange = (value: string) => {
+    resetPages();
     setSortBy(value);
   };
 
   const handleResetFilters = () => {
+    resetPages();
     setFilters({});
     setSortBy("");
   };
 
+  const handlePreviousPage = () => {
+    setCursors((prev) => (prev.length > 1 ? prev.slice(0, -1) : prev));
+  };
+
+  const handleNextPage = () => {
+    if (nextCursor) {
+      setCursors((prev) => [...prev, nextCursor]);
+    }
+  };
+
   return {
     // Data
     tableData,
@@ -163,7 +196,11 @@ export function usePaymentPage(): UsePaymentPageResult {
     // State
     filters,
     sortBy,
+    page,
+    hasPreviousPage: page > 0,
+    hasNextPage: !!nextCursor,
     isLoading,
+    isFetching,
     error: error instanceof Error ? error : null,
 
     // Handlers
@@ -171,5 +208,7 @@ export function usePaymentPage(): UsePaymentPageResult {
     handleIDChange,
     handleSortChange,
     handleResetFilters,
+    handlePreviousPage,
+    handleNextPage,
   };
 }
@@ -4,8 +4,21 @@
  */
 
 import { API_ENDPOINTS } from "@/constants";
-import type { PaymentListParams, PaymentListResponse } from "@/types/payment";
-import { httpGet } from "@/utils/httpClient";
+import type {
+  NewPayment,
+  Payment,
+  PaymentDetail,
+  PaymentEventListResponse,
+  PaymentListParams,
+  PaymentListResponse,
+  PaymentSummaryParams,
+  PaymentSummaryResponse,
+  PaymentStatusUpdate,
+  Refund,
+  RefundListResponse,
+  RefundRequest,
+} from "@/types/payment";
+import { httpGet, httpPatch, httpPost } from "@/utils/httpClient";
 
 export const paymentService = {
   // Get list of payments with optional filters and sorting
@@ -17,9 +30,90 @@ export const paymentService = {
         ? {
             ...(params.status && { status: params.status }),
             ...(params.id && { id: params.id }),
+            ...(params.created_from && { created_from: params.created_from }),
+            ...(params.created_to && { created_to: params.created_to }),
+            ...(params.currency && { currency: params.currency }),
+            ...(params.amount_min && { amount_min: params.amount_min }),
+            ...(params.amount_max && { amount_max: params.amount_max }),
+            ...(params.merchant && { merchant: params.merchant }),
+            ...(params.merchant_prefix && {
+              merchant_prefix: params.merchant_prefix,
+            }),
+            ...(params.q && { q: params.q }),
+            ...(params.report_currency && {
+              report_currency: params.report_currency,
+            }),
             ...(params.sort && { sort: params.sort }),
+            ...(params.limit && { limit: params.limit }),
+            ...(params.cursor && { cursor: params.cursor }),
           }
         : {},
     });
   },
+
+  // Count all payments by status and total them per day, server-side
+  getPaymentSummary: async (
+    params: PaymentSummaryParams,
+  ): Promise<PaymentSummaryResponse> => {
+    return httpGet<PaymentSummaryResponse>(API_ENDPOINTS.PAYMENT.SUMMARY, {
+      params,
+    });
+  },
+
+  // Get a single payment by id, with its history and refunds
+  getPayment: async (id: string): Promise<PaymentDetail> => {
+    return httpGet<PaymentDetail>(API_ENDPOINTS.PAYMENT.DETAIL(id));
+  },
+
+  // Get a payment's history, oldest first
+  listPaymentEvents: async (id: string): Promise<PaymentEventListResponse> => {
+    return httpGet<PaymentEventListResponse>(API_ENDPOINTS.PAYMENT.EVENTS(id));
+  },
+
+  // Record a payment; retrying with the same idempotency key never creates a second one
+  createPayment: async (
+    payment: NewPayment,
+    idempotencyKey: string,
+  ): Promise<Payment> => {
+    return httpPost<Payment>(API_ENDPOINTS.PAYMENT.LIST, payment, {
+      headers: { "Idempotency-Key": idempotencyKey },
+    });
+  },
+
+  // Change a payment's status; version is the one the decision was based on
+  updatePaymentStatus: async (
+    id: string,
+    version: number,
+    update: PaymentStatusUpdate,
+  ): Promise<Payment> => {
+    return httpPatch<Payment>(API_ENDPOINTS.PAYMENT.STATUS(id), update, {
+      headers: { "If-Match": `"${version}"` },
+    });
+  },
+
+  // Get a payment's refunds, oldest first
+  listRefunds: async (id: string): Promise<RefundListResponse> => {
+    return httpGet<RefundListResponse>(API_ENDPOINTS.PAYMENT.REFUNDS(id));
+  },
+
+  // Request a full or partial refund of a completed payment
+  requestRefund: async (id: string, refund: RefundRequest): Promise<Refund> => {
+    return httpPost<Refund>(API_ENDPOINTS.PAYMENT.REFUNDS(id), refund);
+  },
+
+  // Approve a requested refund; it must be someone else's request
+  approveRefund: async (id: string, refundId: string): Promise<Refund> => {
+    return httpPost<Refund>(API_ENDPOINTS.PAYMENT.REFUND_APPROVE(id, refundId));
+  },
+
+  // Reject a requested refund
+  rejectRefund: async (
+    id: string,
+    refundId: string,
+    reason: string,
+  ): Promise<Refund> => {
+    return httpPost<Refund>(API_ENDPOINTS.PAYMENT.REFUND_REJECT(id, refundId), {
+      reason,
+    });
+  },
 };
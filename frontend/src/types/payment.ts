@@ -5,15 +5,151 @@ export interface Payment {
   merchant: string;
   status: PaymentStatus;
   amount: string;
+  currency?: string;
+  reference?: string;
+  notes?: string;
   created_at: string;
+  // goes up on every change; sent back as If-Match when changing the status
+  version?: number;
+  // sum of the approved refunds, and the amount less that sum
+  refunded_amount?: string;
+  net_amount?: string;
+  // only on search results: matched fields as escaped HTML with <mark> tags
+  highlights?: Record<string, string>;
+  // only with report_currency: the amount converted at the rate of created_at
+  report_amount?: string;
+  report_currency?: string;
+  fx_rate?: string;
 }
 
 export interface PaymentListParams {
   status?: string;
   id?: string;
+  created_from?: string;
+  created_to?: string;
+  currency?: string;
+  amount_min?: string;
+  amount_max?: string;
+  merchant?: string;
+  merchant_prefix?: string;
+  q?: string;
+  report_currency?: string;
   sort?: string;
+  limit?: number;
+  cursor?: string;
+}
+
+export interface PaymentEvent {
+  id: string;
+  payment_id: string;
+  type:
+    | "created"
+    | "status_changed"
+    | "refund_requested"
+    | "refund_approved"
+    | "refund_rejected";
+  // empty once the acting user is deleted; actor_email still names them
+  actor_id?: string;
+  actor_email: string;
+  source: "dashboard" | "api_key" | "impersonation";
+  old_value: string;
+  new_value: string;
+  reason: string;
+  // only on refund events
+  refund_id?: string;
+  created_at: string;
+}
+
+export interface PaymentEventListResponse {
+  events: PaymentEvent[];
+}
+
+export type RefundStatus = "requested" | "approved" | "rejected";
+
+export interface Refund {
+  id: string;
+  payment_id: string;
+  amount: string;
+  currency: string;
+  status: RefundStatus;
+  reason: string;
+  requested_by?: string;
+  requested_by_email: string;
+  decided_by?: string;
+  decided_by_email?: string;
+  created_at: string;
+  decided_at?: string;
+}
+
+export interface RefundListResponse {
+  refunds: Refund[];
+}
+
+// what GET /payments/{id} returns: the payment with its history and refunds
+export interface PaymentDetail extends Payment {
+  events: PaymentEvent[];
+  refunds: Refund[];
+}
+
+export interface RefundRequest {
+  // without it, everything that is left to refund
+  amount?: string;
+  reason: string;
+}
+
+export interface PaymentStatusUpdate {
+  status: PaymentStatus;
+  reason: string;
+}
+
+export interface NewPayment {
+  merchant: string;
+  amount: string;
+  currency: string;
+  reference?: string;
+  notes?: string;
 }
 
 export interface PaymentListResponse {
   payments: Payment[];
+  has_more?: boolean;
+  next_cursor?: string;
+}
+
+export interface PaymentSummaryParams {
+  report_currency: string;
+  // IANA time zone whose days the totals are grouped by; UTC without it
+  tz?: string;
+  // first and last day, YYYY-MM-DD in tz; the 30 days up to today without them
+  from?: string;
+  to?: string;
+}
+
+// amounts of the payments created on one day, in the report currency
+export interface PaymentDayTotals {
+  date: string; // YYYY-MM-DD
+  total: string;
+  completed: string;
+  processing: string;
+  failed: string;
+}
+
+// payments in one currency with no rate into the report currency
+export interface UnconvertedTotal {
+  currency: string;
+  count: number;
+  amount: string;
+}
+
+// counts of the payments from `from` through `to` by status, and their daily totals
+export interface PaymentSummaryResponse {
+  report_currency: string;
+  from: string;
+  to: string;
+  total: number;
+  completed: number;
+  processing: number;
+  failed: number;
+  days: PaymentDayTotals[];
+  unconverted: UnconvertedTotal[];
 }
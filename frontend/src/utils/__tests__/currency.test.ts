@@ -22,6 +22,12 @@ describe("formatCurrency", () => {
     expect(result).toContain("150");
   });
 
+  it("formats other currencies with their own symbol and decimals", () => {
+    const result = formatCurrency("15.99", "USD");
+    expect(result).toContain("US$");
+    expect(result).toContain("15,99");
+  });
+
   it("returns Rp0 for NaN input", () => {
     expect(formatCurrency("abc")).toBe("Rp0");
     expect(formatCurrency(NaN)).toBe("Rp0");
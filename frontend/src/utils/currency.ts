@@ -1,7 +1,18 @@
-export function formatCurrency(value: number | string): string {
+export function formatCurrency(
+  value: number | string,
+  currency = "IDR",
+): string {
   const numValue = typeof value === "string" ? parseFloat(value) : value;
   if (isNaN(numValue)) return "Rp0";
 
+  // other currencies keep their own symbol and decimals
+  if (currency !== "IDR") {
+    return new Intl.NumberFormat("id-ID", {
+      style: "currency",
+      currency,
+    }).format(numValue);
+  }
+
   return new Intl.NumberFormat("id-ID", {
     style: "currency",
     currency: "IDR",